@@ -2,6 +2,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -71,6 +73,104 @@ func clusterDestroyFlags(fs *flag.FlagSet) {
 	clusterFlagDryRun(fs, &store.DryRun)
 }
 
+func clusterImportFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.Import
+	clusterFlagDryRun(fs, &store.DryRun)
+
+	fs.BoolVar(
+		&store.AutoApprove,
+		"auto-approve",
+		false,
+		"import the discovered resources without asking",
+	)
+}
+
+func clusterForceUnlockFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.ForceUnlock
+
+	fs.DurationVar(
+		&store.MinAge,
+		"min-age",
+		15*time.Minute,
+		"minimum age of a lock to break it without --force",
+	)
+
+	fs.BoolVar(
+		&store.Force,
+		"force",
+		false,
+		"break locks younger than --min-age",
+	)
+
+	fs.BoolVar(
+		&store.AutoApprove,
+		"auto-approve",
+		false,
+		"break the lock without asking",
+	)
+}
+
+func clusterStateRestoreFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.State.Restore
+
+	fs.BoolVar(
+		&store.AutoApprove,
+		"auto-approve",
+		false,
+		"restore the backup without asking",
+	)
+}
+
+func clusterStateRmFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.State.Remove
+
+	fs.BoolVar(
+		&store.AutoApprove,
+		"auto-approve",
+		false,
+		"remove the resources from the state without asking",
+	)
+}
+
+func clusterStateMigrateFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.State.Migrate
+
+	fs.StringVar(
+		&store.FromProvider,
+		"from-provider",
+		"",
+		"provider to read the existing state with, defaults to the provider of the environment",
+	)
+
+	fs.StringVar(
+		&store.FromBucket,
+		"from-bucket",
+		"",
+		"bucket holding the existing state, defaults to the state bucket of the provider",
+	)
+
+	fs.StringVar(
+		&store.FromKey,
+		"from-key",
+		"",
+		"key of the existing state, defaults to the key of the current cluster",
+	)
+
+	fs.BoolVar(
+		&store.Force,
+		"force",
+		false,
+		"replace a state that already contains resources",
+	)
+
+	fs.BoolVar(
+		&store.AutoApprove,
+		"auto-approve",
+		false,
+		"migrate the state without asking",
+	)
+}
+
 func clusterImagesBuildFlags(fs *flag.FlagSet) {
 	store := &globalFlags.Cluster.Images.Build
 
@@ -120,6 +220,67 @@ func clusterLogsFlags(fs *flag.FlagSet) {
 	)
 }
 
+func clusterConfigurationRollbackFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.Configuration.Rollback
+
+	fs.StringVar(
+		&store.To,
+		"to",
+		"",
+		"hash of the manifest to roll back to, as listed by 'tarmak cluster configuration history'",
+	)
+
+	fs.StringVar(
+		&store.InstancePool,
+		"pool",
+		"",
+		"only roll back instances of this instance pool",
+	)
+
+	fs.BoolVar(
+		&store.WaitForConvergence,
+		"wait-for-convergence",
+		true,
+		"wait for wing convergence after rolling back",
+	)
+}
+
+func clusterInstancesInventoryFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.Instances.Inventory
+
+	fs.StringVar(
+		&store.InstancePool,
+		"pool",
+		"",
+		"only list instances of this instance pool",
+	)
+
+	fs.BoolVar(
+		&store.FailOnDrift,
+		"fail-on-drift",
+		false,
+		"exit with an error if versions differ between instances of an instance pool",
+	)
+}
+
+func clusterInstancesExecFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Cluster.Instances.Exec
+
+	fs.StringVar(
+		&store.InstancePool,
+		"pool",
+		"",
+		"only run the command on instances of this instance pool",
+	)
+
+	fs.DurationVar(
+		&store.Timeout,
+		"timeout",
+		5*time.Minute,
+		"kill the command on instances after this duration",
+	)
+}
+
 func clusterFlagDryRun(fs *flag.FlagSet, store *bool) {
 	fs.BoolVar(
 		store,
@@ -129,6 +290,115 @@ func clusterFlagDryRun(fs *flag.FlagSet, store *bool) {
 	)
 }
 
+func clusterInitFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Init
+
+	fs.StringVar(
+		&store.FromFile,
+		"from-file",
+		"",
+		"read the answers from a spec file, flags take precedence",
+	)
+
+	fs.StringVar(
+		&store.Provider,
+		"provider",
+		"",
+		"name of the provider to use or create",
+	)
+
+	fs.StringVar(
+		&store.Profile,
+		"profile",
+		"",
+		"AWS profile of a new provider",
+	)
+
+	fs.StringVar(
+		&store.VaultPath,
+		"vault-path",
+		"",
+		"Vault path for the AWS credentials of a new provider",
+	)
+
+	fs.StringVar(
+		&store.BucketPrefix,
+		"bucket-prefix",
+		"",
+		"prefix of the state buckets and DynamoDB tables of a new provider",
+	)
+
+	fs.StringVar(
+		&store.PublicZone,
+		"public-zone",
+		"",
+		"public DNS zone of a new provider",
+	)
+
+	fs.StringVar(
+		&store.Environment,
+		"environment",
+		"",
+		"name of the environment to use or create",
+	)
+
+	fs.StringVar(
+		&store.Project,
+		"project",
+		"",
+		"project name of a new environment",
+	)
+
+	fs.StringVar(
+		&store.Contact,
+		"contact",
+		"",
+		"contact mail address of a new environment",
+	)
+
+	fs.StringVar(
+		&store.Region,
+		"region",
+		"",
+		"region of a new environment",
+	)
+
+	fs.StringVar(
+		&store.ClusterType,
+		"cluster-type",
+		"",
+		"type of a new environment, single or multi",
+	)
+
+	fs.StringVar(
+		&store.ClusterName,
+		"cluster-name",
+		"",
+		"name of the cluster in a multi cluster environment",
+	)
+
+	fs.StringSliceVar(
+		&store.Zones,
+		"zones",
+		[]string{},
+		"availability zones of the cluster",
+	)
+
+	fs.StringSliceVar(
+		&store.InstancePoolCounts,
+		"instance-pool-count",
+		[]string{},
+		"instance count of a pool, like worker=3",
+	)
+
+	fs.StringSliceVar(
+		&store.InstancePoolSizes,
+		"instance-pool-size",
+		[]string{},
+		"instance size of a pool, like worker=large",
+	)
+}
+
 func clusterKubeconfigFlags(fs *flag.FlagSet) {
 	store := &globalFlags.Cluster.Kubeconfig
 
@@ -0,0 +1,15 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterConfigurationCmd = &cobra.Command{
+	Use:   "configuration",
+	Short: "Operations on the puppet configuration applied to instances",
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterConfigurationCmd)
+}
@@ -0,0 +1,39 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+)
+
+var clusterConfigurationHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Print the history of manifests uploaded to the cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		defer t.Cleanup()
+		history, err := t.Cluster().ConfigurationHistory()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		varMaps := make([]map[string]string, 0)
+		for _, entry := range history {
+			varMaps = append(varMaps, map[string]string{
+				"hash":      entry.Hash,
+				"timestamp": entry.Timestamp.String(),
+				"path":      entry.Path,
+			})
+		}
+		utils.ListParameters(os.Stdout, []string{"hash", "timestamp", "path"}, varMaps)
+	},
+}
+
+func init() {
+	clusterConfigurationCmd.AddCommand(clusterConfigurationHistoryCmd)
+}
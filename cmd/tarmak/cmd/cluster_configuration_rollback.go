@@ -0,0 +1,31 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterConfigurationRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Pin instances of the cluster to a previously uploaded manifest",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if globalFlags.Cluster.Configuration.Rollback.To == "" {
+			return fmt.Errorf("expecting a manifest hash to roll back to, use --to")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		defer t.Cleanup()
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ConfigurationRollback)
+	},
+}
+
+func init() {
+	clusterConfigurationRollbackFlags(clusterConfigurationRollbackCmd.PersistentFlags())
+	clusterConfigurationCmd.AddCommand(clusterConfigurationRollbackCmd)
+}
@@ -0,0 +1,15 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterEncryptionCmd = &cobra.Command{
+	Use:   "encryption",
+	Short: "Operations on the encryption of resources at rest",
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterEncryptionCmd)
+}
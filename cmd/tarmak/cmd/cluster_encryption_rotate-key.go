@@ -0,0 +1,21 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterEncryptionRotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Rotate the encryption key and re-encrypt all resources",
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).EncryptionRotateKey)
+	},
+}
+
+func init() {
+	clusterEncryptionCmd.AddCommand(clusterEncryptionRotateKeyCmd)
+}
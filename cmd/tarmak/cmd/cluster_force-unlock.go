@@ -12,9 +12,13 @@ import (
 var clusterForceUnlockCmd = &cobra.Command{
 	Use:   "force-unlock [lock ID]",
 	Short: "Remove remote lock using lock ID",
+	Long: `Breaks the lock held on the terraform state of the current cluster. The lock
+ID is optional, the lock is read from the lock table and shown before asking
+to break it. Locks younger than --min-age might still be held by a running
+operation and are only broken with --force.`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			return fmt.Errorf("expected single lock ID argument, got=%d", len(args))
+		if len(args) > 1 {
+			return fmt.Errorf("expected at most a single lock ID argument, got=%d", len(args))
 		}
 		return nil
 	},
@@ -25,5 +29,6 @@ var clusterForceUnlockCmd = &cobra.Command{
 }
 
 func init() {
+	clusterForceUnlockFlags(clusterForceUnlockCmd.PersistentFlags())
 	clusterCmd.AddCommand(clusterForceUnlockCmd)
 }
@@ -0,0 +1,27 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import existing resources into the terraform state of the current cluster",
+	Long: `Discovers existing resources of the current cluster by the tags and names
+tarmak would give them, like the VPC, subnets, bastion, vault instances,
+private zone and API load balancers. Resources that are not yet part of the
+terraform state are imported into the generated terraform code. Use --dry-run
+to only report what would be imported.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterImport)
+	},
+}
+
+func init() {
+	clusterImportFlags(clusterImportCmd.PersistentFlags())
+	clusterCmd.AddCommand(clusterImportCmd)
+}
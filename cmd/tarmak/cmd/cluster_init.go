@@ -11,6 +11,12 @@ var clusterInitCmd = &cobra.Command{
 	Use:     "init",
 	Aliases: []string{"initialise", "initialize"},
 	Short:   "Initialize a cluster",
+	Long: `Initializes a new cluster by asking for the provider, environment and
+cluster settings.
+
+If a spec file is given with --from-file or any of the answers is given as a
+flag, no questions are asked. Existing providers, environments and clusters
+with matching settings are reused, so the command can be run repeatedly.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		globalFlags.Initialize = true
 		t := tarmak.New(globalFlags)
@@ -19,5 +25,6 @@ var clusterInitCmd = &cobra.Command{
 }
 
 func init() {
+	clusterInitFlags(clusterInitCmd.PersistentFlags())
 	clusterCmd.AddCommand(clusterInitCmd)
 }
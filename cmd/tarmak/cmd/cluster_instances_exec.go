@@ -0,0 +1,31 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterInstancesExecCmd = &cobra.Command{
+	Use:   "exec [flags] -- [command] [arguments]",
+	Short: "Run a command on instances of the cluster through wing",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("expecting a command to run")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		defer t.Cleanup()
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).InstancesExec)
+	},
+}
+
+func init() {
+	clusterInstancesExecFlags(clusterInstancesExecCmd.PersistentFlags())
+	clusterInstancesCmd.AddCommand(clusterInstancesExecCmd)
+}
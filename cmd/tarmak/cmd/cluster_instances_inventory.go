@@ -0,0 +1,23 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterInstancesInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Print the facts reported by the instances of the cluster and detect version drift",
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		defer t.Cleanup()
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).InstancesInventory)
+	},
+}
+
+func init() {
+	clusterInstancesInventoryFlags(clusterInstancesInventoryCmd.PersistentFlags())
+	clusterInstancesCmd.AddCommand(clusterInstancesInventoryCmd)
+}
@@ -0,0 +1,15 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Operations on the lock of the terraform state of the current cluster",
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterLockCmd)
+}
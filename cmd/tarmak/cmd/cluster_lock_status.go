@@ -0,0 +1,24 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterLockStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the lock held on the terraform state of the current cluster",
+	Long: `Reads the lock held on the terraform state of the current cluster from the
+lock table and shows its ID, the operation holding it, who took it and when,
+and the versions of tarmak and terraform used.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterLockStatus)
+	},
+}
+
+func init() {
+	clusterLockCmd.AddCommand(clusterLockStatusCmd)
+}
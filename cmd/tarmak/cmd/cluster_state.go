@@ -0,0 +1,17 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Operations on the terraform state of the current cluster",
+	Long: `Inspect and change the terraform state of the current cluster. Commands
+changing the state back it up to the backups bucket of the environment first.`,
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterStateCmd)
+}
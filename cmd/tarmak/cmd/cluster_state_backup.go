@@ -0,0 +1,24 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterStateBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the terraform state of the current cluster",
+	Long: `Stores a copy of the terraform state of the current cluster in the backups
+bucket of the environment. Backups are named after the time they have been
+taken and expire with the lifecycle rules of the bucket.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterStateBackup)
+	},
+}
+
+func init() {
+	clusterStateCmd.AddCommand(clusterStateBackupCmd)
+}
@@ -0,0 +1,21 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterStateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the resources in the terraform state of the current cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterStateList)
+	},
+}
+
+func init() {
+	clusterStateCmd.AddCommand(clusterStateListCmd)
+}
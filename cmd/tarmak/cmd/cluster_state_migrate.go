@@ -0,0 +1,36 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterStateMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the terraform state of the current cluster from another location",
+	Long: `Copies the terraform state of the current cluster from another location into
+its remote state, after backing up the current state. The source defaults to
+where the state of the cluster would be stored by the provider given with
+--from-provider, use --from-bucket and --from-key to read from other buckets.
+The source state is left in place.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		flags := globalFlags.Cluster.State.Migrate
+		if flags.FromProvider == "" && flags.FromBucket == "" && flags.FromKey == "" {
+			return fmt.Errorf("expecting a source state, use --from-provider, --from-bucket or --from-key")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterStateMigrate)
+	},
+}
+
+func init() {
+	clusterStateMigrateFlags(clusterStateMigrateCmd.PersistentFlags())
+	clusterStateCmd.AddCommand(clusterStateMigrateCmd)
+}
@@ -0,0 +1,29 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterStateMvCmd = &cobra.Command{
+	Use:   "mv [source address] [destination address]",
+	Short: "Move a resource to a different address in the terraform state of the current cluster",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("expecting a source and a destination address, got=%d arguments", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterStateMove)
+	},
+}
+
+func init() {
+	clusterStateCmd.AddCommand(clusterStateMvCmd)
+}
@@ -0,0 +1,33 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterStateRestoreCmd = &cobra.Command{
+	Use:   "restore [backup name]",
+	Short: "Restore the terraform state of the current cluster from a backup",
+	Long: `Replaces the terraform state of the current cluster with one of its backups,
+after backing up the current state. Without a backup name the available
+backups are listed.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return fmt.Errorf("expecting at most a single backup name, got=%d", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterStateRestore)
+	},
+}
+
+func init() {
+	clusterStateRestoreFlags(clusterStateRestoreCmd.PersistentFlags())
+	clusterStateCmd.AddCommand(clusterStateRestoreCmd)
+}
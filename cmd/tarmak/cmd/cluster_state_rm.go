@@ -0,0 +1,33 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterStateRmCmd = &cobra.Command{
+	Use:   "rm [address...]",
+	Short: "Remove resources from the terraform state of the current cluster",
+	Long: `Removes resources from the terraform state of the current cluster, after
+backing up the state. The resources are not destroyed, but are no longer
+managed by tarmak.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("expecting at least one resource address")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterStateRemove)
+	},
+}
+
+func init() {
+	clusterStateRmFlags(clusterStateRmCmd.PersistentFlags())
+	clusterStateCmd.AddCommand(clusterStateRmCmd)
+}
@@ -0,0 +1,29 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var clusterStateShowCmd = &cobra.Command{
+	Use:   "show [address]",
+	Short: "Show the attributes of a resource in the terraform state of the current cluster",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("expecting a single resource address, got=%d", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		t.CancellationContext().WaitOrCancel(t.NewCmdTarmak(cmd.Flags(), args).ClusterStateShow)
+	},
+}
+
+func init() {
+	clusterStateCmd.AddCommand(clusterStateShowCmd)
+}
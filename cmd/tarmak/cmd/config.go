@@ -0,0 +1,27 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Operations on the tarmak configuration",
+}
+
+func configUpgradeFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Config.Upgrade
+
+	fs.BoolVar(
+		&store.AutoApprove,
+		"auto-approve",
+		false,
+		"write the upgraded configuration without asking",
+	)
+}
+
+func init() {
+	RootCmd.AddCommand(configCmd)
+}
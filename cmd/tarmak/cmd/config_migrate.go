@@ -0,0 +1,26 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Split tarmak.yaml into one file per provider, environment and cluster",
+	Long: `Moves the configuration from tarmak.yaml into the tarmak.d directory,
+with one file per provider, environment and cluster. The current cluster is
+stored in tarmak.local.yaml, which is not meant to be shared. The old
+tarmak.yaml is kept as tarmak.yaml.migrated.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		globalFlags.Initialize = true
+		t := tarmak.New(globalFlags)
+		t.Perform(t.CmdConfigMigrate())
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configMigrateCmd)
+}
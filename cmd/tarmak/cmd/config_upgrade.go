@@ -0,0 +1,26 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var configUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Rewrite the configuration using the latest schema versions",
+	Long: `Converts the configuration to the latest schema versions supported by
+this tarmak. The changes are shown before they are written. Configurations
+written by a newer tarmak are refused, upgrade tarmak instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		globalFlags.Initialize = true
+		t := tarmak.New(globalFlags)
+		t.Perform(t.CmdConfigUpgrade())
+	},
+}
+
+func init() {
+	configUpgradeFlags(configUpgradeCmd.PersistentFlags())
+	configCmd.AddCommand(configUpgradeCmd)
+}
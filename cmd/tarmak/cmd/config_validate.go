@@ -0,0 +1,22 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate all providers, environments and clusters without contacting the cloud provider",
+	Run: func(cmd *cobra.Command, args []string) {
+		globalFlags.Initialize = true
+		t := tarmak.New(globalFlags)
+		t.Perform(t.CmdConfigValidate())
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
@@ -23,6 +23,58 @@ func environmentDestroyFlags(fs *flag.FlagSet) {
 	)
 }
 
+func environmentDriftFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Environment.Drift
+
+	fs.StringVarP(
+		&store.Output,
+		"output",
+		"o",
+		"text",
+		"format of the drift report, text or json",
+	)
+}
+
+func environmentApplyFlags(fs *flag.FlagSet) {
+	store := &globalFlags.Environment.Apply
+
+	fs.BoolVar(
+		&store.AutoApprove,
+		"auto-approve",
+		false,
+		"apply the planned changes of all clusters without asking for approval",
+	)
+
+	fs.BoolVar(
+		&store.AutoApproveDeletingData,
+		"auto-approve-deleting-data",
+		false,
+		"auto approve deletion of any data as a cause from applying clusters",
+	)
+
+	fs.BoolVarP(
+		&store.WaitForConvergence,
+		"wait-for-convergence",
+		"W",
+		true,
+		"wait for wing convergence on applied instances",
+	)
+
+	fs.IntVar(
+		&store.Concurrency,
+		"concurrency",
+		1,
+		"number of clusters applied in parallel after the hub",
+	)
+
+	fs.BoolVar(
+		&store.ContinueOnFailure,
+		"continue-on-failure",
+		false,
+		"continue applying the remaining clusters if a cluster fails to apply",
+	)
+}
+
 func init() {
 	RootCmd.AddCommand(environmentCmd)
 }
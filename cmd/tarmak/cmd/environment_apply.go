@@ -0,0 +1,40 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+// environmentApplyCmd handles `tarmak environment apply`
+var environmentApplyCmd = &cobra.Command{
+	Use:   "apply [name]",
+	Short: "Apply the hub and all clusters of an environment",
+	Long: `Plans the hub and every cluster of the environment and asks once to
+apply all planned changes. The hub is applied first, if it fails no other
+cluster is applied. The remaining clusters are then applied with the given
+concurrency, after a failure no further clusters are started unless
+--continue-on-failure is set.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return errors.New("you can only give one environment name")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		defer t.Cleanup()
+
+		t.CancellationContext().WaitOrCancel(
+			t.NewCmdTarmak(cmd.Flags(), args).EnvironmentApply,
+		)
+	},
+}
+
+func init() {
+	environmentApplyFlags(environmentApplyCmd.PersistentFlags())
+	environmentCmd.AddCommand(environmentApplyCmd)
+}
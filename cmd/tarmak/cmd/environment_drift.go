@@ -0,0 +1,42 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/tarmak"
+)
+
+// environmentDriftCmd handles `tarmak environment drift`
+var environmentDriftCmd = &cobra.Command{
+	Use:   "drift [name]",
+	Short: "Detect changes made outside of tarmak to the hub and all clusters of an environment",
+	Long: `Plans the hub and every cluster of the environment without applying
+anything and reports resources that have been changed outside of tarmak, like
+edited security groups, resized autoscaling groups or deleted DNS records.
+Pending configuration changes are reported separately.
+
+The command exits with 0 if there is no drift, 2 if drift has been found and
+1 on errors, which makes it suitable for scheduled CI jobs.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return errors.New("you can only give one environment name")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := tarmak.New(globalFlags)
+		defer t.Cleanup()
+
+		t.CancellationContext().WaitOrCancelReturnCode(
+			t.NewCmdTarmak(cmd.Flags(), args).EnvironmentDrift,
+		)
+	},
+}
+
+func init() {
+	environmentDriftFlags(environmentDriftCmd.PersistentFlags())
+	environmentCmd.AddCommand(environmentDriftCmd)
+}
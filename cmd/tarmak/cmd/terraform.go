@@ -111,6 +111,75 @@ var terraformTaintCmd = &cobra.Command{
 	DisableFlagParsing: true,
 }
 
+var terraformImportCmd = &cobra.Command{
+	Use: "import",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(terraformPassthrough(args, terraform.Import))
+	},
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
+var terraformStateCmd = &cobra.Command{
+	Use:                "state",
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
+var terraformStateListCmd = &cobra.Command{
+	Use: "list",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(terraformPassthrough(args, terraform.StateList))
+	},
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
+var terraformStatePullCmd = &cobra.Command{
+	Use: "pull",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(terraformPassthrough(args, terraform.StatePull))
+	},
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
+var terraformStatePushCmd = &cobra.Command{
+	Use: "push",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(terraformPassthrough(args, terraform.StatePush))
+	},
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
+var terraformStateShowCmd = &cobra.Command{
+	Use: "show",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(terraformPassthrough(args, terraform.StateShow))
+	},
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
+var terraformStateMvCmd = &cobra.Command{
+	Use: "mv",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(terraformPassthrough(args, terraform.StateMv))
+	},
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
+var terraformStateRmCmd = &cobra.Command{
+	Use: "rm",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(terraformPassthrough(args, terraform.StateRm))
+	},
+	Hidden:             true,
+	DisableFlagParsing: true,
+}
+
 func init() {
 	RootCmd.AddCommand(internalPluginCmd)
 	terraformCmd.AddCommand(terraformInitCmd)
@@ -122,5 +191,13 @@ func init() {
 	terraformCmd.AddCommand(terraformFmtCmd)
 	terraformCmd.AddCommand(terraformValidateCmd)
 	terraformCmd.AddCommand(terraformTaintCmd)
+	terraformCmd.AddCommand(terraformImportCmd)
+	terraformStateCmd.AddCommand(terraformStateListCmd)
+	terraformStateCmd.AddCommand(terraformStatePullCmd)
+	terraformStateCmd.AddCommand(terraformStatePushCmd)
+	terraformStateCmd.AddCommand(terraformStateShowCmd)
+	terraformStateCmd.AddCommand(terraformStateMvCmd)
+	terraformStateCmd.AddCommand(terraformStateRmCmd)
+	terraformCmd.AddCommand(terraformStateCmd)
 	RootCmd.AddCommand(terraformCmd)
 }
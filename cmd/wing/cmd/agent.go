@@ -2,6 +2,9 @@
 package cmd
 
 import (
+	"os"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/jetstack/tarmak/pkg/wing"
@@ -23,6 +26,24 @@ func init() {
 	agentCmd.Flags().StringVar(&agentFlags.ServerURL, "server-url", "https://localhost:9443", "this specifies the URL to the wing server")
 	agentCmd.Flags().StringVar(&agentFlags.ManifestURL, "manifest-url", "", "this specifies the URL where the puppet.tar.gz can be found")
 	agentCmd.Flags().StringVar(&agentFlags.InstanceName, "instance-name", wing.DefaultInstanceName, "this specifies the instance's name")
+	agentCmd.Flags().StringVar(&agentFlags.InstancePool, "instance-pool", os.Getenv("WING_INSTANCE_POOL"), "this specifies the instance pool the instance belongs to")
+	agentCmd.Flags().StringVar(&agentFlags.CAFile, "ca-file", os.Getenv("WING_CA_FILE"), "this specifies the CA to verify the wing server, enables client certificate authentication")
+	agentCmd.Flags().StringVar(&agentFlags.PKIDir, "pki-dir", wing.DefaultPKIDir, "this specifies the directory to store the instance's client certificate")
+	agentCmd.Flags().StringVar(&agentFlags.MetricsBindAddress, "metrics-bind-address", wing.DefaultMetricsBindAddress, "this specifies the address to serve prometheus metrics on, empty disables metrics")
+	agentCmd.Flags().DurationVar(&agentFlags.ReconvergeInterval, "reconverge-interval", durationFromEnv("WING_RECONVERGE_INTERVAL"), "this specifies how often puppet is reapplied to revert drift, zero disables periodic reconverges")
+	agentCmd.Flags().DurationVar(&agentFlags.ReconvergeJitter, "reconverge-jitter", durationFromEnv("WING_RECONVERGE_JITTER"), "this specifies the maximum random delay added to the reconverge interval")
+	agentCmd.Flags().StringVar(&agentFlags.MaintenanceWindows, "maintenance-windows", os.Getenv("WING_MAINTENANCE_WINDOWS"), "this specifies the windows periodic reconverges are allowed in, separated by ';', e.g. 'Mon-Fri 01:00-05:00 Europe/London'")
+	agentCmd.Flags().StringVar(&agentFlags.ManifestPublicKeyFile, "manifest-public-key-file", os.Getenv("WING_MANIFEST_PUBLIC_KEY_FILE"), "this specifies the public key to verify manifest signatures against, manifests without a valid signature are rejected")
+	agentCmd.Flags().DurationVar(&agentFlags.FactsInterval, "facts-interval", wing.DefaultFactsInterval, "this specifies how often facts about the instance are reported, zero disables facts")
 
 	RootCmd.AddCommand(agentCmd)
 }
+
+// duration from an environment variable, invalid or missing values are zero
+func durationFromEnv(name string) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(name))
+	if err != nil {
+		return 0
+	}
+	return d
+}
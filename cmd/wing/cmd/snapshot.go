@@ -0,0 +1,92 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/tarmak/pkg/wing/etcd"
+)
+
+var snapshotEtcdOptions = &etcd.Options{}
+
+var snapshotForce bool
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and restore the state of the wing server",
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save [file]",
+	Short: "Save a snapshot of the wing server's state in etcd, writes to stdout without a file",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logrus.New()
+
+		var w io.Writer = os.Stdout
+		if len(args) > 0 {
+			f, err := os.Create(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		client, err := snapshotEtcdOptions.NewClient()
+		if err != nil {
+			log.Fatalf("error connecting to etcd: %s", err)
+		}
+		defer client.Close()
+
+		snapshot, err := etcd.Save(context.Background(), client, snapshotEtcdOptions.Prefix, w)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Infof("saved %d keys at revision %d", len(snapshot.Entries), snapshot.Revision)
+	},
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Short: "Restore a snapshot of the wing server's state to etcd",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logrus.New()
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		client, err := snapshotEtcdOptions.NewClient()
+		if err != nil {
+			log.Fatalf("error connecting to etcd: %s", err)
+		}
+		defer client.Close()
+
+		snapshot, err := etcd.Restore(context.Background(), client, snapshotEtcdOptions.Prefix, f, snapshotForce)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Infof("restored %d keys of snapshot taken at %s", len(snapshot.Entries), snapshot.CreationTimestamp)
+		log.Info("restart the wing server to refresh its caches")
+	},
+}
+
+func init() {
+	snapshotEtcdOptions.AddFlags(snapshotCmd.PersistentFlags())
+	snapshotRestoreCmd.Flags().BoolVar(&snapshotForce, "force", false, "replace existing state with the snapshot")
+
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	RootCmd.AddCommand(snapshotCmd)
+}
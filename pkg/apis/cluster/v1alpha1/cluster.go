@@ -44,9 +44,49 @@ const (
 	PrometheusModeExternalScrapeTargetsOnly = "ExternalScrapeTargetsOnly"
 )
 
+const (
+	AuditLogFormatJSON   = "json"
+	AuditLogFormatLegacy = "legacy"
+
+	AuditWebhookModeBatch    = "batch"
+	AuditWebhookModeBlocking = "blocking"
+)
+
+const (
+	EncryptionProviderAESCBC = "aescbc"
+	EncryptionProviderKMS    = "kms"
+)
+
+const (
+	NetworkingProviderCalico    = "calico"
+	NetworkingProviderCilium    = "cilium"
+	NetworkingProviderAmazonVPC = "aws-vpc-cni"
+
+	CiliumTunnelVXLAN  = "vxlan"
+	CiliumTunnelGeneve = "geneve"
+)
+
 const (
 	CalicoBackendEtcd       ClusterKubernetesCalicoBackend = "etcd"
 	CalicoBackendKubernetes ClusterKubernetesCalicoBackend = "kubernetes"
+
+	CalicoEncapsulationIPIP  = "ipip"
+	CalicoEncapsulationVXLAN = "vxlan"
+	CalicoEncapsulationNone  = "none"
+
+	CalicoEncapsulationModeAlways      = "always"
+	CalicoEncapsulationModeCrossSubnet = "cross-subnet"
+)
+
+const (
+	IngressServiceTypeLoadBalancer = "LoadBalancer"
+	IngressServiceTypeNodePort     = "NodePort"
+
+	IngressLoadBalancerTypeELB = "elb"
+	IngressLoadBalancerTypeNLB = "nlb"
+
+	PodIAMProviderKube2IAM = "kube2iam"
+	PodIAMProviderKiam     = "kiam"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -57,15 +97,15 @@ type Cluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	CloudId         string              `json:"cloudId,omitempty"`
+	CloudId         string              `json:"cloudId,omitempty"` // unused, removed by schema version 2
 	InstancePools   []InstancePool      `json:"instancePools,omitempty"`
-	Cloud           string              `json:"cloud,omitempty"`
+	Cloud           string              `json:"cloud,omitempty"` // unused, removed by schema version 2
 	Location        string              `json:"location,omitempty"`
 	Network         *Network            `json:"network,omitempty"`
 	LoggingSinks    []*LoggingSink      `json:"loggingSinks,omitempty"`
 	Values          *Values             `json:"values,omitempty"`
-	KubernetesAPI   *KubernetesAPI      `json:"kubernetesAPI,omitempty"`
-	GroupIdentifier string              `json:"groupIdentifier,omitempty"`
+	KubernetesAPI   *KubernetesAPI      `json:"kubernetesAPI,omitempty"`   // unused, removed by schema version 2
+	GroupIdentifier string              `json:"groupIdentifier,omitempty"` // unused, removed by schema version 2
 	VaultHelper     *ClusterVaultHelper `json:"vaultHelper,omitempty"`
 
 	Environment string             `json:"environment,omitempty"`
@@ -99,6 +139,10 @@ type ClusterKubernetes struct {
 	Grafana           *ClusterKubernetesGrafana           `json:"grafana,omiempty"`
 	Heapster          *ClusterKubernetesHeapster          `json:"heapster,omiempty"`
 	InfluxDB          *ClusterKubernetesInfluxDB          `json:"influxDB,omiempty"`
+	Ingress           *ClusterKubernetesIngress           `json:"ingress,omitempty"`
+	MetricsServer     *ClusterKubernetesMetricsServer     `json:"metricsServer,omitempty"`
+	PodIAM            *ClusterKubernetesPodIAM            `json:"podIAM,omitempty"`
+	CertManager       *ClusterKubernetesCertManager       `json:"certManager,omitempty"`
 
 	APIServer         *ClusterKubernetesAPIServer         `json:"apiServer,omitempty"`
 	Kubelet           *ClusterKubernetesKubelet           `json:"kubelet,omitempty"`
@@ -106,6 +150,7 @@ type ClusterKubernetes struct {
 	Proxy             *ClusterKubernetesProxy             `json:"proxy,omitempty"`
 	ControllerManager *ClusterKubernetesControllerManager `json:"controllerManager,omitempty"`
 	Calico            *ClusterKubernetesCalico            `json:"calico,omitempty"`
+	Networking        *ClusterKubernetesNetworking        `json:"networking,omitempty"`
 
 	GlobalFeatureGates map[string]bool `json:"globalFeatureGates,omitempty"`
 	Hyperkube          *bool           `json:"hyperkube,omitempty"`
@@ -142,6 +187,56 @@ type ClusterKubernetesDashboard struct {
 	Version string `json:"version,omitempty"`
 }
 
+type ClusterKubernetesIngress struct {
+	// Enable the nginx ingress controller, default: false
+	Enabled bool   `json:"enabled,omitempty"`
+	Image   string `json:"image,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Number of controller replicas, default: 2
+	Replicas *int `json:"replicas,omitempty"`
+	// Name of the worker instance pool hosting the controller, if empty all
+	// worker instance pools are used
+	InstancePool string `json:"instancePool,omitempty"`
+	// How to expose the controller: LoadBalancer lets the cloud provider
+	// create a load balancer, NodePort uses the ELB of the worker role,
+	// default: LoadBalancer
+	ServiceType string `json:"serviceType,omitempty"`
+	// Type of the load balancer for service type LoadBalancer: elb or nlb,
+	// default: elb
+	LoadBalancerType string `json:"loadBalancerType,omitempty"`
+	// Only expose the load balancer within the VPC
+	Internal bool `json:"internal,omitempty"`
+	// Additional annotations for the controller's service
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+}
+
+type ClusterKubernetesMetricsServer struct {
+	// Enable the metrics server, default: true
+	Enabled bool   `json:"enabled,omitempty"`
+	Image   string `json:"image,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type ClusterKubernetesPodIAM struct {
+	// Provider assigning IAM roles to pods: kube2iam or kiam, default:
+	// kube2iam
+	Provider string `json:"provider,omitempty"`
+	// Prefix for role names that are not a full ARN
+	BaseRoleARN string `json:"baseRoleARN,omitempty"`
+	Image       string `json:"image,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+type ClusterKubernetesCertManager struct {
+	// Enable cert-manager, default: false
+	Enabled bool   `json:"enabled,omitempty"`
+	Image   string `json:"image,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Register with Let's Encrypt using this email and create the cluster
+	// issuers letsencrypt-staging and letsencrypt-prod
+	ACMEEmail string `json:"acmeEmail,omitempty"`
+}
+
 type ClusterKubernetesAPIServer struct {
 	// expose the API server through a public load balancer
 	Public     bool     `json:"public,omitempty"`
@@ -163,6 +258,79 @@ type ClusterKubernetesAPIServer struct {
 	AuthTokenWebhookFile string `json:"authTokenWebhookFile,omitempty"`
 
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// Audit logging
+	Audit *ClusterKubernetesAPIServerAudit `json:"audit,omitempty"`
+
+	// Encryption of resources at rest in etcd
+	Encryption *ClusterKubernetesAPIServerEncryption `json:"encryption,omitempty"`
+}
+
+// Configure the API server's audit policy, log and backends
+type ClusterKubernetesAPIServerAudit struct {
+	// Enable audit logging, default: enabled for Kubernetes 1.8+
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Audit policy in YAML format, mutually exclusive with policyFile. If
+	// neither is set the policy bundled with tarmak is used.
+	Policy string `json:"policy,omitempty"`
+	// Path to a local file containing the audit policy, mutually exclusive
+	// with policy.
+	PolicyFile string `json:"policyFile,omitempty"`
+
+	// Format of the audit log, either 'json' or 'legacy', default: json
+	LogFormat string `json:"logFormat,omitempty"`
+	// Maximum number of days to retain old audit log files
+	LogMaxAge *int `json:"logMaxAge,omitempty"`
+	// Maximum number of old audit log files to retain, default: 1
+	LogMaxBackup *int `json:"logMaxBackup,omitempty"`
+	// Maximum size in megabytes of the audit log file before it gets
+	// rotated, default: 100
+	LogMaxSize *int `json:"logMaxSize,omitempty"`
+
+	// Send audit events to a remote webhook backend
+	Webhook *ClusterKubernetesAPIServerAuditWebhook `json:"webhook,omitempty"`
+}
+
+type ClusterKubernetesAPIServerAuditWebhook struct {
+	// URL of the remote webhook backend
+	URL string `json:"url,omitempty"`
+	// CA certificate in PEM format to verify the webhook backend
+	CA string `json:"ca,omitempty"`
+	// Strategy for sending audit events, either 'batch' or 'blocking',
+	// default: batch
+	Mode string `json:"mode,omitempty"`
+	// Amount of time to wait before retrying the first failed request,
+	// default: 10s
+	InitialBackoff string `json:"initialBackoff,omitempty"`
+}
+
+// Configure how the API server encrypts resources at rest
+type ClusterKubernetesAPIServerEncryption struct {
+	// Provider used to encrypt new writes, either 'aescbc' (keys stored in
+	// vault) or 'kms' (AWS KMS envelope encryption), default: aescbc
+	Provider string `json:"provider,omitempty"`
+
+	// Resources to encrypt, default: [secrets, configmaps]
+	Resources []string `json:"resources,omitempty"`
+
+	// AWS KMS provider specific options
+	KMS *ClusterKubernetesAPIServerEncryptionKMS `json:"kms,omitempty"`
+}
+
+type ClusterKubernetesAPIServerEncryptionKMS struct {
+	// ARN of the AWS KMS key used to encrypt the data encryption keys
+	KeyARN string `json:"keyARN,omitempty"`
+	// Number of data encryption keys cached in memory, default: 1000
+	CacheSize *int `json:"cacheSize,omitempty"`
+	// Timeout for requests to the KMS plugin, default: 3s
+	Timeout string `json:"timeout,omitempty"`
+
+	// Version of the aws-encryption-provider KMS plugin
+	PluginVersion string `json:"pluginVersion,omitempty"`
+	// URL to download the aws-encryption-provider KMS plugin from, #VERSION#
+	// gets replaced by pluginVersion
+	PluginURL string `json:"pluginURL,omitempty"`
 }
 
 type ClusterKubernetesAPIServerOIDC struct {
@@ -263,6 +431,67 @@ type ClusterKubernetesCalico struct {
 
 	EnableTypha   bool `json:"enableTypha"`
 	TyphaReplicas *int `json:"typhaReplicas"`
+
+	// Encapsulation of pod traffic between nodes, one of ipip, vxlan or none
+	// (default: ipip)
+	Encapsulation string `json:"encapsulation,omitempty"`
+	// Encapsulate traffic always or only when crossing subnet boundaries
+	// (default: always for etcd, cross-subnet for kubernetes backend)
+	EncapsulationMode string `json:"encapsulationMode,omitempty"`
+	// MTU of the pod interfaces, needs to leave room for the encapsulation
+	// header (default: 1480 for ipip, 1450 for vxlan, 1500 for none)
+	MTU *int `json:"mtu,omitempty"`
+
+	// IP pools pod addresses are allocated from, replacing the default pool
+	// covering the whole pod CIDR. Requires the kubernetes backend.
+	IPPools []ClusterKubernetesCalicoIPPool `json:"ipPools,omitempty"`
+	// BGP peers, like top of rack routers, every or selected nodes peer
+	// with. Requires the kubernetes backend.
+	BGPPeers []ClusterKubernetesCalicoBGPPeer `json:"bgpPeers,omitempty"`
+	// Deny all pod traffic outside of kube-system that is not explicitly
+	// allowed by a network policy. Requires the kubernetes backend.
+	DefaultDeny bool `json:"defaultDeny,omitempty"`
+}
+
+type ClusterKubernetesCalicoIPPool struct {
+	Name string `json:"name"`
+	CIDR string `json:"cidr"`
+	// Prefix length of the blocks assigned to nodes (default: 26)
+	BlockSize *int `json:"blockSize,omitempty"`
+	// Selects the nodes using this pool (default: all())
+	NodeSelector string `json:"nodeSelector,omitempty"`
+	// Masquerade traffic leaving the pool (default: true)
+	NATOutgoing *bool `json:"natOutgoing,omitempty"`
+}
+
+type ClusterKubernetesCalicoBGPPeer struct {
+	Name     string `json:"name"`
+	PeerIP   string `json:"peerIP"`
+	ASNumber int64  `json:"asNumber"`
+	// Selects the nodes peering with this peer (default: all nodes)
+	NodeSelector string `json:"nodeSelector,omitempty"`
+}
+
+// Networking selects the network plugin providing the pod network
+type ClusterKubernetesNetworking struct {
+	// Provider of the pod network, one of calico, cilium or aws-vpc-cni
+	// (default: calico)
+	Provider string `json:"provider,omitempty"`
+
+	Cilium       *ClusterKubernetesNetworkingCilium       `json:"cilium,omitempty"`
+	AmazonVPCCNI *ClusterKubernetesNetworkingAmazonVPCCNI `json:"amazonVPCCNI,omitempty"`
+}
+
+type ClusterKubernetesNetworkingCilium struct {
+	Version string `json:"version,omitempty"`
+	// Encapsulation used between nodes, either vxlan or geneve
+	Tunnel string `json:"tunnel,omitempty"`
+}
+
+type ClusterKubernetesNetworkingAmazonVPCCNI struct {
+	Version string `json:"version,omitempty"`
+	// Number of free IP addresses each node keeps attached for new pods
+	WarmIPTarget *int `json:"warmIPTarget,omitempty"`
 }
 
 // +k8s:openapi-gen=true
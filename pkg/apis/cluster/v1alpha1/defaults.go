@@ -52,9 +52,13 @@ func SetDefaults_Cluster(obj *Cluster) {
 		obj.Kubernetes.Hyperkube = boolPointer(true)
 	}
 
-	// podCIDR
+	// podCIDR, the AWS VPC CNI assigns addresses of the VPC's subnets to pods
 	if obj.Kubernetes.PodCIDR == "" {
-		obj.Kubernetes.PodCIDR = "100.64.0.0/16"
+		if n := obj.Kubernetes.Networking; n != nil && n.Provider == NetworkingProviderAmazonVPC {
+			obj.Kubernetes.PodCIDR = obj.Network.CIDR
+		} else {
+			obj.Kubernetes.PodCIDR = "100.64.0.0/16"
+		}
 	}
 
 	// serviceCIDR
@@ -77,6 +81,10 @@ func SetDefaults_Cluster(obj *Cluster) {
 		obj.Kubernetes.Dashboard = &ClusterKubernetesDashboard{}
 	}
 
+	if obj.Kubernetes.Networking == nil {
+		obj.Kubernetes.Networking = &ClusterKubernetesNetworking{}
+	}
+
 	if obj.Kubernetes.Calico == nil {
 		obj.Kubernetes.Calico = &ClusterKubernetesCalico{
 			Backend:     "etcd",
@@ -93,6 +101,29 @@ func SetDefaults_Cluster(obj *Cluster) {
 		obj.Kubernetes.Calico.TyphaReplicas = intPointer(1)
 	}
 
+	if obj.Kubernetes.Calico.Encapsulation == "" {
+		obj.Kubernetes.Calico.Encapsulation = CalicoEncapsulationIPIP
+	}
+
+	if obj.Kubernetes.Calico.EncapsulationMode == "" {
+		if obj.Kubernetes.Calico.Backend == CalicoBackendKubernetes {
+			obj.Kubernetes.Calico.EncapsulationMode = CalicoEncapsulationModeCrossSubnet
+		} else {
+			obj.Kubernetes.Calico.EncapsulationMode = CalicoEncapsulationModeAlways
+		}
+	}
+
+	if obj.Kubernetes.Calico.MTU == nil {
+		switch obj.Kubernetes.Calico.Encapsulation {
+		case CalicoEncapsulationVXLAN:
+			obj.Kubernetes.Calico.MTU = intPointer(1450)
+		case CalicoEncapsulationNone:
+			obj.Kubernetes.Calico.MTU = intPointer(1500)
+		default:
+			obj.Kubernetes.Calico.MTU = intPointer(1480)
+		}
+	}
+
 	if obj.Kubernetes.Heapster == nil {
 		obj.Kubernetes.Heapster = &ClusterKubernetesHeapster{
 			Enabled: true,
@@ -258,6 +289,92 @@ func SetDefaults_ClusterKubernetesAPIServerAmazonAccessLogs(obj *ClusterKubernet
 	}
 }
 
+func SetDefaults_ClusterKubernetesAPIServerAudit(obj *ClusterKubernetesAPIServerAudit) {
+	if obj.LogFormat == "" {
+		obj.LogFormat = AuditLogFormatJSON
+	}
+
+	if obj.LogMaxBackup == nil {
+		obj.LogMaxBackup = intPointer(1)
+	}
+
+	if obj.LogMaxSize == nil {
+		obj.LogMaxSize = intPointer(100)
+	}
+}
+
+func SetDefaults_ClusterKubernetesAPIServerAuditWebhook(obj *ClusterKubernetesAPIServerAuditWebhook) {
+	if obj.Mode == "" {
+		obj.Mode = AuditWebhookModeBatch
+	}
+
+	if obj.InitialBackoff == "" {
+		obj.InitialBackoff = "10s"
+	}
+}
+
+func SetDefaults_ClusterKubernetesAPIServerEncryption(obj *ClusterKubernetesAPIServerEncryption) {
+	if obj.Provider == "" {
+		obj.Provider = EncryptionProviderAESCBC
+	}
+
+	if len(obj.Resources) == 0 {
+		obj.Resources = []string{"secrets", "configmaps"}
+	}
+}
+
+func SetDefaults_ClusterKubernetesAPIServerEncryptionKMS(obj *ClusterKubernetesAPIServerEncryptionKMS) {
+	if obj.CacheSize == nil {
+		obj.CacheSize = intPointer(1000)
+	}
+
+	if obj.Timeout == "" {
+		obj.Timeout = "3s"
+	}
+}
+
+func SetDefaults_ClusterKubernetesCalicoIPPool(obj *ClusterKubernetesCalicoIPPool) {
+	if obj.BlockSize == nil {
+		obj.BlockSize = intPointer(26)
+	}
+
+	if obj.NATOutgoing == nil {
+		obj.NATOutgoing = boolPointer(true)
+	}
+}
+
+func SetDefaults_ClusterKubernetesNetworking(obj *ClusterKubernetesNetworking) {
+	if obj.Provider == "" {
+		obj.Provider = NetworkingProviderCalico
+	}
+}
+
+func SetDefaults_ClusterKubernetesNetworkingCilium(obj *ClusterKubernetesNetworkingCilium) {
+	if obj.Tunnel == "" {
+		obj.Tunnel = CiliumTunnelVXLAN
+	}
+}
+
+func SetDefaults_ClusterKubernetesIngress(obj *ClusterKubernetesIngress) {
+	if obj.Replicas == nil {
+		obj.Replicas = intPointer(2)
+	}
+
+	if obj.ServiceType == "" {
+		obj.ServiceType = IngressServiceTypeLoadBalancer
+	}
+
+	if obj.ServiceType == IngressServiceTypeLoadBalancer && obj.LoadBalancerType == "" {
+		obj.LoadBalancerType = IngressLoadBalancerTypeELB
+	}
+}
+
+func SetDefaults_ClusterKubernetesPodIAM(obj *ClusterKubernetesPodIAM) {
+	if obj.Provider == "" {
+		obj.Provider = PodIAMProviderKube2IAM
+	}
+}
+
 func SetDefaults_ClusterKubernetesClusterAutoscaler(obj *ClusterKubernetesClusterAutoscaler) {
 	if obj.ScaleDownUtilizationThreshold == nil {
 		obj.ScaleDownUtilizationThreshold = floatPointer(0.5)
@@ -60,3 +60,24 @@ func TestLoggingDefaults(t *testing.T) {
 		}
 	}
 }
+
+func TestPodCIDRDefaults(t *testing.T) {
+	cluster := &Cluster{}
+	SetDefaults_Cluster(cluster)
+	if exp, act := "100.64.0.0/16", cluster.Kubernetes.PodCIDR; exp != act {
+		t.Errorf("unexpected pod CIDR, exp=%s act=%s", exp, act)
+	}
+
+	cluster = &Cluster{
+		Network: &Network{CIDR: "10.98.0.0/16"},
+		Kubernetes: &ClusterKubernetes{
+			Networking: &ClusterKubernetesNetworking{
+				Provider: NetworkingProviderAmazonVPC,
+			},
+		},
+	}
+	SetDefaults_Cluster(cluster)
+	if exp, act := "10.98.0.0/16", cluster.Kubernetes.PodCIDR; exp != act {
+		t.Errorf("unexpected pod CIDR for aws-vpc-cni, exp=%s act=%s", exp, act)
+	}
+}
@@ -61,10 +61,24 @@ type InstancePool struct {
 	Labels            []*Label                `json:"labels,omitempty"`
 	Taints            []*Taint                `json:"taints,omitempty"`
 
+	// Periodic reconverges of the instances to revert manual changes
+	Reconverge *InstancePoolReconverge `json:"reconverge,omitempty"`
+
 	// Amazon specific settings for that instance pool
 	Amazon *InstancePoolAmazon `json:"amazon,omitempty"`
 }
 
+// Reconverges reapply the puppet manifests on every instance of the pool
+type InstancePoolReconverge struct {
+	// Interval between reconverges of an instance, e.g. 6h
+	Interval string `json:"interval,omitempty"`
+	// Maximum random delay added to the interval, to spread reconverges
+	Jitter string `json:"jitter,omitempty"`
+	// Reconverges only start within these windows, e.g. 'Mon-Fri 01:00-05:00
+	// Europe/London'. Without windows they can start at any time.
+	MaintenanceWindows []string `json:"maintenanceWindows,omitempty"`
+}
+
 type InstancePoolKubernetes struct {
 	Version string `json:"version,omitempty"`
 }
@@ -0,0 +1,26 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package v1alpha1
+
+// The schema version of the clusters is stored in an annotation of the config
+// containing them, clusters without it are version 1.
+const SchemaVersionAnnotationKey = "cluster.k8s.io/schema-version"
+
+// Conversions upgrade a cluster by one schema version, the conversion at
+// index i upgrades from version i+1 to i+2. Conversions must not change the
+// behaviour of tarmak, so configs can be upgraded at any time.
+var Conversions = []func(*Cluster){
+	// 2: cloud settings are taken from the provider of the environment
+	convertDropUnusedFields,
+}
+
+// SchemaVersion returns the latest schema version of this API group
+func SchemaVersion() int {
+	return len(Conversions) + 1
+}
+
+func convertDropUnusedFields(cluster *Cluster) {
+	cluster.CloudId = ""
+	cluster.Cloud = ""
+	cluster.GroupIdentifier = ""
+	cluster.KubernetesAPI = nil
+}
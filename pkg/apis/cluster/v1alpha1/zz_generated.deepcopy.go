@@ -169,6 +169,26 @@ func (in *ClusterKubernetes) DeepCopyInto(out *ClusterKubernetes) {
 		*out = new(ClusterKubernetesInfluxDB)
 		**out = **in
 	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ClusterKubernetesIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsServer != nil {
+		in, out := &in.MetricsServer, &out.MetricsServer
+		*out = new(ClusterKubernetesMetricsServer)
+		**out = **in
+	}
+	if in.PodIAM != nil {
+		in, out := &in.PodIAM, &out.PodIAM
+		*out = new(ClusterKubernetesPodIAM)
+		**out = **in
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(ClusterKubernetesCertManager)
+		**out = **in
+	}
 	if in.APIServer != nil {
 		in, out := &in.APIServer, &out.APIServer
 		*out = new(ClusterKubernetesAPIServer)
@@ -199,6 +219,11 @@ func (in *ClusterKubernetes) DeepCopyInto(out *ClusterKubernetes) {
 		*out = new(ClusterKubernetesCalico)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(ClusterKubernetesNetworking)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GlobalFeatureGates != nil {
 		in, out := &in.GlobalFeatureGates, &out.GlobalFeatureGates
 		*out = make(map[string]bool, len(*in))
@@ -206,6 +231,11 @@ func (in *ClusterKubernetes) DeepCopyInto(out *ClusterKubernetes) {
 			(*out)[key] = val
 		}
 	}
+	if in.Hyperkube != nil {
+		in, out := &in.Hyperkube, &out.Hyperkube
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -227,6 +257,11 @@ func (in *ClusterKubernetesAPIServer) DeepCopyInto(out *ClusterKubernetesAPIServ
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateAllowCIDRs != nil {
+		in, out := &in.PrivateAllowCIDRs, &out.PrivateAllowCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.EnableAdmissionControllers != nil {
 		in, out := &in.EnableAdmissionControllers, &out.EnableAdmissionControllers
 		*out = make([]string, len(*in))
@@ -254,6 +289,16 @@ func (in *ClusterKubernetesAPIServer) DeepCopyInto(out *ClusterKubernetesAPIServ
 			(*out)[key] = val
 		}
 	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(ClusterKubernetesAPIServerAudit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ClusterKubernetesAPIServerEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -319,6 +364,110 @@ func (in *ClusterKubernetesAPIServerAmazonAccessLogs) DeepCopy() *ClusterKuberne
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesAPIServerAudit) DeepCopyInto(out *ClusterKubernetesAPIServerAudit) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LogMaxAge != nil {
+		in, out := &in.LogMaxAge, &out.LogMaxAge
+		*out = new(int)
+		**out = **in
+	}
+	if in.LogMaxBackup != nil {
+		in, out := &in.LogMaxBackup, &out.LogMaxBackup
+		*out = new(int)
+		**out = **in
+	}
+	if in.LogMaxSize != nil {
+		in, out := &in.LogMaxSize, &out.LogMaxSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(ClusterKubernetesAPIServerAuditWebhook)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesAPIServerAudit.
+func (in *ClusterKubernetesAPIServerAudit) DeepCopy() *ClusterKubernetesAPIServerAudit {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesAPIServerAudit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesAPIServerAuditWebhook) DeepCopyInto(out *ClusterKubernetesAPIServerAuditWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesAPIServerAuditWebhook.
+func (in *ClusterKubernetesAPIServerAuditWebhook) DeepCopy() *ClusterKubernetesAPIServerAuditWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesAPIServerAuditWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesAPIServerEncryption) DeepCopyInto(out *ClusterKubernetesAPIServerEncryption) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(ClusterKubernetesAPIServerEncryptionKMS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesAPIServerEncryption.
+func (in *ClusterKubernetesAPIServerEncryption) DeepCopy() *ClusterKubernetesAPIServerEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesAPIServerEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesAPIServerEncryptionKMS) DeepCopyInto(out *ClusterKubernetesAPIServerEncryptionKMS) {
+	*out = *in
+	if in.CacheSize != nil {
+		in, out := &in.CacheSize, &out.CacheSize
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesAPIServerEncryptionKMS.
+func (in *ClusterKubernetesAPIServerEncryptionKMS) DeepCopy() *ClusterKubernetesAPIServerEncryptionKMS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesAPIServerEncryptionKMS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKubernetesAPIServerOIDC) DeepCopyInto(out *ClusterKubernetesAPIServerOIDC) {
 	*out = *in
@@ -348,6 +497,23 @@ func (in *ClusterKubernetesCalico) DeepCopyInto(out *ClusterKubernetesCalico) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int)
+		**out = **in
+	}
+	if in.IPPools != nil {
+		in, out := &in.IPPools, &out.IPPools
+		*out = make([]ClusterKubernetesCalicoIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BGPPeers != nil {
+		in, out := &in.BGPPeers, &out.BGPPeers
+		*out = make([]ClusterKubernetesCalicoBGPPeer, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -361,6 +527,64 @@ func (in *ClusterKubernetesCalico) DeepCopy() *ClusterKubernetesCalico {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesCalicoBGPPeer) DeepCopyInto(out *ClusterKubernetesCalicoBGPPeer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesCalicoBGPPeer.
+func (in *ClusterKubernetesCalicoBGPPeer) DeepCopy() *ClusterKubernetesCalicoBGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesCalicoBGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesCalicoIPPool) DeepCopyInto(out *ClusterKubernetesCalicoIPPool) {
+	*out = *in
+	if in.BlockSize != nil {
+		in, out := &in.BlockSize, &out.BlockSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.NATOutgoing != nil {
+		in, out := &in.NATOutgoing, &out.NATOutgoing
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesCalicoIPPool.
+func (in *ClusterKubernetesCalicoIPPool) DeepCopy() *ClusterKubernetesCalicoIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesCalicoIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesCertManager) DeepCopyInto(out *ClusterKubernetesCertManager) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesCertManager.
+func (in *ClusterKubernetesCertManager) DeepCopy() *ClusterKubernetesCertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesCertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKubernetesClusterAutoscaler) DeepCopyInto(out *ClusterKubernetesClusterAutoscaler) {
 	*out = *in
@@ -490,6 +714,34 @@ func (in *ClusterKubernetesInfluxDB) DeepCopy() *ClusterKubernetesInfluxDB {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesIngress) DeepCopyInto(out *ClusterKubernetesIngress) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int)
+		**out = **in
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesIngress.
+func (in *ClusterKubernetesIngress) DeepCopy() *ClusterKubernetesIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKubernetesKubelet) DeepCopyInto(out *ClusterKubernetesKubelet) {
 	*out = *in
@@ -513,6 +765,101 @@ func (in *ClusterKubernetesKubelet) DeepCopy() *ClusterKubernetesKubelet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesMetricsServer) DeepCopyInto(out *ClusterKubernetesMetricsServer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesMetricsServer.
+func (in *ClusterKubernetesMetricsServer) DeepCopy() *ClusterKubernetesMetricsServer {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesMetricsServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesNetworking) DeepCopyInto(out *ClusterKubernetesNetworking) {
+	*out = *in
+	if in.Cilium != nil {
+		in, out := &in.Cilium, &out.Cilium
+		*out = new(ClusterKubernetesNetworkingCilium)
+		**out = **in
+	}
+	if in.AmazonVPCCNI != nil {
+		in, out := &in.AmazonVPCCNI, &out.AmazonVPCCNI
+		*out = new(ClusterKubernetesNetworkingAmazonVPCCNI)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesNetworking.
+func (in *ClusterKubernetesNetworking) DeepCopy() *ClusterKubernetesNetworking {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesNetworking)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesNetworkingAmazonVPCCNI) DeepCopyInto(out *ClusterKubernetesNetworkingAmazonVPCCNI) {
+	*out = *in
+	if in.WarmIPTarget != nil {
+		in, out := &in.WarmIPTarget, &out.WarmIPTarget
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesNetworkingAmazonVPCCNI.
+func (in *ClusterKubernetesNetworkingAmazonVPCCNI) DeepCopy() *ClusterKubernetesNetworkingAmazonVPCCNI {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesNetworkingAmazonVPCCNI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesNetworkingCilium) DeepCopyInto(out *ClusterKubernetesNetworkingCilium) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesNetworkingCilium.
+func (in *ClusterKubernetesNetworkingCilium) DeepCopy() *ClusterKubernetesNetworkingCilium {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesNetworkingCilium)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubernetesPodIAM) DeepCopyInto(out *ClusterKubernetesPodIAM) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubernetesPodIAM.
+func (in *ClusterKubernetesPodIAM) DeepCopy() *ClusterKubernetesPodIAM {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubernetesPodIAM)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKubernetesPrometheus) DeepCopyInto(out *ClusterKubernetesPrometheus) {
 	*out = *in
@@ -795,6 +1142,11 @@ func (in *InstancePool) DeepCopyInto(out *InstancePool) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateAllowCIDRs != nil {
+		in, out := &in.PrivateAllowCIDRs, &out.PrivateAllowCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make([]*Label, len(*in))
@@ -817,6 +1169,11 @@ func (in *InstancePool) DeepCopyInto(out *InstancePool) {
 			}
 		}
 	}
+	if in.Reconverge != nil {
+		in, out := &in.Reconverge, &out.Reconverge
+		*out = new(InstancePoolReconverge)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Amazon != nil {
 		in, out := &in.Amazon, &out.Amazon
 		*out = new(InstancePoolAmazon)
@@ -880,6 +1237,27 @@ func (in *InstancePoolKubernetes) DeepCopy() *InstancePoolKubernetes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancePoolReconverge) DeepCopyInto(out *InstancePoolReconverge) {
+	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancePoolReconverge.
+func (in *InstancePoolReconverge) DeepCopy() *InstancePoolReconverge {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancePoolReconverge)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternetGW) DeepCopyInto(out *InternetGW) {
 	*out = *in
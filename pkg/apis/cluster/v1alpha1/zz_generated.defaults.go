@@ -31,6 +31,12 @@ func SetObjectDefaults_Cluster(in *Cluster) {
 		if in.Kubernetes.ClusterAutoscaler != nil {
 			SetDefaults_ClusterKubernetesClusterAutoscaler(in.Kubernetes.ClusterAutoscaler)
 		}
+		if in.Kubernetes.Ingress != nil {
+			SetDefaults_ClusterKubernetesIngress(in.Kubernetes.Ingress)
+		}
+		if in.Kubernetes.PodIAM != nil {
+			SetDefaults_ClusterKubernetesPodIAM(in.Kubernetes.PodIAM)
+		}
 		if in.Kubernetes.APIServer != nil {
 			if in.Kubernetes.APIServer.Amazon != nil {
 				if in.Kubernetes.APIServer.Amazon.PublicELBAccessLogs != nil {
@@ -40,6 +46,30 @@ func SetObjectDefaults_Cluster(in *Cluster) {
 					SetDefaults_ClusterKubernetesAPIServerAmazonAccessLogs(in.Kubernetes.APIServer.Amazon.InternalELBAccessLogs)
 				}
 			}
+			if in.Kubernetes.APIServer.Audit != nil {
+				SetDefaults_ClusterKubernetesAPIServerAudit(in.Kubernetes.APIServer.Audit)
+				if in.Kubernetes.APIServer.Audit.Webhook != nil {
+					SetDefaults_ClusterKubernetesAPIServerAuditWebhook(in.Kubernetes.APIServer.Audit.Webhook)
+				}
+			}
+			if in.Kubernetes.APIServer.Encryption != nil {
+				SetDefaults_ClusterKubernetesAPIServerEncryption(in.Kubernetes.APIServer.Encryption)
+				if in.Kubernetes.APIServer.Encryption.KMS != nil {
+					SetDefaults_ClusterKubernetesAPIServerEncryptionKMS(in.Kubernetes.APIServer.Encryption.KMS)
+				}
+			}
+		}
+		if in.Kubernetes.Calico != nil {
+			for i := range in.Kubernetes.Calico.IPPools {
+				a := &in.Kubernetes.Calico.IPPools[i]
+				SetDefaults_ClusterKubernetesCalicoIPPool(a)
+			}
+		}
+		if in.Kubernetes.Networking != nil {
+			SetDefaults_ClusterKubernetesNetworking(in.Kubernetes.Networking)
+			if in.Kubernetes.Networking.Cilium != nil {
+				SetDefaults_ClusterKubernetesNetworkingCilium(in.Kubernetes.Networking.Cilium)
+			}
 		}
 	}
 }
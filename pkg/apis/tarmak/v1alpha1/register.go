@@ -34,6 +34,8 @@ func init() {
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Config{},
+		&Provider{},
+		&Environment{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,36 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package v1alpha1
+
+// The schema of the v1alpha1 types changes whenever fields are moved or
+// dropped. A config records the schema version it was written with in an
+// annotation, configs without it are version 1.
+const SchemaVersionAnnotationKey = "api.tarmak.io/schema-version"
+
+// Conversions upgrade a config by one schema version, the conversion at index
+// i upgrades from version i+1 to i+2. Conversions must not change the
+// behaviour of tarmak, so configs can be upgraded at any time.
+var Conversions = []func(*Config){
+	// 2: contact and project are configured per environment
+	convertContactProjectToEnvironments,
+}
+
+// SchemaVersion returns the latest schema version of this API group
+func SchemaVersion() int {
+	return len(Conversions) + 1
+}
+
+// contact and project of the environment take precedence, the top level
+// values are only used where an environment has none
+func convertContactProjectToEnvironments(conf *Config) {
+	for pos := range conf.Environments {
+		environment := &conf.Environments[pos]
+		if environment.Contact == "" {
+			environment.Contact = conf.Contact
+		}
+		if environment.Project == "" {
+			environment.Project = conf.Project
+		}
+	}
+	conf.Contact = ""
+	conf.Project = ""
+}
@@ -2,6 +2,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
@@ -18,8 +20,8 @@ type Config struct {
 
 	CurrentCluster string `json:"currentCluster,omitempty"` // <environmentName>-<clusterName>
 
-	Contact string `json:"contact,omitempty"`
-	Project string `json:"project,omitempty"`
+	Contact string `json:"contact,omitempty"` // fallback for environments, removed by schema version 2
+	Project string `json:"project,omitempty"` // fallback for environments, removed by schema version 2
 
 	Clusters     []clusterv1alpha1.Cluster `json:"clusters,omitempty"`
 	Providers    []Provider                `json:"providers,omitempty"`
@@ -120,6 +122,41 @@ type Image struct {
 	Encrypted bool   `json:"encrypted,omitempty"`
 }
 
+// This represents an uploaded puppet manifest in a cluster's manifest history
+type ManifestHistoryEntry struct {
+	Hash      string      `json:"hash"`                // hash of the manifest, prefixed with type (eg: sha256:xyz)
+	MD5       string      `json:"md5,omitempty"`       // md5 hash the manifest object is stored under
+	Path      string      `json:"path"`                // path to the manifest object (eg: s3://bucket/key)
+	Timestamp metav1.Time `json:"timestamp,omitempty"` // time the manifest has been uploaded
+}
+
+// This represents an existing cloud resource, that can be imported into the
+// terraform state of a cluster
+type ImportResource struct {
+	Address string `json:"address"`      // address of the resource in the generated terraform code
+	ID      string `json:"id,omitempty"` // ID of the existing resource, empty if none has been found
+	Match   string `json:"match"`        // how the resource has been discovered (eg: tag Name=vpc.dev-hub)
+}
+
+// This represents a backup of the terraform state of a cluster
+type StateBackup struct {
+	Name      string      `json:"name"`                // name to restore the backup by
+	Path      string      `json:"path"`                // path to the backup object (eg: s3://bucket/key)
+	Size      int64       `json:"size,omitempty"`      // size of the state in bytes
+	Timestamp metav1.Time `json:"timestamp,omitempty"` // time the backup has been taken
+}
+
+// This represents a lock held on the terraform state of a cluster
+type StateLock struct {
+	ID               string      `json:"id"`                         // ID to force-unlock the lock with
+	Operation        string      `json:"operation,omitempty"`        // terraform operation holding the lock (eg: OperationTypeApply)
+	Who              string      `json:"who,omitempty"`              // user@hostname that took the lock
+	TerraformVersion string      `json:"terraformVersion,omitempty"` // version of terraform that took the lock
+	TarmakVersion    string      `json:"tarmakVersion,omitempty"`    // version of tarmak that took the lock, empty for locks not taken by tarmak
+	Created          metav1.Time `json:"created,omitempty"`          // time the lock has been taken
+	Path             string      `json:"path,omitempty"`             // path of the locked state
+}
+
 // This represents tarmaks global flags
 type Flags struct {
 	Verbose         bool   `json:"verbose,omitempty"`         // logrus log level to run with
@@ -134,6 +171,10 @@ type Flags struct {
 
 	Environment EnvironmentFlags `json:"environment,omitempty"` // environment specific flags
 
+	Init InitFlags `json:"init,omitempty"` // flags for initializing without prompts
+
+	Config ConfigFlags `json:"config,omitempty"` // flags for handling the configuration
+
 	WingDevMode bool `json:"wingDevMode,omitempty"` // use a bundled wing version rather than a tagged release from GitHub
 
 	PublicAPIEndpoint bool `json:"publicAPIEndpoint,omitempty"` // Use public endpoint to point kubeconfig to
@@ -150,6 +191,51 @@ type ClusterFlags struct {
 	Plan       ClusterPlanFlags       `json:"plan,omitempty"`       // flags for planning clusters
 	Kubeconfig ClusterKubeconfigFlags `json:"kubeconfig,omitempty"` // flags for kubeconfig of clusters
 	Logs       ClusterLogsFlags       `json:"logs,omitempty"`       // flags for getting logs from clusters
+
+	Configuration ClusterConfigurationFlags `json:"configuration,omitempty"` // flags for handling cluster configuration
+	Instances     ClusterInstancesFlags     `json:"instances,omitempty"`     // flags for handling instances
+	Import        ClusterImportFlags        `json:"import,omitempty"`        // flags for importing existing resources
+	State         ClusterStateFlags         `json:"state,omitempty"`         // flags for handling the terraform state
+	ForceUnlock   ClusterForceUnlockFlags   `json:"forceUnlock,omitempty"`   // flags for force unlocking the terraform state
+}
+
+// Contains the cluster force unlock flags
+type ClusterForceUnlockFlags struct {
+	MinAge      time.Duration `json:"minAge,omitempty"`      // minimum age of a lock to break it without --force
+	Force       bool          `json:"force,omitempty"`       // break locks younger than the minimum age
+	AutoApprove bool          `json:"autoApprove,omitempty"` // break the lock without asking
+}
+
+// Contains the cluster state flags
+type ClusterStateFlags struct {
+	Restore ClusterStateRestoreFlags `json:"restore,omitempty"` // flags for restoring a state backup
+	Remove  ClusterStateRemoveFlags  `json:"remove,omitempty"`  // flags for removing resources from the state
+	Migrate ClusterStateMigrateFlags `json:"migrate,omitempty"` // flags for migrating the state from another location
+}
+
+// Contains the cluster state restore flags
+type ClusterStateRestoreFlags struct {
+	AutoApprove bool `json:"autoApprove,omitempty"` // restore without asking
+}
+
+// Contains the cluster state remove flags
+type ClusterStateRemoveFlags struct {
+	AutoApprove bool `json:"autoApprove,omitempty"` // remove without asking
+}
+
+// Contains the cluster state migrate flags
+type ClusterStateMigrateFlags struct {
+	FromProvider string `json:"fromProvider,omitempty"` // provider to read the existing state with, defaults to the provider of the environment
+	FromBucket   string `json:"fromBucket,omitempty"`   // bucket holding the existing state, defaults to the state bucket of the provider
+	FromKey      string `json:"fromKey,omitempty"`      // key of the existing state, defaults to the key of the cluster
+	Force        bool   `json:"force,omitempty"`        // replace a destination state that already contains resources
+	AutoApprove  bool   `json:"autoApprove,omitempty"`  // migrate without asking
+}
+
+// Contains the cluster import flags
+type ClusterImportFlags struct {
+	DryRun      bool `json:"dryRun,omitempty"`      // only report the resources that would be imported
+	AutoApprove bool `json:"autoApprove,omitempty"` // import without asking
 }
 
 // Contains the cluster plan flags
@@ -160,6 +246,8 @@ type ClusterPlanFlags struct {
 // This contains the environment specific operation flags
 type EnvironmentFlags struct {
 	Destroy EnvironmentDestroyFlags `json:"destroy,omitempty"` // flags for destroying environment
+	Drift   EnvironmentDriftFlags   `json:"drift,omitempty"`   // flags for detecting drift of an environment
+	Apply   EnvironmentApplyFlags   `json:"apply,omitempty"`   // flags for applying a complete environment
 }
 
 // Contains the cluster apply flags
@@ -204,7 +292,84 @@ type ClusterLogsFlags struct {
 	Until string `json:"until,omitempty"` // fetch logs until date
 }
 
+// Contains the cluster configuration flags
+type ClusterConfigurationFlags struct {
+	Rollback ClusterConfigurationRollbackFlags `json:"rollback,omitempty"` // flags for rolling back configuration
+}
+
+// Contains the cluster configuration rollback flags
+type ClusterConfigurationRollbackFlags struct {
+	To                 string `json:"to,omitempty"`                 // hash of the manifest to roll back to
+	InstancePool       string `json:"instancePool,omitempty"`       // only roll back instances of this instance pool
+	WaitForConvergence bool   `json:"waitForConvergence,omitempty"` // wait for wing convergence after rolling back
+}
+
+// Contains the cluster instances flags
+type ClusterInstancesFlags struct {
+	Inventory ClusterInstancesInventoryFlags `json:"inventory,omitempty"` // flags for the inventory of instances
+	Exec      ClusterInstancesExecFlags      `json:"exec,omitempty"`      // flags for running commands on instances
+}
+
+// Contains the cluster instances inventory flags
+type ClusterInstancesInventoryFlags struct {
+	InstancePool string `json:"instancePool,omitempty"` // only list instances of this instance pool
+	FailOnDrift  bool   `json:"failOnDrift,omitempty"`  // return an error if versions differ between instances of a pool
+}
+
+// Contains the cluster instances exec flags
+type ClusterInstancesExecFlags struct {
+	InstancePool string        `json:"instancePool,omitempty"` // only run on instances of this instance pool
+	Timeout      time.Duration `json:"timeout,omitempty"`      // kill the command on instances after this duration
+}
+
 // Contains the environment destroy flags
 type EnvironmentDestroyFlags struct {
 	AutoApprove bool `json:"autoApprove,omitempty"` // auto-approve destroying a whole environment
 }
+
+// Contains the environment apply flags
+type EnvironmentApplyFlags struct {
+	AutoApprove             bool `json:"autoApprove,omitempty"`             // apply without asking to approve the planned changes
+	AutoApproveDeletingData bool `json:"autoApproveDeletingData,omitempty"` // auto approve apply queries about deleting data
+	WaitForConvergence      bool `json:"waitForConvergence,omitempty"`      // wait for wing convergence when applying clusters
+	Concurrency             int  `json:"concurrency,omitempty"`             // number of clusters applied at the same time after the hub
+	ContinueOnFailure       bool `json:"continueOnFailure,omitempty"`       // keep applying clusters after a cluster failed
+}
+
+// This contains the configuration specific operation flags
+type ConfigFlags struct {
+	Upgrade ConfigUpgradeFlags `json:"upgrade,omitempty"` // flags for upgrading the configuration
+}
+
+// Contains the config upgrade flags
+type ConfigUpgradeFlags struct {
+	AutoApprove bool `json:"autoApprove,omitempty"` // write the upgraded configuration without asking
+}
+
+// Contains the answers to the init prompts, when any of them is set tarmak
+// init runs without asking
+type InitFlags struct {
+	FromFile string `json:"fromFile,omitempty"` // path to a spec file with the answers, flags take precedence
+
+	Provider     string `json:"provider,omitempty"`     // name of the provider to use or create
+	Profile      string `json:"profile,omitempty"`      // AWS profile of a new provider
+	VaultPath    string `json:"vaultPath,omitempty"`    // Vault path for the AWS credentials of a new provider
+	BucketPrefix string `json:"bucketPrefix,omitempty"` // prefix of the state buckets and DynamoDB tables of a new provider
+	PublicZone   string `json:"publicZone,omitempty"`   // public DNS zone of a new provider
+
+	Environment string `json:"environment,omitempty"` // name of the environment to use or create
+	Project     string `json:"project,omitempty"`     // project name of a new environment
+	Contact     string `json:"contact,omitempty"`     // contact mail address of a new environment
+	Region      string `json:"region,omitempty"`      // region of a new environment
+
+	ClusterType        string   `json:"clusterType,omitempty"`        // type of a new environment, single or multi
+	ClusterName        string   `json:"clusterName,omitempty"`        // name of the cluster in a multi cluster environment
+	Zones              []string `json:"zones,omitempty"`              // availability zones of the cluster
+	InstancePoolCounts []string `json:"instancePoolCounts,omitempty"` // instance counts per pool, like worker=3
+	InstancePoolSizes  []string `json:"instancePoolSizes,omitempty"`  // instance sizes per pool, like worker=large
+}
+
+// Contains the environment drift flags
+type EnvironmentDriftFlags struct {
+	Output string `json:"output,omitempty"` // format of the drift report, text or json
+}
@@ -27,6 +27,39 @@ func (in *ClusterApplyFlags) DeepCopy() *ClusterApplyFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfigurationFlags) DeepCopyInto(out *ClusterConfigurationFlags) {
+	*out = *in
+	out.Rollback = in.Rollback
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigurationFlags.
+func (in *ClusterConfigurationFlags) DeepCopy() *ClusterConfigurationFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfigurationFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfigurationRollbackFlags) DeepCopyInto(out *ClusterConfigurationRollbackFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigurationRollbackFlags.
+func (in *ClusterConfigurationRollbackFlags) DeepCopy() *ClusterConfigurationRollbackFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfigurationRollbackFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDestroyFlags) DeepCopyInto(out *ClusterDestroyFlags) {
 	*out = *in
@@ -52,6 +85,11 @@ func (in *ClusterFlags) DeepCopyInto(out *ClusterFlags) {
 	out.Plan = in.Plan
 	out.Kubeconfig = in.Kubeconfig
 	out.Logs = in.Logs
+	out.Configuration = in.Configuration
+	out.Instances = in.Instances
+	out.Import = in.Import
+	out.State = in.State
+	out.ForceUnlock = in.ForceUnlock
 	return
 }
 
@@ -65,6 +103,22 @@ func (in *ClusterFlags) DeepCopy() *ClusterFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterForceUnlockFlags) DeepCopyInto(out *ClusterForceUnlockFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterForceUnlockFlags.
+func (in *ClusterForceUnlockFlags) DeepCopy() *ClusterForceUnlockFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterForceUnlockFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImagesBuildFlags) DeepCopyInto(out *ClusterImagesBuildFlags) {
 	*out = *in
@@ -98,6 +152,72 @@ func (in *ClusterImagesFlags) DeepCopy() *ClusterImagesFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImportFlags) DeepCopyInto(out *ClusterImportFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImportFlags.
+func (in *ClusterImportFlags) DeepCopy() *ClusterImportFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImportFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInstancesExecFlags) DeepCopyInto(out *ClusterInstancesExecFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstancesExecFlags.
+func (in *ClusterInstancesExecFlags) DeepCopy() *ClusterInstancesExecFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInstancesExecFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInstancesFlags) DeepCopyInto(out *ClusterInstancesFlags) {
+	*out = *in
+	out.Inventory = in.Inventory
+	out.Exec = in.Exec
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstancesFlags.
+func (in *ClusterInstancesFlags) DeepCopy() *ClusterInstancesFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInstancesFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInstancesInventoryFlags) DeepCopyInto(out *ClusterInstancesInventoryFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstancesInventoryFlags.
+func (in *ClusterInstancesInventoryFlags) DeepCopy() *ClusterInstancesInventoryFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInstancesInventoryFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKubeconfigFlags) DeepCopyInto(out *ClusterKubeconfigFlags) {
 	*out = *in
@@ -146,6 +266,73 @@ func (in *ClusterPlanFlags) DeepCopy() *ClusterPlanFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateFlags) DeepCopyInto(out *ClusterStateFlags) {
+	*out = *in
+	out.Restore = in.Restore
+	out.Remove = in.Remove
+	out.Migrate = in.Migrate
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateFlags.
+func (in *ClusterStateFlags) DeepCopy() *ClusterStateFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateMigrateFlags) DeepCopyInto(out *ClusterStateMigrateFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateMigrateFlags.
+func (in *ClusterStateMigrateFlags) DeepCopy() *ClusterStateMigrateFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateMigrateFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateRemoveFlags) DeepCopyInto(out *ClusterStateRemoveFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateRemoveFlags.
+func (in *ClusterStateRemoveFlags) DeepCopy() *ClusterStateRemoveFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateRemoveFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateRestoreFlags) DeepCopyInto(out *ClusterStateRestoreFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateRestoreFlags.
+func (in *ClusterStateRestoreFlags) DeepCopy() *ClusterStateRestoreFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateRestoreFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -193,6 +380,23 @@ func (in *Config) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigFlags) DeepCopyInto(out *ConfigFlags) {
+	*out = *in
+	out.Upgrade = in.Upgrade
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigFlags.
+func (in *ConfigFlags) DeepCopy() *ConfigFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigList) DeepCopyInto(out *ConfigList) {
 	*out = *in
@@ -226,6 +430,22 @@ func (in *ConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigUpgradeFlags) DeepCopyInto(out *ConfigUpgradeFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigUpgradeFlags.
+func (in *ConfigUpgradeFlags) DeepCopy() *ConfigUpgradeFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigUpgradeFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Environment) DeepCopyInto(out *Environment) {
 	*out = *in
@@ -262,6 +482,22 @@ func (in *Environment) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentApplyFlags) DeepCopyInto(out *EnvironmentApplyFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentApplyFlags.
+func (in *EnvironmentApplyFlags) DeepCopy() *EnvironmentApplyFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentApplyFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentDestroyFlags) DeepCopyInto(out *EnvironmentDestroyFlags) {
 	*out = *in
@@ -278,10 +514,28 @@ func (in *EnvironmentDestroyFlags) DeepCopy() *EnvironmentDestroyFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentDriftFlags) DeepCopyInto(out *EnvironmentDriftFlags) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentDriftFlags.
+func (in *EnvironmentDriftFlags) DeepCopy() *EnvironmentDriftFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentDriftFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentFlags) DeepCopyInto(out *EnvironmentFlags) {
 	*out = *in
 	out.Destroy = in.Destroy
+	out.Drift = in.Drift
+	out.Apply = in.Apply
 	return
 }
 
@@ -333,6 +587,8 @@ func (in *Flags) DeepCopyInto(out *Flags) {
 	*out = *in
 	out.Cluster = in.Cluster
 	out.Environment = in.Environment
+	in.Init.DeepCopyInto(&out.Init)
+	out.Config = in.Config
 	return
 }
 
@@ -372,6 +628,70 @@ func (in *Image) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportResource) DeepCopyInto(out *ImportResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportResource.
+func (in *ImportResource) DeepCopy() *ImportResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitFlags) DeepCopyInto(out *InitFlags) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstancePoolCounts != nil {
+		in, out := &in.InstancePoolCounts, &out.InstancePoolCounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstancePoolSizes != nil {
+		in, out := &in.InstancePoolSizes, &out.InstancePoolSizes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitFlags.
+func (in *InitFlags) DeepCopy() *InitFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(InitFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestHistoryEntry) DeepCopyInto(out *ManifestHistoryEntry) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestHistoryEntry.
+func (in *ManifestHistoryEntry) DeepCopy() *ManifestHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
@@ -498,3 +818,37 @@ func (in *ProviderList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateBackup) DeepCopyInto(out *StateBackup) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateBackup.
+func (in *StateBackup) DeepCopy() *StateBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(StateBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateLock) DeepCopyInto(out *StateLock) {
+	*out = *in
+	in.Created.DeepCopyInto(&out.Created)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateLock.
+func (in *StateLock) DeepCopy() *StateLock {
+	if in == nil {
+		return nil
+	}
+	out := new(StateLock)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -41,6 +41,12 @@ func SetObjectDefaults_Config(in *Config) {
 			if a.Kubernetes.ClusterAutoscaler != nil {
 				clusterv1alpha1.SetDefaults_ClusterKubernetesClusterAutoscaler(a.Kubernetes.ClusterAutoscaler)
 			}
+			if a.Kubernetes.Ingress != nil {
+				clusterv1alpha1.SetDefaults_ClusterKubernetesIngress(a.Kubernetes.Ingress)
+			}
+			if a.Kubernetes.PodIAM != nil {
+				clusterv1alpha1.SetDefaults_ClusterKubernetesPodIAM(a.Kubernetes.PodIAM)
+			}
 			if a.Kubernetes.APIServer != nil {
 				if a.Kubernetes.APIServer.Amazon != nil {
 					if a.Kubernetes.APIServer.Amazon.PublicELBAccessLogs != nil {
@@ -50,6 +56,30 @@ func SetObjectDefaults_Config(in *Config) {
 						clusterv1alpha1.SetDefaults_ClusterKubernetesAPIServerAmazonAccessLogs(a.Kubernetes.APIServer.Amazon.InternalELBAccessLogs)
 					}
 				}
+				if a.Kubernetes.APIServer.Audit != nil {
+					clusterv1alpha1.SetDefaults_ClusterKubernetesAPIServerAudit(a.Kubernetes.APIServer.Audit)
+					if a.Kubernetes.APIServer.Audit.Webhook != nil {
+						clusterv1alpha1.SetDefaults_ClusterKubernetesAPIServerAuditWebhook(a.Kubernetes.APIServer.Audit.Webhook)
+					}
+				}
+				if a.Kubernetes.APIServer.Encryption != nil {
+					clusterv1alpha1.SetDefaults_ClusterKubernetesAPIServerEncryption(a.Kubernetes.APIServer.Encryption)
+					if a.Kubernetes.APIServer.Encryption.KMS != nil {
+						clusterv1alpha1.SetDefaults_ClusterKubernetesAPIServerEncryptionKMS(a.Kubernetes.APIServer.Encryption.KMS)
+					}
+				}
+			}
+			if a.Kubernetes.Calico != nil {
+				for j := range a.Kubernetes.Calico.IPPools {
+					b := &a.Kubernetes.Calico.IPPools[j]
+					clusterv1alpha1.SetDefaults_ClusterKubernetesCalicoIPPool(b)
+				}
+			}
+			if a.Kubernetes.Networking != nil {
+				clusterv1alpha1.SetDefaults_ClusterKubernetesNetworking(a.Kubernetes.Networking)
+				if a.Kubernetes.Networking.Cilium != nil {
+					clusterv1alpha1.SetDefaults_ClusterKubernetesNetworkingCilium(a.Kubernetes.Networking.Cilium)
+				}
 			}
 		}
 	}
@@ -31,6 +31,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Instance{},
 		&InstanceList{},
+		&Command{},
+		&CommandList{},
 	)
 	return nil
 }
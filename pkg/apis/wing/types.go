@@ -36,6 +36,38 @@ type InstanceSpecManifest struct {
 type InstanceStatus struct {
 	Converge *InstanceStatusManifest
 	DryRun   *InstanceStatusManifest
+	Facts    *InstanceFacts
+
+	Terminated *InstanceTermination
+}
+
+// InstanceTermination records when the wing server noticed that the instance
+// vanished, the instance object is deleted after a grace period
+type InstanceTermination struct {
+	Timestamp metav1.Time
+	Reason    string
+}
+
+// InstanceFacts contains details about the instance's operating system and
+// installed software, as gathered by wing
+type InstanceFacts struct {
+	LastUpdateTimestamp metav1.Time
+	InstanceType        string
+	Kernel              string
+	OSRelease           string
+	PuppetVersion       string
+	KubeletVersion      string
+	DockerVersion       string
+	BootTimestamp       metav1.Time
+	RebootRequired      bool
+	Disks               []InstanceDiskUsage
+}
+
+// InstanceDiskUsage is the usage of a mounted filesystem
+type InstanceDiskUsage struct {
+	Path       string
+	TotalBytes int64
+	UsedBytes  int64
 }
 
 //  InstaceSpecManifest defines the state and hash of a run manifest
@@ -46,6 +78,44 @@ type InstanceStatusManifest struct {
 	LastUpdateTimestamp metav1.Time
 	Messages            []string
 	ExitCodes           []int
+	Report              *PuppetReport
+}
+
+// PuppetReport is a bounded summary of puppet's report of a run
+type PuppetReport struct {
+	Status    string
+	Time      metav1.Time
+	Duration  metav1.Duration
+	Resources PuppetResourceCounts
+	OutOfSync []PuppetResource
+	Omitted   int
+}
+
+// PuppetResourceCounts contains the number of resources by state
+type PuppetResourceCounts struct {
+	Total     int
+	Changed   int
+	Failed    int
+	OutOfSync int
+	Skipped   int
+}
+
+// PuppetResource is the status of a single resource in a puppet run
+type PuppetResource struct {
+	Resource string
+	File     string
+	Line     int
+	Changed  bool
+	Failed   bool
+	Duration metav1.Duration
+	Events   []PuppetEvent
+}
+
+// PuppetEvent is a single change of a resource's property
+type PuppetEvent struct {
+	Property string
+	Status   string
+	Message  string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -57,3 +127,50 @@ type InstanceList struct {
 
 	Items []Instance
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Command is an ad-hoc command run by wing on the matching instances
+type Command struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   *CommandSpec
+	Status *CommandStatus
+}
+
+// CommandSpec defines the command and the instances it runs on
+type CommandSpec struct {
+	InstancePool string
+	Selector     *metav1.LabelSelector
+	Command      []string
+	Timeout      metav1.Duration
+}
+
+// CommandStatus contains the results reported by the instances
+type CommandStatus struct {
+	Results []CommandResult
+}
+
+// CommandResult is the result of a command on a single instance
+type CommandResult struct {
+	InstanceName        string
+	State               CommandState
+	ExitCode            int
+	Output              string
+	StartTimestamp      metav1.Time
+	CompletionTimestamp metav1.Time
+}
+
+type CommandState string
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CommandList struct {
+	metav1.TypeMeta
+	// +optional
+	metav1.ListMeta
+
+	Items []Command
+}
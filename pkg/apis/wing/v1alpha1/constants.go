@@ -7,4 +7,14 @@ const (
 	InstanceManifestStateConverging = InstanceManifestState("converging")
 	InstanceManifestStateConverged  = InstanceManifestState("converged")
 	InstanceManifestStateError      = InstanceManifestState("error")
+	// the manifest failed signature verification and has not been applied
+	InstanceManifestStateRejected = InstanceManifestState("rejected")
+)
+
+type CommandState string
+
+const (
+	CommandStateRunning   = CommandState("running")
+	CommandStateSucceeded = CommandState("succeeded")
+	CommandStateFailed    = CommandState("failed")
 )
@@ -36,6 +36,39 @@ type InstanceSpecManifest struct {
 type InstanceStatus struct {
 	Converge *InstanceStatusManifest `json:"converge,omitempty"`
 	DryRun   *InstanceStatusManifest `json:"dryRun,omitempty"`
+	Facts    *InstanceFacts          `json:"facts,omitempty"`
+
+	// set by the wing server when the instance no longer exists at the provider
+	Terminated *InstanceTermination `json:"terminated,omitempty"`
+}
+
+// InstanceTermination records when the wing server noticed that the instance
+// vanished, the instance object is deleted after a grace period
+type InstanceTermination struct {
+	Timestamp metav1.Time `json:"timestamp,omitempty"` // timestamp when the instance was found missing
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// InstanceFacts contains details about the instance's operating system and
+// installed software, as gathered by wing
+type InstanceFacts struct {
+	LastUpdateTimestamp metav1.Time         `json:"lastUpdateTimestamp,omitempty"` // timestamp when the facts were gathered
+	InstanceType        string              `json:"instanceType,omitempty"`        // cloud provider's instance type
+	Kernel              string              `json:"kernel,omitempty"`              // kernel release
+	OSRelease           string              `json:"osRelease,omitempty"`           // pretty name of the os release
+	PuppetVersion       string              `json:"puppetVersion,omitempty"`
+	KubeletVersion      string              `json:"kubeletVersion,omitempty"`
+	DockerVersion       string              `json:"dockerVersion,omitempty"`
+	BootTimestamp       metav1.Time         `json:"bootTimestamp,omitempty"`  // timestamp when the instance booted
+	RebootRequired      bool                `json:"rebootRequired,omitempty"` // updates are pending a reboot
+	Disks               []InstanceDiskUsage `json:"disks,omitempty"`          // usage of local filesystems
+}
+
+// InstanceDiskUsage is the usage of a mounted filesystem
+type InstanceDiskUsage struct {
+	Path       string `json:"path"`
+	TotalBytes int64  `json:"totalBytes"`
+	UsedBytes  int64  `json:"usedBytes"`
 }
 
 //  InstaceSpecManifest defines the state and hash of a run manifest
@@ -45,6 +78,44 @@ type InstanceStatusManifest struct {
 	LastUpdateTimestamp metav1.Time           `json:"lastUpdateTimestamp,omitempty"` // timestamp when a converge was requested
 	Messages            []string              `json:"messages,omitempty"`            // contains output of the retries
 	ExitCodes           []int                 `json:"exitCodes,omitempty"`           // return code of the retries
+	Report              *PuppetReport         `json:"report,omitempty"`              // summary of the last puppet run
+}
+
+// PuppetReport is a bounded summary of puppet's report of a run
+type PuppetReport struct {
+	Status    string               `json:"status,omitempty"`    // changed, unchanged or failed
+	Time      metav1.Time          `json:"time,omitempty"`      // timestamp when the run started
+	Duration  metav1.Duration      `json:"duration,omitempty"`  // total duration of the run
+	Resources PuppetResourceCounts `json:"resources,omitempty"` // number of resources by state
+	OutOfSync []PuppetResource     `json:"outOfSync,omitempty"` // out of sync resources, failed resources first
+	Omitted   int                  `json:"omitted,omitempty"`   // number of out of sync resources not listed
+}
+
+// PuppetResourceCounts contains the number of resources by state
+type PuppetResourceCounts struct {
+	Total     int `json:"total,omitempty"`
+	Changed   int `json:"changed,omitempty"`
+	Failed    int `json:"failed,omitempty"`
+	OutOfSync int `json:"outOfSync,omitempty"`
+	Skipped   int `json:"skipped,omitempty"`
+}
+
+// PuppetResource is the status of a single resource in a puppet run
+type PuppetResource struct {
+	Resource string          `json:"resource"`           // type and title, eg: Service[kubelet]
+	File     string          `json:"file,omitempty"`     // file the resource is declared in
+	Line     int             `json:"line,omitempty"`     // line the resource is declared in
+	Changed  bool            `json:"changed,omitempty"`  // resource has been changed
+	Failed   bool            `json:"failed,omitempty"`   // resource failed to apply
+	Duration metav1.Duration `json:"duration,omitempty"` // time it took to evaluate the resource
+	Events   []PuppetEvent   `json:"events,omitempty"`   // events of the resource
+}
+
+// PuppetEvent is a single change of a resource's property
+type PuppetEvent struct {
+	Property string `json:"property,omitempty"`
+	Status   string `json:"status,omitempty"` // success, failure, noop or audit
+	Message  string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -55,3 +126,47 @@ type InstanceList struct {
 
 	Items []Instance `json:"items"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Command is an ad-hoc command run by wing on the matching instances
+type Command struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   *CommandSpec   `json:"spec,omitempty"`
+	Status *CommandStatus `json:"status,omitempty"`
+}
+
+// CommandSpec defines the command and the instances it runs on
+type CommandSpec struct {
+	InstancePool string                `json:"instancePool,omitempty"` // run on instances of this pool, all pools if empty
+	Selector     *metav1.LabelSelector `json:"selector,omitempty"`     // run on instances with matching labels
+	Command      []string              `json:"command"`                // command and its arguments, not run in a shell
+	Timeout      metav1.Duration       `json:"timeout,omitempty"`      // kill the command after this duration
+}
+
+// CommandStatus contains the results reported by the instances
+type CommandStatus struct {
+	Results []CommandResult `json:"results,omitempty"`
+}
+
+// CommandResult is the result of a command on a single instance
+type CommandResult struct {
+	InstanceName        string       `json:"instanceName"`
+	State               CommandState `json:"state,omitempty"`
+	ExitCode            int          `json:"exitCode"`
+	Output              string       `json:"output,omitempty"` // combined stdout and stderr, truncated to its end
+	StartTimestamp      metav1.Time  `json:"startTimestamp,omitempty"`
+	CompletionTimestamp metav1.Time  `json:"completionTimestamp,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CommandList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Command `json:"items"`
+}
@@ -10,6 +10,7 @@ import (
 	unsafe "unsafe"
 
 	wing "github.com/jetstack/tarmak/pkg/apis/wing"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -21,6 +22,56 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*Command)(nil), (*wing.Command)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Command_To_wing_Command(a.(*Command), b.(*wing.Command), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.Command)(nil), (*Command)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_Command_To_v1alpha1_Command(a.(*wing.Command), b.(*Command), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CommandList)(nil), (*wing.CommandList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CommandList_To_wing_CommandList(a.(*CommandList), b.(*wing.CommandList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.CommandList)(nil), (*CommandList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_CommandList_To_v1alpha1_CommandList(a.(*wing.CommandList), b.(*CommandList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CommandResult)(nil), (*wing.CommandResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CommandResult_To_wing_CommandResult(a.(*CommandResult), b.(*wing.CommandResult), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.CommandResult)(nil), (*CommandResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_CommandResult_To_v1alpha1_CommandResult(a.(*wing.CommandResult), b.(*CommandResult), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CommandSpec)(nil), (*wing.CommandSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CommandSpec_To_wing_CommandSpec(a.(*CommandSpec), b.(*wing.CommandSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.CommandSpec)(nil), (*CommandSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_CommandSpec_To_v1alpha1_CommandSpec(a.(*wing.CommandSpec), b.(*CommandSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CommandStatus)(nil), (*wing.CommandStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CommandStatus_To_wing_CommandStatus(a.(*CommandStatus), b.(*wing.CommandStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.CommandStatus)(nil), (*CommandStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_CommandStatus_To_v1alpha1_CommandStatus(a.(*wing.CommandStatus), b.(*CommandStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Instance)(nil), (*wing.Instance)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Instance_To_wing_Instance(a.(*Instance), b.(*wing.Instance), scope)
 	}); err != nil {
@@ -31,6 +82,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceDiskUsage)(nil), (*wing.InstanceDiskUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_InstanceDiskUsage_To_wing_InstanceDiskUsage(a.(*InstanceDiskUsage), b.(*wing.InstanceDiskUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.InstanceDiskUsage)(nil), (*InstanceDiskUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_InstanceDiskUsage_To_v1alpha1_InstanceDiskUsage(a.(*wing.InstanceDiskUsage), b.(*InstanceDiskUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InstanceFacts)(nil), (*wing.InstanceFacts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_InstanceFacts_To_wing_InstanceFacts(a.(*InstanceFacts), b.(*wing.InstanceFacts), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.InstanceFacts)(nil), (*InstanceFacts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_InstanceFacts_To_v1alpha1_InstanceFacts(a.(*wing.InstanceFacts), b.(*InstanceFacts), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceList)(nil), (*wing.InstanceList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_InstanceList_To_wing_InstanceList(a.(*InstanceList), b.(*wing.InstanceList), scope)
 	}); err != nil {
@@ -81,9 +152,181 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceTermination)(nil), (*wing.InstanceTermination)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_InstanceTermination_To_wing_InstanceTermination(a.(*InstanceTermination), b.(*wing.InstanceTermination), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.InstanceTermination)(nil), (*InstanceTermination)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_InstanceTermination_To_v1alpha1_InstanceTermination(a.(*wing.InstanceTermination), b.(*InstanceTermination), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PuppetEvent)(nil), (*wing.PuppetEvent)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PuppetEvent_To_wing_PuppetEvent(a.(*PuppetEvent), b.(*wing.PuppetEvent), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.PuppetEvent)(nil), (*PuppetEvent)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_PuppetEvent_To_v1alpha1_PuppetEvent(a.(*wing.PuppetEvent), b.(*PuppetEvent), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PuppetReport)(nil), (*wing.PuppetReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PuppetReport_To_wing_PuppetReport(a.(*PuppetReport), b.(*wing.PuppetReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.PuppetReport)(nil), (*PuppetReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_PuppetReport_To_v1alpha1_PuppetReport(a.(*wing.PuppetReport), b.(*PuppetReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PuppetResource)(nil), (*wing.PuppetResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PuppetResource_To_wing_PuppetResource(a.(*PuppetResource), b.(*wing.PuppetResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.PuppetResource)(nil), (*PuppetResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_PuppetResource_To_v1alpha1_PuppetResource(a.(*wing.PuppetResource), b.(*PuppetResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PuppetResourceCounts)(nil), (*wing.PuppetResourceCounts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PuppetResourceCounts_To_wing_PuppetResourceCounts(a.(*PuppetResourceCounts), b.(*wing.PuppetResourceCounts), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wing.PuppetResourceCounts)(nil), (*PuppetResourceCounts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wing_PuppetResourceCounts_To_v1alpha1_PuppetResourceCounts(a.(*wing.PuppetResourceCounts), b.(*PuppetResourceCounts), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_Command_To_wing_Command(in *Command, out *wing.Command, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = (*wing.CommandSpec)(unsafe.Pointer(in.Spec))
+	out.Status = (*wing.CommandStatus)(unsafe.Pointer(in.Status))
+	return nil
+}
+
+// Convert_v1alpha1_Command_To_wing_Command is an autogenerated conversion function.
+func Convert_v1alpha1_Command_To_wing_Command(in *Command, out *wing.Command, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Command_To_wing_Command(in, out, s)
+}
+
+func autoConvert_wing_Command_To_v1alpha1_Command(in *wing.Command, out *Command, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = (*CommandSpec)(unsafe.Pointer(in.Spec))
+	out.Status = (*CommandStatus)(unsafe.Pointer(in.Status))
+	return nil
+}
+
+// Convert_wing_Command_To_v1alpha1_Command is an autogenerated conversion function.
+func Convert_wing_Command_To_v1alpha1_Command(in *wing.Command, out *Command, s conversion.Scope) error {
+	return autoConvert_wing_Command_To_v1alpha1_Command(in, out, s)
+}
+
+func autoConvert_v1alpha1_CommandList_To_wing_CommandList(in *CommandList, out *wing.CommandList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]wing.Command)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_CommandList_To_wing_CommandList is an autogenerated conversion function.
+func Convert_v1alpha1_CommandList_To_wing_CommandList(in *CommandList, out *wing.CommandList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CommandList_To_wing_CommandList(in, out, s)
+}
+
+func autoConvert_wing_CommandList_To_v1alpha1_CommandList(in *wing.CommandList, out *CommandList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]Command)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_wing_CommandList_To_v1alpha1_CommandList is an autogenerated conversion function.
+func Convert_wing_CommandList_To_v1alpha1_CommandList(in *wing.CommandList, out *CommandList, s conversion.Scope) error {
+	return autoConvert_wing_CommandList_To_v1alpha1_CommandList(in, out, s)
+}
+
+func autoConvert_v1alpha1_CommandResult_To_wing_CommandResult(in *CommandResult, out *wing.CommandResult, s conversion.Scope) error {
+	out.InstanceName = in.InstanceName
+	out.State = wing.CommandState(in.State)
+	out.ExitCode = in.ExitCode
+	out.Output = in.Output
+	out.StartTimestamp = in.StartTimestamp
+	out.CompletionTimestamp = in.CompletionTimestamp
+	return nil
+}
+
+// Convert_v1alpha1_CommandResult_To_wing_CommandResult is an autogenerated conversion function.
+func Convert_v1alpha1_CommandResult_To_wing_CommandResult(in *CommandResult, out *wing.CommandResult, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CommandResult_To_wing_CommandResult(in, out, s)
+}
+
+func autoConvert_wing_CommandResult_To_v1alpha1_CommandResult(in *wing.CommandResult, out *CommandResult, s conversion.Scope) error {
+	out.InstanceName = in.InstanceName
+	out.State = CommandState(in.State)
+	out.ExitCode = in.ExitCode
+	out.Output = in.Output
+	out.StartTimestamp = in.StartTimestamp
+	out.CompletionTimestamp = in.CompletionTimestamp
+	return nil
+}
+
+// Convert_wing_CommandResult_To_v1alpha1_CommandResult is an autogenerated conversion function.
+func Convert_wing_CommandResult_To_v1alpha1_CommandResult(in *wing.CommandResult, out *CommandResult, s conversion.Scope) error {
+	return autoConvert_wing_CommandResult_To_v1alpha1_CommandResult(in, out, s)
+}
+
+func autoConvert_v1alpha1_CommandSpec_To_wing_CommandSpec(in *CommandSpec, out *wing.CommandSpec, s conversion.Scope) error {
+	out.InstancePool = in.InstancePool
+	out.Selector = (*v1.LabelSelector)(unsafe.Pointer(in.Selector))
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Timeout = in.Timeout
+	return nil
+}
+
+// Convert_v1alpha1_CommandSpec_To_wing_CommandSpec is an autogenerated conversion function.
+func Convert_v1alpha1_CommandSpec_To_wing_CommandSpec(in *CommandSpec, out *wing.CommandSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CommandSpec_To_wing_CommandSpec(in, out, s)
+}
+
+func autoConvert_wing_CommandSpec_To_v1alpha1_CommandSpec(in *wing.CommandSpec, out *CommandSpec, s conversion.Scope) error {
+	out.InstancePool = in.InstancePool
+	out.Selector = (*v1.LabelSelector)(unsafe.Pointer(in.Selector))
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Timeout = in.Timeout
 	return nil
 }
 
+// Convert_wing_CommandSpec_To_v1alpha1_CommandSpec is an autogenerated conversion function.
+func Convert_wing_CommandSpec_To_v1alpha1_CommandSpec(in *wing.CommandSpec, out *CommandSpec, s conversion.Scope) error {
+	return autoConvert_wing_CommandSpec_To_v1alpha1_CommandSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_CommandStatus_To_wing_CommandStatus(in *CommandStatus, out *wing.CommandStatus, s conversion.Scope) error {
+	out.Results = *(*[]wing.CommandResult)(unsafe.Pointer(&in.Results))
+	return nil
+}
+
+// Convert_v1alpha1_CommandStatus_To_wing_CommandStatus is an autogenerated conversion function.
+func Convert_v1alpha1_CommandStatus_To_wing_CommandStatus(in *CommandStatus, out *wing.CommandStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CommandStatus_To_wing_CommandStatus(in, out, s)
+}
+
+func autoConvert_wing_CommandStatus_To_v1alpha1_CommandStatus(in *wing.CommandStatus, out *CommandStatus, s conversion.Scope) error {
+	out.Results = *(*[]CommandResult)(unsafe.Pointer(&in.Results))
+	return nil
+}
+
+// Convert_wing_CommandStatus_To_v1alpha1_CommandStatus is an autogenerated conversion function.
+func Convert_wing_CommandStatus_To_v1alpha1_CommandStatus(in *wing.CommandStatus, out *CommandStatus, s conversion.Scope) error {
+	return autoConvert_wing_CommandStatus_To_v1alpha1_CommandStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_Instance_To_wing_Instance(in *Instance, out *wing.Instance, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	out.InstanceID = in.InstanceID
@@ -112,6 +355,68 @@ func Convert_wing_Instance_To_v1alpha1_Instance(in *wing.Instance, out *Instance
 	return autoConvert_wing_Instance_To_v1alpha1_Instance(in, out, s)
 }
 
+func autoConvert_v1alpha1_InstanceDiskUsage_To_wing_InstanceDiskUsage(in *InstanceDiskUsage, out *wing.InstanceDiskUsage, s conversion.Scope) error {
+	out.Path = in.Path
+	out.TotalBytes = in.TotalBytes
+	out.UsedBytes = in.UsedBytes
+	return nil
+}
+
+// Convert_v1alpha1_InstanceDiskUsage_To_wing_InstanceDiskUsage is an autogenerated conversion function.
+func Convert_v1alpha1_InstanceDiskUsage_To_wing_InstanceDiskUsage(in *InstanceDiskUsage, out *wing.InstanceDiskUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InstanceDiskUsage_To_wing_InstanceDiskUsage(in, out, s)
+}
+
+func autoConvert_wing_InstanceDiskUsage_To_v1alpha1_InstanceDiskUsage(in *wing.InstanceDiskUsage, out *InstanceDiskUsage, s conversion.Scope) error {
+	out.Path = in.Path
+	out.TotalBytes = in.TotalBytes
+	out.UsedBytes = in.UsedBytes
+	return nil
+}
+
+// Convert_wing_InstanceDiskUsage_To_v1alpha1_InstanceDiskUsage is an autogenerated conversion function.
+func Convert_wing_InstanceDiskUsage_To_v1alpha1_InstanceDiskUsage(in *wing.InstanceDiskUsage, out *InstanceDiskUsage, s conversion.Scope) error {
+	return autoConvert_wing_InstanceDiskUsage_To_v1alpha1_InstanceDiskUsage(in, out, s)
+}
+
+func autoConvert_v1alpha1_InstanceFacts_To_wing_InstanceFacts(in *InstanceFacts, out *wing.InstanceFacts, s conversion.Scope) error {
+	out.LastUpdateTimestamp = in.LastUpdateTimestamp
+	out.InstanceType = in.InstanceType
+	out.Kernel = in.Kernel
+	out.OSRelease = in.OSRelease
+	out.PuppetVersion = in.PuppetVersion
+	out.KubeletVersion = in.KubeletVersion
+	out.DockerVersion = in.DockerVersion
+	out.BootTimestamp = in.BootTimestamp
+	out.RebootRequired = in.RebootRequired
+	out.Disks = *(*[]wing.InstanceDiskUsage)(unsafe.Pointer(&in.Disks))
+	return nil
+}
+
+// Convert_v1alpha1_InstanceFacts_To_wing_InstanceFacts is an autogenerated conversion function.
+func Convert_v1alpha1_InstanceFacts_To_wing_InstanceFacts(in *InstanceFacts, out *wing.InstanceFacts, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InstanceFacts_To_wing_InstanceFacts(in, out, s)
+}
+
+func autoConvert_wing_InstanceFacts_To_v1alpha1_InstanceFacts(in *wing.InstanceFacts, out *InstanceFacts, s conversion.Scope) error {
+	out.LastUpdateTimestamp = in.LastUpdateTimestamp
+	out.InstanceType = in.InstanceType
+	out.Kernel = in.Kernel
+	out.OSRelease = in.OSRelease
+	out.PuppetVersion = in.PuppetVersion
+	out.KubeletVersion = in.KubeletVersion
+	out.DockerVersion = in.DockerVersion
+	out.BootTimestamp = in.BootTimestamp
+	out.RebootRequired = in.RebootRequired
+	out.Disks = *(*[]InstanceDiskUsage)(unsafe.Pointer(&in.Disks))
+	return nil
+}
+
+// Convert_wing_InstanceFacts_To_v1alpha1_InstanceFacts is an autogenerated conversion function.
+func Convert_wing_InstanceFacts_To_v1alpha1_InstanceFacts(in *wing.InstanceFacts, out *InstanceFacts, s conversion.Scope) error {
+	return autoConvert_wing_InstanceFacts_To_v1alpha1_InstanceFacts(in, out, s)
+}
+
 func autoConvert_v1alpha1_InstanceList_To_wing_InstanceList(in *InstanceList, out *wing.InstanceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]wing.Instance)(unsafe.Pointer(&in.Items))
@@ -183,6 +488,8 @@ func Convert_wing_InstanceSpecManifest_To_v1alpha1_InstanceSpecManifest(in *wing
 func autoConvert_v1alpha1_InstanceStatus_To_wing_InstanceStatus(in *InstanceStatus, out *wing.InstanceStatus, s conversion.Scope) error {
 	out.Converge = (*wing.InstanceStatusManifest)(unsafe.Pointer(in.Converge))
 	out.DryRun = (*wing.InstanceStatusManifest)(unsafe.Pointer(in.DryRun))
+	out.Facts = (*wing.InstanceFacts)(unsafe.Pointer(in.Facts))
+	out.Terminated = (*wing.InstanceTermination)(unsafe.Pointer(in.Terminated))
 	return nil
 }
 
@@ -194,6 +501,8 @@ func Convert_v1alpha1_InstanceStatus_To_wing_InstanceStatus(in *InstanceStatus,
 func autoConvert_wing_InstanceStatus_To_v1alpha1_InstanceStatus(in *wing.InstanceStatus, out *InstanceStatus, s conversion.Scope) error {
 	out.Converge = (*InstanceStatusManifest)(unsafe.Pointer(in.Converge))
 	out.DryRun = (*InstanceStatusManifest)(unsafe.Pointer(in.DryRun))
+	out.Facts = (*InstanceFacts)(unsafe.Pointer(in.Facts))
+	out.Terminated = (*InstanceTermination)(unsafe.Pointer(in.Terminated))
 	return nil
 }
 
@@ -208,6 +517,7 @@ func autoConvert_v1alpha1_InstanceStatusManifest_To_wing_InstanceStatusManifest(
 	out.LastUpdateTimestamp = in.LastUpdateTimestamp
 	out.Messages = *(*[]string)(unsafe.Pointer(&in.Messages))
 	out.ExitCodes = *(*[]int)(unsafe.Pointer(&in.ExitCodes))
+	out.Report = (*wing.PuppetReport)(unsafe.Pointer(in.Report))
 	return nil
 }
 
@@ -222,6 +532,7 @@ func autoConvert_wing_InstanceStatusManifest_To_v1alpha1_InstanceStatusManifest(
 	out.LastUpdateTimestamp = in.LastUpdateTimestamp
 	out.Messages = *(*[]string)(unsafe.Pointer(&in.Messages))
 	out.ExitCodes = *(*[]int)(unsafe.Pointer(&in.ExitCodes))
+	out.Report = (*PuppetReport)(unsafe.Pointer(in.Report))
 	return nil
 }
 
@@ -229,3 +540,143 @@ func autoConvert_wing_InstanceStatusManifest_To_v1alpha1_InstanceStatusManifest(
 func Convert_wing_InstanceStatusManifest_To_v1alpha1_InstanceStatusManifest(in *wing.InstanceStatusManifest, out *InstanceStatusManifest, s conversion.Scope) error {
 	return autoConvert_wing_InstanceStatusManifest_To_v1alpha1_InstanceStatusManifest(in, out, s)
 }
+
+func autoConvert_v1alpha1_InstanceTermination_To_wing_InstanceTermination(in *InstanceTermination, out *wing.InstanceTermination, s conversion.Scope) error {
+	out.Timestamp = in.Timestamp
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_v1alpha1_InstanceTermination_To_wing_InstanceTermination is an autogenerated conversion function.
+func Convert_v1alpha1_InstanceTermination_To_wing_InstanceTermination(in *InstanceTermination, out *wing.InstanceTermination, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InstanceTermination_To_wing_InstanceTermination(in, out, s)
+}
+
+func autoConvert_wing_InstanceTermination_To_v1alpha1_InstanceTermination(in *wing.InstanceTermination, out *InstanceTermination, s conversion.Scope) error {
+	out.Timestamp = in.Timestamp
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_wing_InstanceTermination_To_v1alpha1_InstanceTermination is an autogenerated conversion function.
+func Convert_wing_InstanceTermination_To_v1alpha1_InstanceTermination(in *wing.InstanceTermination, out *InstanceTermination, s conversion.Scope) error {
+	return autoConvert_wing_InstanceTermination_To_v1alpha1_InstanceTermination(in, out, s)
+}
+
+func autoConvert_v1alpha1_PuppetEvent_To_wing_PuppetEvent(in *PuppetEvent, out *wing.PuppetEvent, s conversion.Scope) error {
+	out.Property = in.Property
+	out.Status = in.Status
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_PuppetEvent_To_wing_PuppetEvent is an autogenerated conversion function.
+func Convert_v1alpha1_PuppetEvent_To_wing_PuppetEvent(in *PuppetEvent, out *wing.PuppetEvent, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PuppetEvent_To_wing_PuppetEvent(in, out, s)
+}
+
+func autoConvert_wing_PuppetEvent_To_v1alpha1_PuppetEvent(in *wing.PuppetEvent, out *PuppetEvent, s conversion.Scope) error {
+	out.Property = in.Property
+	out.Status = in.Status
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_wing_PuppetEvent_To_v1alpha1_PuppetEvent is an autogenerated conversion function.
+func Convert_wing_PuppetEvent_To_v1alpha1_PuppetEvent(in *wing.PuppetEvent, out *PuppetEvent, s conversion.Scope) error {
+	return autoConvert_wing_PuppetEvent_To_v1alpha1_PuppetEvent(in, out, s)
+}
+
+func autoConvert_v1alpha1_PuppetReport_To_wing_PuppetReport(in *PuppetReport, out *wing.PuppetReport, s conversion.Scope) error {
+	out.Status = in.Status
+	out.Time = in.Time
+	out.Duration = in.Duration
+	if err := Convert_v1alpha1_PuppetResourceCounts_To_wing_PuppetResourceCounts(&in.Resources, &out.Resources, s); err != nil {
+		return err
+	}
+	out.OutOfSync = *(*[]wing.PuppetResource)(unsafe.Pointer(&in.OutOfSync))
+	out.Omitted = in.Omitted
+	return nil
+}
+
+// Convert_v1alpha1_PuppetReport_To_wing_PuppetReport is an autogenerated conversion function.
+func Convert_v1alpha1_PuppetReport_To_wing_PuppetReport(in *PuppetReport, out *wing.PuppetReport, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PuppetReport_To_wing_PuppetReport(in, out, s)
+}
+
+func autoConvert_wing_PuppetReport_To_v1alpha1_PuppetReport(in *wing.PuppetReport, out *PuppetReport, s conversion.Scope) error {
+	out.Status = in.Status
+	out.Time = in.Time
+	out.Duration = in.Duration
+	if err := Convert_wing_PuppetResourceCounts_To_v1alpha1_PuppetResourceCounts(&in.Resources, &out.Resources, s); err != nil {
+		return err
+	}
+	out.OutOfSync = *(*[]PuppetResource)(unsafe.Pointer(&in.OutOfSync))
+	out.Omitted = in.Omitted
+	return nil
+}
+
+// Convert_wing_PuppetReport_To_v1alpha1_PuppetReport is an autogenerated conversion function.
+func Convert_wing_PuppetReport_To_v1alpha1_PuppetReport(in *wing.PuppetReport, out *PuppetReport, s conversion.Scope) error {
+	return autoConvert_wing_PuppetReport_To_v1alpha1_PuppetReport(in, out, s)
+}
+
+func autoConvert_v1alpha1_PuppetResource_To_wing_PuppetResource(in *PuppetResource, out *wing.PuppetResource, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.File = in.File
+	out.Line = in.Line
+	out.Changed = in.Changed
+	out.Failed = in.Failed
+	out.Duration = in.Duration
+	out.Events = *(*[]wing.PuppetEvent)(unsafe.Pointer(&in.Events))
+	return nil
+}
+
+// Convert_v1alpha1_PuppetResource_To_wing_PuppetResource is an autogenerated conversion function.
+func Convert_v1alpha1_PuppetResource_To_wing_PuppetResource(in *PuppetResource, out *wing.PuppetResource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PuppetResource_To_wing_PuppetResource(in, out, s)
+}
+
+func autoConvert_wing_PuppetResource_To_v1alpha1_PuppetResource(in *wing.PuppetResource, out *PuppetResource, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.File = in.File
+	out.Line = in.Line
+	out.Changed = in.Changed
+	out.Failed = in.Failed
+	out.Duration = in.Duration
+	out.Events = *(*[]PuppetEvent)(unsafe.Pointer(&in.Events))
+	return nil
+}
+
+// Convert_wing_PuppetResource_To_v1alpha1_PuppetResource is an autogenerated conversion function.
+func Convert_wing_PuppetResource_To_v1alpha1_PuppetResource(in *wing.PuppetResource, out *PuppetResource, s conversion.Scope) error {
+	return autoConvert_wing_PuppetResource_To_v1alpha1_PuppetResource(in, out, s)
+}
+
+func autoConvert_v1alpha1_PuppetResourceCounts_To_wing_PuppetResourceCounts(in *PuppetResourceCounts, out *wing.PuppetResourceCounts, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Changed = in.Changed
+	out.Failed = in.Failed
+	out.OutOfSync = in.OutOfSync
+	out.Skipped = in.Skipped
+	return nil
+}
+
+// Convert_v1alpha1_PuppetResourceCounts_To_wing_PuppetResourceCounts is an autogenerated conversion function.
+func Convert_v1alpha1_PuppetResourceCounts_To_wing_PuppetResourceCounts(in *PuppetResourceCounts, out *wing.PuppetResourceCounts, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PuppetResourceCounts_To_wing_PuppetResourceCounts(in, out, s)
+}
+
+func autoConvert_wing_PuppetResourceCounts_To_v1alpha1_PuppetResourceCounts(in *wing.PuppetResourceCounts, out *PuppetResourceCounts, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Changed = in.Changed
+	out.Failed = in.Failed
+	out.OutOfSync = in.OutOfSync
+	out.Skipped = in.Skipped
+	return nil
+}
+
+// Convert_wing_PuppetResourceCounts_To_v1alpha1_PuppetResourceCounts is an autogenerated conversion function.
+func Convert_wing_PuppetResourceCounts_To_v1alpha1_PuppetResourceCounts(in *wing.PuppetResourceCounts, out *PuppetResourceCounts, s conversion.Scope) error {
+	return autoConvert_wing_PuppetResourceCounts_To_v1alpha1_PuppetResourceCounts(in, out, s)
+}
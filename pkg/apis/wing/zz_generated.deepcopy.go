@@ -7,9 +7,147 @@
 package wing
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Command) DeepCopyInto(out *Command) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(CommandSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(CommandStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Command.
+func (in *Command) DeepCopy() *Command {
+	if in == nil {
+		return nil
+	}
+	out := new(Command)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Command) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandList) DeepCopyInto(out *CommandList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Command, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandList.
+func (in *CommandList) DeepCopy() *CommandList {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CommandList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandResult) DeepCopyInto(out *CommandResult) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandResult.
+func (in *CommandResult) DeepCopy() *CommandResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSpec) DeepCopyInto(out *CommandSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSpec.
+func (in *CommandSpec) DeepCopy() *CommandSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandStatus) DeepCopyInto(out *CommandStatus) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]CommandResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandStatus.
+func (in *CommandStatus) DeepCopy() *CommandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -46,6 +184,45 @@ func (in *Instance) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceDiskUsage) DeepCopyInto(out *InstanceDiskUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceDiskUsage.
+func (in *InstanceDiskUsage) DeepCopy() *InstanceDiskUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceDiskUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceFacts) DeepCopyInto(out *InstanceFacts) {
+	*out = *in
+	in.LastUpdateTimestamp.DeepCopyInto(&out.LastUpdateTimestamp)
+	in.BootTimestamp.DeepCopyInto(&out.BootTimestamp)
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]InstanceDiskUsage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceFacts.
+func (in *InstanceFacts) DeepCopy() *InstanceFacts {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceFacts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceList) DeepCopyInto(out *InstanceList) {
 	*out = *in
@@ -135,6 +312,16 @@ func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
 		*out = new(InstanceStatusManifest)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Facts != nil {
+		in, out := &in.Facts, &out.Facts
+		*out = new(InstanceFacts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Terminated != nil {
+		in, out := &in.Terminated, &out.Terminated
+		*out = new(InstanceTermination)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -162,6 +349,11 @@ func (in *InstanceStatusManifest) DeepCopyInto(out *InstanceStatusManifest) {
 		*out = make([]int, len(*in))
 		copy(*out, *in)
 	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(PuppetReport)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -174,3 +366,100 @@ func (in *InstanceStatusManifest) DeepCopy() *InstanceStatusManifest {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceTermination) DeepCopyInto(out *InstanceTermination) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceTermination.
+func (in *InstanceTermination) DeepCopy() *InstanceTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetEvent) DeepCopyInto(out *PuppetEvent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetEvent.
+func (in *PuppetEvent) DeepCopy() *PuppetEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetReport) DeepCopyInto(out *PuppetReport) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Duration = in.Duration
+	out.Resources = in.Resources
+	if in.OutOfSync != nil {
+		in, out := &in.OutOfSync, &out.OutOfSync
+		*out = make([]PuppetResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetReport.
+func (in *PuppetReport) DeepCopy() *PuppetReport {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetResource) DeepCopyInto(out *PuppetResource) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]PuppetEvent, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetResource.
+func (in *PuppetResource) DeepCopy() *PuppetResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetResourceCounts) DeepCopyInto(out *PuppetResourceCounts) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetResourceCounts.
+func (in *PuppetResourceCounts) DeepCopy() *PuppetResourceCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetResourceCounts)
+	in.DeepCopyInto(out)
+	return out
+}
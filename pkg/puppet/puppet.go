@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/docker/docker/pkg/archive"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
@@ -182,6 +183,14 @@ func kubernetesClusterConfig(conf *clusterv1alpha1.ClusterKubernetes, hieraData
 		hieraData.variables = append(hieraData.variables, "kubernetes::apiserver::aws_iam_authenticator_init: true")
 	}
 
+	if conf.APIServer != nil && conf.APIServer.Audit != nil {
+		apiServerAuditConfig(conf.APIServer.Audit, hieraData)
+	}
+
+	if conf.APIServer != nil && conf.APIServer.Encryption != nil {
+		apiServerEncryptionConfig(conf.APIServer.Encryption, hieraData)
+	}
+
 	if conf.PodSecurityPolicy != nil {
 		if conf.PodSecurityPolicy.Enabled {
 			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`tarmak::kubernetes_pod_security_policy: true`))
@@ -248,7 +257,36 @@ func kubernetesClusterConfig(conf *clusterv1alpha1.ClusterKubernetes, hieraData
 		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::controller_manager::feature_gates:%s`, gates))
 	}
 
-	if conf.Calico != nil {
+	provider := clusterv1alpha1.NetworkingProviderCalico
+	if conf.Networking != nil && conf.Networking.Provider != "" {
+		provider = conf.Networking.Provider
+	}
+	hieraData.variables = append(hieraData.variables, fmt.Sprintf("tarmak::kubernetes_network_provider: %s", provider))
+
+	switch provider {
+	case clusterv1alpha1.NetworkingProviderCilium:
+		// cilium allocates pod addresses from the node's pod CIDR
+		hieraData.variables = append(hieraData.variables, "kubernetes::controller_manager::allocate_node_cidrs: true")
+		if c := conf.Networking.Cilium; c != nil {
+			if c.Version != "" {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf("kubernetes_addons::cilium::version: %s", hieraString(c.Version)))
+			}
+			if c.Tunnel != "" {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf("kubernetes_addons::cilium::tunnel: %s", c.Tunnel))
+			}
+		}
+	case clusterv1alpha1.NetworkingProviderAmazonVPC:
+		if c := conf.Networking.AmazonVPCCNI; c != nil {
+			if c.Version != "" {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf("kubernetes_addons::aws_vpc_cni::version: %s", hieraString(c.Version)))
+			}
+			if c.WarmIPTarget != nil {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf("kubernetes_addons::aws_vpc_cni::warm_ip_target: %d", *c.WarmIPTarget))
+			}
+		}
+	}
+
+	if conf.Calico != nil && provider == clusterv1alpha1.NetworkingProviderCalico {
 		hieraData.variables = append(hieraData.variables, fmt.Sprintf("tarmak::calico_backend: %s", conf.Calico.Backend))
 
 		hieraData.variables = append(hieraData.variables, fmt.Sprintf("calico::typha_enabled: %v", conf.Calico.EnableTypha))
@@ -258,13 +296,272 @@ func kubernetesClusterConfig(conf *clusterv1alpha1.ClusterKubernetes, hieraData
 
 		if conf.Calico.Backend == "kubernetes" {
 			hieraData.variables = append(hieraData.variables, "kubernetes::controller_manager::allocate_node_cidrs: true")
-			hieraData.variables = append(hieraData.variables, "calico::node::ipv4_pool_ipip_mode: cross-subnet")
 		}
+
+		calicoConfig(conf.Calico, hieraData)
 	}
 
+	addonsConfig(conf, provider, hieraData)
+
 	return
 }
 
+// node label selecting the instances hosting the ingress controller
+const ingressNodeLabel = "tarmak.io/ingress"
+
+// ingressInstancePool returns true if the ingress controller is bound to
+// the given instance pool
+func ingressInstancePool(conf *clusterv1alpha1.ClusterKubernetes, name string) bool {
+	return conf != nil && conf.Ingress != nil && conf.Ingress.Enabled && conf.Ingress.InstancePool == name
+}
+
+// addonsConfig only configures add-ons that are part of the cluster
+// configuration, so add-ons deployed by other means are left untouched
+func addonsConfig(conf *clusterv1alpha1.ClusterKubernetes, networkingProvider string, hieraData *hieraData) {
+	// ingress controller
+	if i := conf.Ingress; i != nil && i.Enabled {
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::default_backend::ensure: "present"`)
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::nginx_ingress::ensure: "present"`)
+		if i.Image != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::nginx_ingress::image: "%s"`, i.Image))
+		}
+		if i.Version != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::nginx_ingress::version: "%s"`, i.Version))
+		}
+		if i.Replicas != nil {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::nginx_ingress::replicas: %d`, *i.Replicas))
+		}
+		if i.ServiceType != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::nginx_ingress::service_type: "%s"`, i.ServiceType))
+		}
+
+		annotations := make(map[string]string)
+		if i.LoadBalancerType == clusterv1alpha1.IngressLoadBalancerTypeNLB {
+			annotations["service.beta.kubernetes.io/aws-load-balancer-type"] = "nlb"
+		}
+		if i.Internal {
+			annotations["service.beta.kubernetes.io/aws-load-balancer-internal"] = "0.0.0.0/0"
+		}
+		for key, value := range i.ServiceAnnotations {
+			annotations[key] = value
+		}
+		if len(annotations) > 0 {
+			data, err := json.Marshal(annotations)
+			if err != nil {
+				panic(err)
+			}
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::nginx_ingress::service_annotations: %s`, string(data)))
+		}
+
+		if i.InstancePool != "" {
+			data, err := json.Marshal(map[string]string{ingressNodeLabel: "true"})
+			if err != nil {
+				panic(err)
+			}
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::nginx_ingress::node_selector: %s`, string(data)))
+		}
+	} else if i != nil {
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::default_backend::ensure: "absent"`)
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::nginx_ingress::ensure: "absent"`)
+	}
+
+	// metrics server, default: enabled
+	if m := conf.MetricsServer; m == nil || m.Enabled {
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::metrics_server::ensure: "present"`)
+		if m != nil && m.Image != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::metrics_server::image: "%s"`, m.Image))
+		}
+		if m != nil && m.Version != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::metrics_server::version: "%s"`, m.Version))
+		}
+	} else {
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::metrics_server::ensure: "absent"`)
+	}
+
+	// IAM roles for pods, the provider not selected is removed to allow
+	// switching between them
+	if p := conf.PodIAM; p != nil {
+		for _, provider := range []string{clusterv1alpha1.PodIAMProviderKube2IAM, clusterv1alpha1.PodIAMProviderKiam} {
+			if provider != p.Provider {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::%s::ensure: "absent"`, provider))
+				continue
+			}
+
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::%s::ensure: "present"`, provider))
+			if p.BaseRoleARN != "" {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::%s::base_role_arn: "%s"`, provider, p.BaseRoleARN))
+			}
+			if p.Image != "" {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::%s::image: "%s"`, provider, p.Image))
+			}
+			if p.Version != "" {
+				hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::%s::version: "%s"`, provider, p.Version))
+			}
+
+			// the agent intercepts metadata requests of the pod interfaces
+			hostInterface := "cali+"
+			switch networkingProvider {
+			case clusterv1alpha1.NetworkingProviderCilium:
+				hostInterface = "lxc+"
+			case clusterv1alpha1.NetworkingProviderAmazonVPC:
+				hostInterface = "eni+"
+			}
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::%s::host_interface: "%s"`, provider, hostInterface))
+		}
+	}
+
+	// cert-manager
+	if c := conf.CertManager; c != nil && c.Enabled {
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::cert_manager::ensure: "present"`)
+		if c.Image != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::cert_manager::image: "%s"`, c.Image))
+		}
+		if c.Version != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::cert_manager::version: "%s"`, c.Version))
+		}
+		if c.ACMEEmail != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes_addons::cert_manager::acme_email: "%s"`, c.ACMEEmail))
+		}
+	} else if c != nil {
+		hieraData.variables = append(hieraData.variables, `kubernetes_addons::cert_manager::ensure: "absent"`)
+	}
+}
+
+func calicoConfig(conf *clusterv1alpha1.ClusterKubernetesCalico, hieraData *hieraData) {
+	if conf.Encapsulation != "" {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf("calico::encapsulation: %s", conf.Encapsulation))
+	}
+
+	if conf.Encapsulation == "" || conf.Encapsulation == clusterv1alpha1.CalicoEncapsulationIPIP {
+		mode := conf.EncapsulationMode
+		if mode == "" && conf.Backend == clusterv1alpha1.CalicoBackendKubernetes {
+			mode = clusterv1alpha1.CalicoEncapsulationModeCrossSubnet
+		}
+		if mode != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf("calico::node::ipv4_pool_ipip_mode: %s", mode))
+		}
+	}
+
+	if conf.MTU != nil {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf("calico::mtu: %d", *conf.MTU))
+	}
+
+	if len(conf.IPPools) > 0 {
+		var pools []map[string]interface{}
+		for _, p := range conf.IPPools {
+			pool := map[string]interface{}{
+				"name": p.Name,
+				"cidr": p.CIDR,
+			}
+			if p.BlockSize != nil {
+				pool["block_size"] = *p.BlockSize
+			}
+			if p.NodeSelector != "" {
+				pool["node_selector"] = p.NodeSelector
+			}
+			if p.NATOutgoing != nil {
+				pool["nat_outgoing"] = *p.NATOutgoing
+			}
+			pools = append(pools, pool)
+		}
+
+		data, err := json.Marshal(pools)
+		if err != nil {
+			panic(err)
+		}
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf("calico::ip_pools: %s", string(data)))
+	}
+
+	if len(conf.BGPPeers) > 0 {
+		var peers []map[string]interface{}
+		for _, p := range conf.BGPPeers {
+			peer := map[string]interface{}{
+				"name":      p.Name,
+				"peer_ip":   p.PeerIP,
+				"as_number": p.ASNumber,
+			}
+			if p.NodeSelector != "" {
+				peer["node_selector"] = p.NodeSelector
+			}
+			peers = append(peers, peer)
+		}
+
+		data, err := json.Marshal(peers)
+		if err != nil {
+			panic(err)
+		}
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf("calico::bgp_peers: %s", string(data)))
+	}
+
+	if conf.DefaultDeny {
+		hieraData.variables = append(hieraData.variables, "calico::default_deny: true")
+	}
+}
+
+func apiServerAuditConfig(conf *clusterv1alpha1.ClusterKubernetesAPIServerAudit, hieraData *hieraData) {
+	if conf.Enabled != nil {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_enabled: %t`, *conf.Enabled))
+	}
+
+	if conf.Policy != "" {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_policy: %s`, hieraString(conf.Policy)))
+	}
+
+	if conf.LogFormat != "" {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_log_format: "%s"`, conf.LogFormat))
+	}
+	if conf.LogMaxAge != nil {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_log_maxage: %d`, *conf.LogMaxAge))
+	}
+	if conf.LogMaxBackup != nil {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_log_maxbackup: %d`, *conf.LogMaxBackup))
+	}
+	if conf.LogMaxSize != nil {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_log_maxsize: %d`, *conf.LogMaxSize))
+	}
+
+	if w := conf.Webhook; w != nil && w.URL != "" {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_webhook_server: "%s"`, w.URL))
+		if w.CA != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_webhook_ca: %s`, hieraString(w.CA)))
+		}
+		if w.Mode != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_webhook_mode: "%s"`, w.Mode))
+		}
+		if w.InitialBackoff != "" {
+			hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_webhook_initial_backoff: "%s"`, w.InitialBackoff))
+		}
+	}
+}
+
+func apiServerEncryptionConfig(conf *clusterv1alpha1.ClusterKubernetesAPIServerEncryption, hieraData *hieraData) {
+	if conf.Provider != clusterv1alpha1.EncryptionProviderKMS || conf.KMS == nil {
+		return
+	}
+
+	hieraData.variables = append(hieraData.variables, `tarmak::master::encryption_kms_plugin: true`)
+	hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::kms_plugin::key_arn: "%s"`, conf.KMS.KeyARN))
+	if a, err := arn.Parse(conf.KMS.KeyARN); err == nil {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::kms_plugin::region: "%s"`, a.Region))
+	}
+
+	if conf.KMS.PluginVersion != "" {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::kms_plugin::version: "%s"`, conf.KMS.PluginVersion))
+	}
+	if conf.KMS.PluginURL != "" {
+		hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::kms_plugin::download_url: "%s"`, conf.KMS.PluginURL))
+	}
+}
+
+// quote a possibly multi-line string, so it can be used as a hiera value
+func hieraString(in string) string {
+	data, err := json.Marshal(in)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
 func featureGatesString(globalGates, componentGates map[string]bool, usePodPriority bool, conf *clusterv1alpha1.ClusterKubernetesClusterAutoscaler) string {
 	gates := utils.DuplicateMapBool(globalGates)
 	if usePodPriority {
@@ -382,6 +679,20 @@ func kubernetesClusterConfigPerRole(conf *clusterv1alpha1.ClusterKubernetes, rol
 		}
 	}
 
+	// only add-ons that are configured are managed
+	if roleName == clusterv1alpha1.KubernetesMasterRoleName {
+		if conf.Ingress != nil {
+			hieraData.classes = append(hieraData.classes, `kubernetes_addons::nginx_ingress`)
+		}
+		if conf.PodIAM != nil {
+			hieraData.classes = append(hieraData.classes, `kubernetes_addons::kube2iam`)
+			hieraData.classes = append(hieraData.classes, `kubernetes_addons::kiam`)
+		}
+		if conf.CertManager != nil {
+			hieraData.classes = append(hieraData.classes, `kubernetes_addons::cert_manager`)
+		}
+	}
+
 	if g := conf.Grafana; g != nil && conf.Grafana.Enabled {
 		hieraData.variables = append(hieraData.variables, `kubernetes_addons::grafana::ensure: "present"`)
 	} else {
@@ -431,6 +742,9 @@ func (p *Puppet) contentClusterConfig(cluster interfaces.Cluster) ([]string, err
                 hieraData.variables = append(hieraData.variables, fmt.Sprintf("tarmak::master::apiserver_additional_san_domains: %s", string(sansJSON)))
         }
 	kubernetesClusterConfig(cluster.Config().Kubernetes, hieraData)
+	if err := p.auditPolicyFileConfig(cluster.Config().Kubernetes, hieraData); err != nil {
+		return nil, err
+	}
         amazonClusterConfig(cluster.Config().Amazon, hieraData)
 
 	hieraData.classes = append(hieraData.classes, `tarmak::fluent_bit`)
@@ -461,6 +775,30 @@ func (p *Puppet) contentClusterConfig(cluster interfaces.Cluster) ([]string, err
 	return append(classes, variables...), nil
 }
 
+// read the audit policy from a local file, if configured
+func (p *Puppet) auditPolicyFileConfig(conf *clusterv1alpha1.ClusterKubernetes, hieraData *hieraData) error {
+	if conf == nil || conf.APIServer == nil || conf.APIServer.Audit == nil || conf.APIServer.Audit.PolicyFile == "" {
+		return nil
+	}
+
+	path, err := p.tarmak.HomeDirExpand(conf.APIServer.Audit.PolicyFile)
+	if err != nil {
+		return fmt.Errorf("unable to expand audit policy file path: %s", err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.tarmak.ConfigPath(), path)
+	}
+
+	policy, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read audit policy file: %s", err)
+	}
+
+	hieraData.variables = append(hieraData.variables, fmt.Sprintf(`kubernetes::apiserver::audit_policy: %s`, hieraString(string(policy))))
+
+	return nil
+}
+
 func contentInstancePoolConfig(clusterConf *clusterv1alpha1.Cluster, instanceConf *clusterv1alpha1.InstancePool, roleName string) (classes, variables []string) {
 
 	hieraData := &hieraData{}
@@ -570,14 +908,21 @@ func (p *Puppet) writeHieraData(puppetPath string, cluster interfaces.Cluster) e
 
 		var taintLabelError error
 
+		var labels []string
 		if len(instancePool.Config().Labels) > 0 {
-			labels, err := instancePool.Labels()
+			l, err := instancePool.Labels()
 			if err != nil {
 				taintLabelError = multierror.Append(taintLabelError, fmt.Errorf("error reading instance pool labels: %s", err))
 			} else {
-				variables = append(variables, fmt.Sprintf("kubernetes::kubelet::node_labels:\n%s", labels))
+				labels = append(labels, l)
 			}
 		}
+		if ingressInstancePool(cluster.Config().Kubernetes, instancePool.Name()) {
+			labels = append(labels, fmt.Sprintf(`  %s: "true"`, ingressNodeLabel))
+		}
+		if len(labels) > 0 {
+			variables = append(variables, fmt.Sprintf("kubernetes::kubelet::node_labels:\n%s", strings.Join(labels, "\n")))
+		}
 		if len(instancePool.Config().Taints) > 0 {
 			taints, err := instancePool.Taints()
 			if err != nil {
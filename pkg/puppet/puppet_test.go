@@ -2,6 +2,7 @@
 package puppet
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -48,6 +49,298 @@ func TestOIDCFields(t *testing.T) {
 
 }
 
+func TestAuditFields(t *testing.T) {
+	enabled := true
+	maxAge := 30
+	c := clusterv1alpha1.ClusterKubernetes{
+		APIServer: &clusterv1alpha1.ClusterKubernetesAPIServer{
+			Audit: &clusterv1alpha1.ClusterKubernetesAPIServerAudit{
+				Enabled:   &enabled,
+				Policy:    "apiVersion: audit.k8s.io/v1\nkind: Policy",
+				LogFormat: clusterv1alpha1.AuditLogFormatJSON,
+				LogMaxAge: &maxAge,
+				Webhook: &clusterv1alpha1.ClusterKubernetesAPIServerAuditWebhook{
+					URL:            "https://audit.example.com",
+					Mode:           clusterv1alpha1.AuditWebhookModeBatch,
+					InitialBackoff: "10s",
+				},
+			},
+		},
+	}
+
+	d := hieraData{}
+
+	kubernetesClusterConfig(&c, &d)
+
+	for _, exp := range []string{
+		`kubernetes::apiserver::audit_enabled: true`,
+		`kubernetes::apiserver::audit_policy: "apiVersion: audit.k8s.io/v1\nkind: Policy"`,
+		`kubernetes::apiserver::audit_log_format: "json"`,
+		`kubernetes::apiserver::audit_log_maxage: 30`,
+		`kubernetes::apiserver::audit_webhook_server: "https://audit.example.com"`,
+		`kubernetes::apiserver::audit_webhook_mode: "batch"`,
+		`kubernetes::apiserver::audit_webhook_initial_backoff: "10s"`,
+	} {
+		found := false
+		for _, v := range d.variables {
+			if v == exp {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected variable not found: %s", exp)
+		}
+	}
+
+	for _, v := range d.variables {
+		if strings.Contains(v, "audit_webhook_ca") {
+			t.Errorf("unexpected variable: %s", v)
+		}
+	}
+}
+
+func TestEncryptionFields(t *testing.T) {
+	c := clusterv1alpha1.ClusterKubernetes{
+		APIServer: &clusterv1alpha1.ClusterKubernetesAPIServer{
+			Encryption: &clusterv1alpha1.ClusterKubernetesAPIServerEncryption{
+				Provider: clusterv1alpha1.EncryptionProviderAESCBC,
+			},
+		},
+	}
+
+	d := hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	for _, v := range d.variables {
+		if strings.Contains(v, "kms_plugin") {
+			t.Errorf("unexpected variable for aescbc provider: %s", v)
+		}
+	}
+
+	c.APIServer.Encryption = &clusterv1alpha1.ClusterKubernetesAPIServerEncryption{
+		Provider: clusterv1alpha1.EncryptionProviderKMS,
+		KMS: &clusterv1alpha1.ClusterKubernetesAPIServerEncryptionKMS{
+			KeyARN:        "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			PluginVersion: "0.1.0",
+		},
+	}
+
+	d = hieraData{}
+	kubernetesClusterConfig(&c, &d)
+
+	for _, exp := range []string{
+		`tarmak::master::encryption_kms_plugin: true`,
+		`kubernetes::kms_plugin::key_arn: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"`,
+		`kubernetes::kms_plugin::region: "eu-west-1"`,
+		`kubernetes::kms_plugin::version: "0.1.0"`,
+	} {
+		found := false
+		for _, v := range d.variables {
+			if v == exp {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected variable not found: %s", exp)
+		}
+	}
+}
+
+func TestNetworkingFields(t *testing.T) {
+	c := clusterv1alpha1.ClusterKubernetes{
+		Calico: &clusterv1alpha1.ClusterKubernetesCalico{
+			Backend: clusterv1alpha1.CalicoBackendEtcd,
+		},
+	}
+
+	d := hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	expectVariables(t, d.variables, []string{
+		`tarmak::kubernetes_network_provider: calico`,
+		`tarmak::calico_backend: etcd`,
+	})
+
+	warmIPTarget := 5
+	c.Networking = &clusterv1alpha1.ClusterKubernetesNetworking{
+		Provider: clusterv1alpha1.NetworkingProviderAmazonVPC,
+		AmazonVPCCNI: &clusterv1alpha1.ClusterKubernetesNetworkingAmazonVPCCNI{
+			Version:      "1.6.3",
+			WarmIPTarget: &warmIPTarget,
+		},
+	}
+
+	d = hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	expectVariables(t, d.variables, []string{
+		`tarmak::kubernetes_network_provider: aws-vpc-cni`,
+		`kubernetes_addons::aws_vpc_cni::version: "1.6.3"`,
+		`kubernetes_addons::aws_vpc_cni::warm_ip_target: 5`,
+	})
+	for _, v := range d.variables {
+		if strings.Contains(v, "calico") {
+			t.Errorf("unexpected calico variable for aws-vpc-cni provider: %s", v)
+		}
+	}
+
+	c.Networking = &clusterv1alpha1.ClusterKubernetesNetworking{
+		Provider: clusterv1alpha1.NetworkingProviderCilium,
+		Cilium: &clusterv1alpha1.ClusterKubernetesNetworkingCilium{
+			Tunnel: clusterv1alpha1.CiliumTunnelGeneve,
+		},
+	}
+
+	d = hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	expectVariables(t, d.variables, []string{
+		`tarmak::kubernetes_network_provider: cilium`,
+		`kubernetes::controller_manager::allocate_node_cidrs: true`,
+		`kubernetes_addons::cilium::tunnel: geneve`,
+	})
+}
+
+func TestCalicoFields(t *testing.T) {
+	mtu := 1450
+	blockSize := 24
+	c := clusterv1alpha1.ClusterKubernetes{
+		Calico: &clusterv1alpha1.ClusterKubernetesCalico{
+			Backend:           clusterv1alpha1.CalicoBackendKubernetes,
+			Encapsulation:     clusterv1alpha1.CalicoEncapsulationVXLAN,
+			EncapsulationMode: clusterv1alpha1.CalicoEncapsulationModeAlways,
+			MTU:               &mtu,
+			IPPools: []clusterv1alpha1.ClusterKubernetesCalicoIPPool{
+				{Name: "pool-a", CIDR: "100.64.0.0/17", BlockSize: &blockSize},
+			},
+			BGPPeers: []clusterv1alpha1.ClusterKubernetesCalicoBGPPeer{
+				{Name: "rack1-tor", PeerIP: "10.99.0.1", ASNumber: 64512},
+			},
+			DefaultDeny: true,
+		},
+	}
+
+	d := hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	expectVariables(t, d.variables, []string{
+		`calico::encapsulation: vxlan`,
+		`calico::mtu: 1450`,
+		`calico::ip_pools: [{"block_size":24,"cidr":"100.64.0.0/17","name":"pool-a"}]`,
+		`calico::bgp_peers: [{"as_number":64512,"name":"rack1-tor","peer_ip":"10.99.0.1"}]`,
+		`calico::default_deny: true`,
+	})
+	for _, v := range d.variables {
+		if strings.Contains(v, "ipv4_pool_ipip_mode") {
+			t.Errorf("unexpected ipip mode for vxlan encapsulation: %s", v)
+		}
+	}
+
+	c.Calico.Encapsulation = clusterv1alpha1.CalicoEncapsulationIPIP
+	c.Calico.EncapsulationMode = clusterv1alpha1.CalicoEncapsulationModeCrossSubnet
+	d = hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	expectVariables(t, d.variables, []string{
+		`calico::encapsulation: ipip`,
+		`calico::node::ipv4_pool_ipip_mode: cross-subnet`,
+	})
+}
+
+func TestAddonsFields(t *testing.T) {
+	c := clusterv1alpha1.ClusterKubernetes{}
+
+	// add-ons that are not configured are left untouched
+	d := hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	kubernetesClusterConfigPerRole(&c, clusterv1alpha1.KubernetesMasterRoleName, &d)
+	expectVariables(t, d.variables, []string{
+		`kubernetes_addons::metrics_server::ensure: "present"`,
+	})
+	for _, v := range d.variables {
+		for _, addon := range []string{"nginx_ingress", "default_backend", "kube2iam", "kiam", "cert_manager"} {
+			if strings.HasPrefix(v, fmt.Sprintf("kubernetes_addons::%s::", addon)) {
+				t.Errorf("unexpected variable for unconfigured add-on: %s", v)
+			}
+		}
+	}
+	for _, class := range d.classes {
+		for _, addon := range []string{"nginx_ingress", "kube2iam", "kiam", "cert_manager"} {
+			if class == fmt.Sprintf("kubernetes_addons::%s", addon) {
+				t.Errorf("unexpected class for unconfigured add-on: %s", class)
+			}
+		}
+	}
+
+	c = clusterv1alpha1.ClusterKubernetes{
+		Ingress:     &clusterv1alpha1.ClusterKubernetesIngress{},
+		CertManager: &clusterv1alpha1.ClusterKubernetesCertManager{},
+	}
+	d = hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	expectVariables(t, d.variables, []string{
+		`kubernetes_addons::nginx_ingress::ensure: "absent"`,
+		`kubernetes_addons::cert_manager::ensure: "absent"`,
+	})
+
+	replicas := 3
+	c = clusterv1alpha1.ClusterKubernetes{
+		Ingress: &clusterv1alpha1.ClusterKubernetesIngress{
+			Enabled:          true,
+			Replicas:         &replicas,
+			InstancePool:     "ingress",
+			ServiceType:      clusterv1alpha1.IngressServiceTypeLoadBalancer,
+			LoadBalancerType: clusterv1alpha1.IngressLoadBalancerTypeNLB,
+			Internal:         true,
+			ServiceAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "10.0.0.0/8",
+			},
+		},
+		MetricsServer: &clusterv1alpha1.ClusterKubernetesMetricsServer{},
+		PodIAM: &clusterv1alpha1.ClusterKubernetesPodIAM{
+			Provider:    clusterv1alpha1.PodIAMProviderKiam,
+			BaseRoleARN: "arn:aws:iam::123456789012:role/",
+		},
+		Networking: &clusterv1alpha1.ClusterKubernetesNetworking{
+			Provider: clusterv1alpha1.NetworkingProviderCilium,
+		},
+		CertManager: &clusterv1alpha1.ClusterKubernetesCertManager{
+			Enabled:   true,
+			Version:   "0.8.1",
+			ACMEEmail: "ops@example.com",
+		},
+	}
+
+	d = hieraData{}
+	kubernetesClusterConfig(&c, &d)
+	expectVariables(t, d.variables, []string{
+		`kubernetes_addons::nginx_ingress::ensure: "present"`,
+		`kubernetes_addons::nginx_ingress::replicas: 3`,
+		`kubernetes_addons::nginx_ingress::service_type: "LoadBalancer"`,
+		`kubernetes_addons::nginx_ingress::service_annotations: {"service.beta.kubernetes.io/aws-load-balancer-internal":"10.0.0.0/8","service.beta.kubernetes.io/aws-load-balancer-type":"nlb"}`,
+		`kubernetes_addons::nginx_ingress::node_selector: {"tarmak.io/ingress":"true"}`,
+		`kubernetes_addons::metrics_server::ensure: "absent"`,
+		`kubernetes_addons::kube2iam::ensure: "absent"`,
+		`kubernetes_addons::kiam::ensure: "present"`,
+		`kubernetes_addons::kiam::base_role_arn: "arn:aws:iam::123456789012:role/"`,
+		`kubernetes_addons::kiam::host_interface: "lxc+"`,
+		`kubernetes_addons::cert_manager::ensure: "present"`,
+		`kubernetes_addons::cert_manager::version: "0.8.1"`,
+		`kubernetes_addons::cert_manager::acme_email: "ops@example.com"`,
+	})
+}
+
+func expectVariables(t *testing.T, variables, expected []string) {
+	for _, exp := range expected {
+		found := false
+		for _, v := range variables {
+			if v == exp {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected variable not found: %s", exp)
+		}
+	}
+}
+
 func TestFeatureGatesString(t *testing.T) {
 	f := &featureGateMap{
 		T: t,
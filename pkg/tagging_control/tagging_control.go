@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -22,6 +24,7 @@ import (
 const (
 	tagSize   = 255
 	tagPrefix = "tarmak.io"
+	tagEOF    = "==EOF"
 
 	AWSCACert = `-----BEGIN CERTIFICATE-----
 MIIDIjCCAougAwIBAgIJAKnL4UEDMN/FMA0GCSqGSIb3DQEBBQUAMGoxCzAJBgNV
@@ -112,6 +115,23 @@ func HandleRequests(ctx context.Context, request TagInstanceRequest) error {
 // verify the rsa signature against the instance identity content and AWS global
 // cert
 func (h *Handler) verify() error {
+	if err := VerifyIdentityDocument(h.request.InstanceDocumentRaw, h.request.RSASignature); err != nil {
+		return err
+	}
+
+	// verify ssh keys
+	for k, v := range h.request.PublicKeys {
+		if err := VerifyKeySignature(v, h.request.KeySignatures[k], h.request.InstanceDocumentRaw); err != nil {
+			return fmt.Errorf("could not verify public key %s: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyIdentityDocument verifies the base64 encoded rsa signature of an
+// instance identity document against the AWS global cert
+func VerifyIdentityDocument(document, rsaSignature []byte) error {
 	block, rest := pem.Decode([]byte(AWSCACert))
 	if len(rest) != 0 {
 		return fmt.Errorf("expected to fully parse AWS certificate but had remainder: %s", rest)
@@ -122,39 +142,89 @@ func (h *Handler) verify() error {
 		return err
 	}
 
-	rsaSignature, err := base64.StdEncoding.DecodeString(string(h.request.RSASignature))
+	signature, err := base64.StdEncoding.DecodeString(string(rsaSignature))
 	if err != nil {
 		return err
 	}
 
-	err = awsCaCert.CheckSignature(x509.SHA256WithRSA, h.request.InstanceDocumentRaw, rsaSignature)
+	err = awsCaCert.CheckSignature(x509.SHA256WithRSA, document, signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify identity document signature against AWS: %s", err)
 	}
 
-	// verify ssh keys
-	for k, v := range h.request.PublicKeys {
-		pk, _, _, rest, err := ssh.ParseAuthorizedKey(v)
+	return nil
+}
+
+// VerifyKeySignature verifies data has been signed by the private key of an
+// authorized ssh public key
+func VerifyKeySignature(publicKey []byte, sig *ssh.Signature, data []byte) error {
+	pk, _, _, rest, err := ssh.ParseAuthorizedKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %s", err)
+	}
+
+	if len(rest) != 0 {
+		return fmt.Errorf("got rest parsing public key: %s", rest)
+	}
+
+	if sig == nil {
+		return errors.New("did not receive signature")
+	}
+
+	return pk.Verify(data, sig)
+}
+
+// PublicKeysFromTags reassembles the public keys split up into instance tags
+func PublicKeysFromTags(tags map[string]string) (map[string][]byte, error) {
+	parts := make(map[string]map[int]string)
+
+	for key, value := range tags {
+		if !strings.HasPrefix(key, tagPrefix+"/") {
+			continue
+		}
+
+		name := strings.TrimPrefix(key, tagPrefix+"/")
+		pos := strings.LastIndex(name, "-")
+		if pos < 0 {
+			continue
+		}
+
+		index, err := strconv.Atoi(name[pos+1:])
 		if err != nil {
-			return fmt.Errorf("failed to parse public key: %s", err)
+			continue
 		}
 
-		if len(rest) != 0 {
-			return fmt.Errorf("got rest parsing public key: %s", rest)
+		name = name[:pos]
+		if _, ok := parts[name]; !ok {
+			parts[name] = make(map[int]string)
 		}
+		parts[name][index] = value
+	}
 
-		sig, ok := h.request.KeySignatures[k]
-		if !ok {
-			return fmt.Errorf("did not receive signature for public key %s", k)
+	publicKeys := make(map[string][]byte)
+	for name, values := range parts {
+		var indexes []int
+		for index := range values {
+			indexes = append(indexes, index)
 		}
+		sort.Ints(indexes)
 
-		err = pk.Verify(h.request.InstanceDocumentRaw, sig)
-		if err != nil {
-			return fmt.Errorf("could not verify public key %s: %s", k, err)
+		var data string
+		for i, index := range indexes {
+			if i != index {
+				return nil, fmt.Errorf("missing part %d of public key %s", i, name)
+			}
+			data += values[index]
+		}
+
+		if !strings.HasSuffix(data, tagEOF) {
+			return nil, fmt.Errorf("public key %s is incomplete", name)
 		}
+
+		publicKeys[name] = []byte(strings.TrimSuffix(data, tagEOF))
 	}
 
-	return nil
+	return publicKeys, nil
 }
 
 // check generated tags against the ec2 instance
@@ -213,7 +283,7 @@ func (h *Handler) createTags() map[string]string {
 	tags := make(map[string]string)
 
 	for keyName, data := range h.request.PublicKeys {
-		data = append(data, []byte("==EOF")...)
+		data = append(data, []byte(tagEOF)...)
 
 		for i := 0; i < len(data); i += tagSize {
 			end := i + tagSize
@@ -174,6 +174,38 @@ func Test_createTags(t *testing.T) {
 	}, tags)
 }
 
+func Test_PublicKeysFromTags(t *testing.T) {
+	longKey := make([]byte, 300)
+	for i := range longKey {
+		longKey[i] = 'a'
+	}
+
+	h := &Handler{
+		request: &TagInstanceRequest{
+			PublicKeys: map[string][]byte{
+				"ssh_host_rsa_key":     longKey,
+				"ssh_host_ed25519_key": ED25519PublicKey,
+			},
+		},
+	}
+
+	tags := h.createTags()
+	tags["Name"] = "not-a-key"
+
+	publicKeys, err := PublicKeysFromTags(tags)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(h.request.PublicKeys, publicKeys) {
+		t.Errorf("got mismatch of public keys\nexp=%s\ngot=%s", h.request.PublicKeys, publicKeys)
+	}
+
+	delete(tags, "tarmak.io/ssh_host_rsa_key-0")
+	if _, err := PublicKeysFromTags(tags); err == nil {
+		t.Error("expected error for missing key part")
+	}
+}
+
 func checkTags(t *testing.T, exp, got map[string]string) {
 	if !reflect.DeepEqual(exp, got) {
 		t.Fatalf("got mismatch of tags\nexp=%s\ngot=%s", exp, got)
@@ -4,10 +4,14 @@ package cluster
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"net/url"
 	"path/filepath"
 	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-version"
 	"github.com/sirupsen/logrus"
@@ -91,6 +95,13 @@ func NewFromConfig(environment interfaces.Environment, conf *clusterv1alpha1.Clu
 				}
 			}
 		}
+
+		// expose the ingress controller through the ELB of the worker role
+		if ingress := k.Ingress; ingress != nil && ingress.Enabled && ingress.ServiceType == clusterv1alpha1.IngressServiceTypeNodePort {
+			if worker := cluster.Role("worker"); worker != nil {
+				worker.AWS.ELBIngress = true
+			}
+		}
 	}
 
 	// setup instance pools
@@ -370,6 +381,7 @@ func (c *Cluster) verifyHubState() error {
 		"vault_vault_url",
 		"tagging_control_tagging_control_policy_arn",
 		"bastion_bastion_wing_binary_read_policy_arn",
+		"bastion_wing_ca",
 	}
 	var result *multierror.Error
 	for _, r := range requiredHubResources {
@@ -424,6 +436,11 @@ func (c *Cluster) Validate() error {
 		result = multierror.Append(result, err)
 	}
 
+	// validate region
+	if err := c.validateRegion(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	//validate logging
 	if err := c.validateLoggingSinks(); err != nil {
 		result = multierror.Append(result, err)
@@ -449,12 +466,31 @@ func (c *Cluster) Validate() error {
 			}
 		}
 
+		//validate pod network
+		if err := c.validateNetworking(); err != nil {
+			result = multierror.Append(result, err)
+		}
+
 		//validate calico
-		if c.Config().Kubernetes.Calico != nil {
+		if c.Config().Kubernetes.Calico != nil && c.networkingProvider() == clusterv1alpha1.NetworkingProviderCalico {
 			if err := c.validateCalico(); err != nil {
 				result = multierror.Append(result, err)
 			}
 		}
+
+		//validate ingress controller
+		if c.Config().Kubernetes.Ingress != nil && c.Config().Kubernetes.Ingress.Enabled {
+			if err := c.validateIngress(); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+
+		//validate pod IAM roles
+		if c.Config().Kubernetes.PodIAM != nil {
+			if err := c.validatePodIAM(); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
 	}
 
 	return result.ErrorOrNil()
@@ -481,6 +517,37 @@ func (c *Cluster) validateNetwork() (result error) {
 	return nil
 }
 
+// validate the placement of clusters in another region than their
+// environment, they get their own network which is peered with the hub's
+func (c *Cluster) validateRegion() error {
+	if c.Region() == c.environment.Location() {
+		return nil
+	}
+
+	if c.Type() != clusterv1alpha1.ClusterTypeClusterMulti {
+		return fmt.Errorf("cluster %s of type %s can't be placed in region %s, only clusters of type %s can be placed in another region than their environment (%s)",
+			c.Name(), c.Type(), c.Region(), clusterv1alpha1.ClusterTypeClusterMulti, c.environment.Location())
+	}
+
+	if _, ok := c.Config().Network.ObjectMeta.Annotations[clusterv1alpha1.ExistingVPCAnnotationKey]; ok {
+		return fmt.Errorf("cluster %s can't use an existing VPC, as it is placed in another region than its environment", c.Name())
+	}
+
+	hub := c.environment.Hub()
+	if hub == nil || hub.Config().Network == nil || c.networkCIDR == nil {
+		return nil
+	}
+	_, hubNet, err := net.ParseCIDR(hub.Config().Network.CIDR)
+	if err != nil {
+		return nil
+	}
+	if hubNet.Contains(c.networkCIDR.IP) || c.networkCIDR.Contains(hubNet.IP) {
+		return fmt.Errorf("network %s of cluster %s overlaps with network %s of the hub, which it is peered with", c.networkCIDR, c.Name(), hubNet)
+	}
+
+	return nil
+}
+
 // validate logging configuration
 func (c *Cluster) validateLoggingSinks() (result error) {
 
@@ -497,6 +564,19 @@ func (c *Cluster) validateLoggingSinks() (result error) {
 					return fmt.Errorf("cannot enable AWS elasticsearch proxy and specify a custom CA for logging sink %d", index)
 				}
 			}
+
+			for _, t := range loggingSink.Types {
+				if t != clusterv1alpha1.LoggingSinkTypeAudit {
+					continue
+				}
+				if !c.auditEnabled() {
+					return fmt.Errorf("cannot ship audit logs for logging sink %d with API server auditing disabled", index)
+				}
+				if k := c.Config().Kubernetes; k != nil && k.APIServer != nil && k.APIServer.Audit != nil &&
+					k.APIServer.Audit.LogFormat == clusterv1alpha1.AuditLogFormatLegacy {
+					return fmt.Errorf("cannot ship audit logs for logging sink %d with audit log format '%s'", index, clusterv1alpha1.AuditLogFormatLegacy)
+				}
+			}
 		}
 	}
 
@@ -574,6 +654,14 @@ func (c *Cluster) validateAPIServer() (result error) {
 		}
 	}
 
+	if err := c.validateAPIServerAudit(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if err := c.validateAPIServerEncryption(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	vK, err := version.NewVersion(c.Config().Kubernetes.Version)
 	if err != nil {
 		return multierror.Append(result, err)
@@ -593,6 +681,149 @@ func (c *Cluster) validateAPIServer() (result error) {
 	return result
 }
 
+func (c *Cluster) validateAPIServerAudit() error {
+	var result *multierror.Error
+
+	audit := c.Config().Kubernetes.APIServer.Audit
+	if audit == nil {
+		return nil
+	}
+
+	if audit.Policy != "" && audit.PolicyFile != "" {
+		result = multierror.Append(result, errors.New("audit policy and policyFile are mutually exclusive"))
+	}
+
+	if audit.LogFormat != clusterv1alpha1.AuditLogFormatJSON &&
+		audit.LogFormat != clusterv1alpha1.AuditLogFormatLegacy {
+		result = multierror.Append(result, fmt.Errorf(
+			"audit logFormat may only be set to [%s %s], got=%s",
+			clusterv1alpha1.AuditLogFormatJSON, clusterv1alpha1.AuditLogFormatLegacy, audit.LogFormat))
+	}
+
+	for _, l := range []struct {
+		name  string
+		value *int
+	}{
+		{"logMaxAge", audit.LogMaxAge},
+		{"logMaxBackup", audit.LogMaxBackup},
+		{"logMaxSize", audit.LogMaxSize},
+	} {
+		if l.value != nil && *l.value < 0 {
+			result = multierror.Append(result, fmt.Errorf("audit %s cannot be negative, got=%d", l.name, *l.value))
+		}
+	}
+
+	if w := audit.Webhook; w != nil {
+		u, err := url.Parse(w.URL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			result = multierror.Append(result, fmt.Errorf("audit webhook url '%s' is not a valid URL", w.URL))
+		}
+
+		if w.Mode != clusterv1alpha1.AuditWebhookModeBatch &&
+			w.Mode != clusterv1alpha1.AuditWebhookModeBlocking {
+			result = multierror.Append(result, fmt.Errorf(
+				"audit webhook mode may only be set to [%s %s], got=%s",
+				clusterv1alpha1.AuditWebhookModeBatch, clusterv1alpha1.AuditWebhookModeBlocking, w.Mode))
+		}
+
+		if _, err := time.ParseDuration(w.InitialBackoff); err != nil {
+			result = multierror.Append(result, fmt.Errorf("audit webhook initialBackoff '%s' is not a valid duration: %s", w.InitialBackoff, err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (c *Cluster) validateAPIServerEncryption() error {
+	var result *multierror.Error
+
+	encryption := c.Config().Kubernetes.APIServer.Encryption
+	if encryption == nil {
+		return nil
+	}
+
+	for _, r := range encryption.Resources {
+		if r == "" {
+			result = multierror.Append(result, errors.New("encryption resources cannot contain an empty resource name"))
+		}
+	}
+
+	switch encryption.Provider {
+	case clusterv1alpha1.EncryptionProviderAESCBC:
+		if encryption.KMS != nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"encryption kms options are only valid with provider %s", clusterv1alpha1.EncryptionProviderKMS))
+		}
+
+	case clusterv1alpha1.EncryptionProviderKMS:
+		kms := encryption.KMS
+		if kms == nil || kms.KeyARN == "" {
+			result = multierror.Append(result, errors.New("encryption provider kms requires a kms keyARN"))
+			break
+		}
+
+		a, err := arn.Parse(kms.KeyARN)
+		if err != nil || a.Service != "kms" {
+			result = multierror.Append(result, fmt.Errorf("encryption kms keyARN '%s' is not a valid KMS key ARN", kms.KeyARN))
+		}
+
+		if kms.CacheSize != nil && *kms.CacheSize < 0 {
+			result = multierror.Append(result, fmt.Errorf("encryption kms cacheSize cannot be negative, got=%d", *kms.CacheSize))
+		}
+
+		if _, err := time.ParseDuration(kms.Timeout); err != nil {
+			result = multierror.Append(result, fmt.Errorf("encryption kms timeout '%s' is not a valid duration: %s", kms.Timeout, err))
+		}
+
+		// the kms provider was introduced with Kubernetes 1.10
+		vK, err := version.NewVersion(c.Config().Kubernetes.Version)
+		if err != nil {
+			result = multierror.Append(result, err)
+			break
+		}
+		v110, err := version.NewVersion("1.10.0")
+		if err != nil {
+			result = multierror.Append(result, err)
+			break
+		}
+		if vK.LessThan(v110) {
+			result = multierror.Append(result, fmt.Errorf(
+				"encryption provider kms requires kubernetes version 1.10.0 or later, got=%s", vK))
+		}
+
+	default:
+		result = multierror.Append(result, fmt.Errorf(
+			"encryption provider may only be set to [%s %s], got=%s",
+			clusterv1alpha1.EncryptionProviderAESCBC, clusterv1alpha1.EncryptionProviderKMS, encryption.Provider))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// returns whether API server auditing is enabled for this cluster
+func (c *Cluster) auditEnabled() bool {
+	k := c.Config().Kubernetes
+	if k == nil {
+		return true
+	}
+
+	if k.APIServer != nil && k.APIServer.Audit != nil && k.APIServer.Audit.Enabled != nil {
+		return *k.APIServer.Audit.Enabled
+	}
+
+	// puppet enables auditing by default from Kubernetes 1.8 onwards
+	vK, err := version.NewVersion(k.Version)
+	if err != nil {
+		return true
+	}
+	v18, err := version.NewVersion("1.8.0")
+	if err != nil {
+		return true
+	}
+
+	return !vK.LessThan(v18)
+}
+
 func (c *Cluster) validatePrometheusMode() error {
 	var result error
 
@@ -612,6 +843,96 @@ func (c *Cluster) validatePrometheusMode() error {
 	return result
 }
 
+// networkingProvider returns the network plugin providing the pod network
+func (c *Cluster) networkingProvider() string {
+	if k := c.Config().Kubernetes; k != nil && k.Networking != nil && k.Networking.Provider != "" {
+		return k.Networking.Provider
+	}
+	return clusterv1alpha1.NetworkingProviderCalico
+}
+
+// validate the network provider and how pod and service CIDR fit into the
+// VPC network
+func (c *Cluster) validateNetworking() error {
+	var result *multierror.Error
+
+	k := c.Config().Kubernetes
+	provider := c.networkingProvider()
+
+	switch provider {
+	case clusterv1alpha1.NetworkingProviderCalico:
+	case clusterv1alpha1.NetworkingProviderCilium:
+		if cilium := k.Networking.Cilium; cilium != nil &&
+			cilium.Tunnel != clusterv1alpha1.CiliumTunnelVXLAN &&
+			cilium.Tunnel != clusterv1alpha1.CiliumTunnelGeneve {
+			result = multierror.Append(result, fmt.Errorf(
+				"cilium's tunnel may only be set to [%s %s], got=%s",
+				clusterv1alpha1.CiliumTunnelVXLAN, clusterv1alpha1.CiliumTunnelGeneve, cilium.Tunnel))
+		}
+	case clusterv1alpha1.NetworkingProviderAmazonVPC:
+		if vpcCNI := k.Networking.AmazonVPCCNI; vpcCNI != nil &&
+			vpcCNI.WarmIPTarget != nil && *vpcCNI.WarmIPTarget < 0 {
+			result = multierror.Append(result, fmt.Errorf(
+				"aws-vpc-cni's warmIPTarget may not be negative, got=%d", *vpcCNI.WarmIPTarget))
+		}
+	default:
+		result = multierror.Append(result, fmt.Errorf(
+			"networking provider may only be set to [%s %s %s], got=%s",
+			clusterv1alpha1.NetworkingProviderCalico, clusterv1alpha1.NetworkingProviderCilium,
+			clusterv1alpha1.NetworkingProviderAmazonVPC, provider))
+	}
+
+	var podNet, serviceNet, vpcNet *net.IPNet
+	var err error
+
+	if k.PodCIDR != "" {
+		if _, podNet, err = net.ParseCIDR(k.PodCIDR); err != nil {
+			result = multierror.Append(result, fmt.Errorf("error parsing pod CIDR: %s", err))
+		}
+	}
+	if k.ServiceCIDR != "" {
+		if _, serviceNet, err = net.ParseCIDR(k.ServiceCIDR); err != nil {
+			result = multierror.Append(result, fmt.Errorf("error parsing service CIDR: %s", err))
+		}
+	}
+	if n := c.Config().Network; n != nil && n.CIDR != "" {
+		// errors are reported by validateNetwork
+		_, vpcNet, _ = net.ParseCIDR(n.CIDR)
+	}
+
+	if podNet != nil && serviceNet != nil && cidrsOverlap(podNet, serviceNet) {
+		result = multierror.Append(result, fmt.Errorf(
+			"pod CIDR %s overlaps with service CIDR %s", podNet, serviceNet))
+	}
+
+	if vpcNet != nil && serviceNet != nil && cidrsOverlap(vpcNet, serviceNet) {
+		result = multierror.Append(result, fmt.Errorf(
+			"service CIDR %s overlaps with network CIDR %s", serviceNet, vpcNet))
+	}
+
+	if vpcNet != nil && podNet != nil {
+		if provider == clusterv1alpha1.NetworkingProviderAmazonVPC {
+			// pods get their addresses assigned from the VPC's subnets
+			podOnes, _ := podNet.Mask.Size()
+			vpcOnes, _ := vpcNet.Mask.Size()
+			if !vpcNet.Contains(podNet.IP) || podOnes < vpcOnes {
+				result = multierror.Append(result, fmt.Errorf(
+					"pod CIDR %s needs to be within network CIDR %s when using %s, set kubernetes.podCIDR to %s",
+					podNet, vpcNet, provider, vpcNet))
+			}
+		} else if cidrsOverlap(vpcNet, podNet) {
+			result = multierror.Append(result, fmt.Errorf(
+				"pod CIDR %s overlaps with network CIDR %s", podNet, vpcNet))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
 func (c *Cluster) validateCalico() error {
 	var result *multierror.Error
 
@@ -639,9 +960,200 @@ func (c *Cluster) validateCalico() error {
 			"typha enabled so expecting a non-zero positive replica count, got=%s", got))
 	}
 
+	switch calico.Encapsulation {
+	case clusterv1alpha1.CalicoEncapsulationIPIP, clusterv1alpha1.CalicoEncapsulationNone:
+	case clusterv1alpha1.CalicoEncapsulationVXLAN:
+		if calico.EncapsulationMode == clusterv1alpha1.CalicoEncapsulationModeCrossSubnet {
+			result = multierror.Append(result, errors.New(
+				"calico's vxlan encapsulation only supports encapsulation mode 'always'"))
+		}
+		if len(calico.BGPPeers) > 0 {
+			result = multierror.Append(result, errors.New(
+				"calico's vxlan encapsulation does not use BGP, bgpPeers can't be configured"))
+		}
+	default:
+		result = multierror.Append(result, fmt.Errorf(
+			"calico's encapsulation may only be set to [%s %s %s], got=%s",
+			clusterv1alpha1.CalicoEncapsulationIPIP, clusterv1alpha1.CalicoEncapsulationVXLAN,
+			clusterv1alpha1.CalicoEncapsulationNone, calico.Encapsulation))
+	}
+
+	if calico.EncapsulationMode != clusterv1alpha1.CalicoEncapsulationModeAlways &&
+		calico.EncapsulationMode != clusterv1alpha1.CalicoEncapsulationModeCrossSubnet {
+		result = multierror.Append(result, fmt.Errorf(
+			"calico's encapsulationMode may only be set to [%s %s], got=%s",
+			clusterv1alpha1.CalicoEncapsulationModeAlways, clusterv1alpha1.CalicoEncapsulationModeCrossSubnet,
+			calico.EncapsulationMode))
+	}
+
+	if calico.MTU != nil && (*calico.MTU < 1000 || *calico.MTU > 9001) {
+		result = multierror.Append(result, fmt.Errorf(
+			"calico's mtu needs to be between 1000 and 9001, got=%d", *calico.MTU))
+	}
+
+	if calico.Backend != clusterv1alpha1.CalicoBackendKubernetes {
+		if len(calico.IPPools) > 0 || len(calico.BGPPeers) > 0 || calico.DefaultDeny {
+			result = multierror.Append(result, fmt.Errorf(
+				"ipPools, bgpPeers and defaultDeny require calico's backend to be 'kubernetes', got=%s", calico.Backend))
+		}
+	}
+
+	if err := c.validateCalicoIPPools(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	names := make(map[string]bool)
+	for _, peer := range calico.BGPPeers {
+		if peer.Name == "" {
+			result = multierror.Append(result, errors.New("calico bgp peer without name"))
+		} else if names[peer.Name] {
+			result = multierror.Append(result, fmt.Errorf("duplicate calico bgp peer name: %s", peer.Name))
+		}
+		names[peer.Name] = true
+
+		if net.ParseIP(peer.PeerIP) == nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"calico bgp peer %s has an invalid peerIP: '%s'", peer.Name, peer.PeerIP))
+		}
+		if peer.ASNumber < 1 || peer.ASNumber > math.MaxUint32 {
+			result = multierror.Append(result, fmt.Errorf(
+				"calico bgp peer %s needs an asNumber between 1 and %d, got=%d", peer.Name, uint32(math.MaxUint32), peer.ASNumber))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validate that calico's IP pools are within the pod network and don't
+// overlap with each other
+func (c *Cluster) validateCalicoIPPools() error {
+	var result *multierror.Error
+
+	k := c.Config().Kubernetes
+	_, podNet, err := net.ParseCIDR(k.PodCIDR)
+	if err != nil {
+		// pod CIDR errors are reported by validateNetworking
+		podNet = nil
+	}
+
+	names := make(map[string]bool)
+	var pools []*net.IPNet
+	for _, pool := range k.Calico.IPPools {
+		if pool.Name == "" {
+			result = multierror.Append(result, errors.New("calico ip pool without name"))
+		} else if names[pool.Name] {
+			result = multierror.Append(result, fmt.Errorf("duplicate calico ip pool name: %s", pool.Name))
+		}
+		names[pool.Name] = true
+
+		_, poolNet, err := net.ParseCIDR(pool.CIDR)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("error parsing cidr of calico ip pool %s: %s", pool.Name, err))
+			continue
+		}
+
+		poolOnes, _ := poolNet.Mask.Size()
+		if podNet != nil {
+			podOnes, _ := podNet.Mask.Size()
+			if !podNet.Contains(poolNet.IP) || poolOnes < podOnes {
+				result = multierror.Append(result, fmt.Errorf(
+					"calico ip pool %s (%s) needs to be within pod CIDR %s", pool.Name, poolNet, podNet))
+			}
+		}
+
+		if pool.BlockSize != nil {
+			if *pool.BlockSize < 20 || *pool.BlockSize > 32 {
+				result = multierror.Append(result, fmt.Errorf(
+					"calico ip pool %s needs a blockSize between 20 and 32, got=%d", pool.Name, *pool.BlockSize))
+			} else if *pool.BlockSize < poolOnes {
+				result = multierror.Append(result, fmt.Errorf(
+					"calico ip pool %s (%s) is smaller than its blockSize %d", pool.Name, poolNet, *pool.BlockSize))
+			}
+		}
+
+		for _, other := range pools {
+			if cidrsOverlap(poolNet, other) {
+				result = multierror.Append(result, fmt.Errorf(
+					"calico ip pool %s (%s) overlaps with %s", pool.Name, poolNet, other))
+			}
+		}
+		pools = append(pools, poolNet)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (c *Cluster) validateIngress() error {
+	var result *multierror.Error
+
+	ingress := c.Config().Kubernetes.Ingress
+
+	if ingress.Replicas != nil && *ingress.Replicas < 1 {
+		result = multierror.Append(result, fmt.Errorf(
+			"ingress replicas need to be at least 1, got=%d", *ingress.Replicas))
+	}
+
+	if ingress.InstancePool != "" {
+		var instancePool *clusterv1alpha1.InstancePool
+		for pos := range c.Config().InstancePools {
+			if c.Config().InstancePools[pos].Name == ingress.InstancePool {
+				instancePool = &c.Config().InstancePools[pos]
+			}
+		}
+		if instancePool == nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"ingress instance pool %s does not exist", ingress.InstancePool))
+		} else if instancePool.Type != clusterv1alpha1.InstancePoolTypeWorker {
+			result = multierror.Append(result, fmt.Errorf(
+				"ingress instance pool %s needs to be of type %s, got=%s",
+				ingress.InstancePool, clusterv1alpha1.InstancePoolTypeWorker, instancePool.Type))
+		}
+	}
+
+	switch ingress.ServiceType {
+	case clusterv1alpha1.IngressServiceTypeLoadBalancer:
+		if ingress.LoadBalancerType != clusterv1alpha1.IngressLoadBalancerTypeELB &&
+			ingress.LoadBalancerType != clusterv1alpha1.IngressLoadBalancerTypeNLB {
+			result = multierror.Append(result, fmt.Errorf(
+				"ingress loadBalancerType may only be set to [%s %s], got=%s",
+				clusterv1alpha1.IngressLoadBalancerTypeELB, clusterv1alpha1.IngressLoadBalancerTypeNLB, ingress.LoadBalancerType))
+		}
+	case clusterv1alpha1.IngressServiceTypeNodePort:
+		// the ELB of the worker role is created by terraform
+		if ingress.LoadBalancerType != "" || ingress.Internal || len(ingress.ServiceAnnotations) > 0 {
+			result = multierror.Append(result, fmt.Errorf(
+				"ingress loadBalancerType, internal and serviceAnnotations are only supported with serviceType %s",
+				clusterv1alpha1.IngressServiceTypeLoadBalancer))
+		}
+	default:
+		result = multierror.Append(result, fmt.Errorf(
+			"ingress serviceType may only be set to [%s %s], got=%s",
+			clusterv1alpha1.IngressServiceTypeLoadBalancer, clusterv1alpha1.IngressServiceTypeNodePort, ingress.ServiceType))
+	}
+
 	return result.ErrorOrNil()
 }
 
+func (c *Cluster) validatePodIAM() error {
+	k := c.Config().Kubernetes
+
+	switch k.PodIAM.Provider {
+	case clusterv1alpha1.PodIAMProviderKube2IAM:
+	case clusterv1alpha1.PodIAMProviderKiam:
+		// the TLS certificates of kiam's agent and server are issued by
+		// cert-manager
+		if k.CertManager == nil || !k.CertManager.Enabled {
+			return fmt.Errorf("pod IAM provider %s requires cert-manager to be enabled", k.PodIAM.Provider)
+		}
+	default:
+		return fmt.Errorf(
+			"pod IAM provider may only be set to [%s %s], got=%s",
+			clusterv1alpha1.PodIAMProviderKube2IAM, clusterv1alpha1.PodIAMProviderKiam, k.PodIAM.Provider)
+	}
+
+	return nil
+}
+
 // Determine if this Cluster is a cluster or hub, single or multi environment
 func (c *Cluster) Type() string {
 	if c.conf.Type != "" {
@@ -661,10 +1173,21 @@ func (c *Cluster) RemoteState() string {
 	return c.Environment().Provider().RemoteState(c.Environment().Name(), c.Name(), "main")
 }
 
+// Region returns the location of the cluster, clusters without a location
+// are placed in the region of their environment
 func (c *Cluster) Region() string {
+	if c.conf.Location == "" {
+		return c.environment.Location()
+	}
 	return c.conf.Location
 }
 
+// CrossRegion returns true if the cluster is placed in another region than
+// the hub of its environment
+func (c *Cluster) CrossRegion() bool {
+	return c.Type() == clusterv1alpha1.ClusterTypeClusterMulti && c.Region() != c.environment.Location()
+}
+
 func (c *Cluster) Subnets() (subnets []clusterv1alpha1.Subnet) {
 	zones := make(map[string]bool)
 
@@ -963,7 +1486,8 @@ func (c *Cluster) AmazonEBSEncrypted() bool {
 func (c *Cluster) validateSubnets() error {
 	var result *multierror.Error
 
-	if c.Type() == clusterv1alpha1.ClusterTypeClusterMulti && c.Environment().Hub() != nil {
+	// clusters in another region have their own network
+	if c.Type() == clusterv1alpha1.ClusterTypeClusterMulti && !c.CrossRegion() && c.Environment().Hub() != nil {
 		hSubnets := c.Environment().Hub().Subnets()
 
 		for _, cNet := range c.Subnets() {
@@ -3,12 +3,14 @@ package cluster
 
 import (
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
 
 	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
 	"github.com/jetstack/tarmak/pkg/tarmak/config"
 	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
 )
@@ -54,6 +56,7 @@ func newFakeCluster(t *testing.T, cluster *clusterv1alpha1.Cluster) *fakeCluster
 	c.fakeEnvironment.EXPECT().Log().AnyTimes().Return(loggerCtx)
 	c.fakeEnvironment.EXPECT().Provider().AnyTimes().Return(c.fakeProvider)
 	c.fakeEnvironment.EXPECT().Tarmak().AnyTimes().Return(c.fakeTarmak)
+	c.fakeEnvironment.EXPECT().Location().AnyTimes().Return("my-region")
 	c.Cluster.log = loggerCtx
 
 	c.fakeProvider.EXPECT().InstanceType(gomock.Any()).Do(func(in string) string { return "provider-" + in }).AnyTimes()
@@ -234,6 +237,395 @@ func TestValidateClusterAutoscaler(t *testing.T) {
 	}
 }
 
+func TestValidateAPIServerAudit(t *testing.T) {
+	clusterConfig := config.NewClusterSingle("single", "cluster")
+	clusterConfig.Kubernetes.APIServer = &clusterv1alpha1.ClusterKubernetesAPIServer{
+		Audit: &clusterv1alpha1.ClusterKubernetesAPIServerAudit{
+			Webhook: &clusterv1alpha1.ClusterKubernetesAPIServerAuditWebhook{
+				URL: "https://audit.example.com/events",
+			},
+		},
+	}
+	config.ApplyDefaults(clusterConfig)
+
+	cluster := &Cluster{
+		conf: clusterConfig,
+	}
+	audit := clusterConfig.Kubernetes.APIServer.Audit
+
+	if err := cluster.validateAPIServerAudit(); err != nil {
+		t.Errorf("validation should pass with defaulted audit configuration: %s", err)
+	}
+
+	// inline and file based policy
+	audit.Policy = "kind: Policy"
+	audit.PolicyFile = "audit-policy.yaml"
+	if cluster.validateAPIServerAudit() == nil {
+		t.Errorf("validation should fail when setting policy and policyFile")
+	}
+	audit.PolicyFile = ""
+
+	// unknown log format
+	audit.LogFormat = "xml"
+	if cluster.validateAPIServerAudit() == nil {
+		t.Errorf("validation should fail with an unknown log format")
+	}
+	audit.LogFormat = clusterv1alpha1.AuditLogFormatJSON
+
+	// negative retention
+	audit.LogMaxAge = new(int)
+	*audit.LogMaxAge = -1
+	if cluster.validateAPIServerAudit() == nil {
+		t.Errorf("validation should fail with negative log max age")
+	}
+	*audit.LogMaxAge = 7
+
+	// invalid webhook settings
+	audit.Webhook.URL = "audit.example.com"
+	if cluster.validateAPIServerAudit() == nil {
+		t.Errorf("validation should fail with a webhook url without scheme")
+	}
+	audit.Webhook.URL = "https://audit.example.com/events"
+
+	audit.Webhook.Mode = "sometimes"
+	if cluster.validateAPIServerAudit() == nil {
+		t.Errorf("validation should fail with an unknown webhook mode")
+	}
+	audit.Webhook.Mode = clusterv1alpha1.AuditWebhookModeBlocking
+
+	audit.Webhook.InitialBackoff = "ten seconds"
+	if cluster.validateAPIServerAudit() == nil {
+		t.Errorf("validation should fail with an invalid initial backoff")
+	}
+	audit.Webhook.InitialBackoff = "5s"
+
+	if err := cluster.validateAPIServerAudit(); err != nil {
+		t.Errorf("validation should pass with valid audit configuration: %s", err)
+	}
+
+	// audit logging sink requires audit logs in json format
+	clusterConfig.LoggingSinks = []*clusterv1alpha1.LoggingSink{
+		&clusterv1alpha1.LoggingSink{
+			Types: []clusterv1alpha1.LoggingSinkType{clusterv1alpha1.LoggingSinkTypeAudit},
+		},
+	}
+	if err := cluster.validateLoggingSinks(); err != nil {
+		t.Errorf("validation should pass for audit logging sink: %s", err)
+	}
+
+	audit.LogFormat = clusterv1alpha1.AuditLogFormatLegacy
+	if cluster.validateLoggingSinks() == nil {
+		t.Errorf("validation should fail for audit logging sink with legacy log format")
+	}
+	audit.LogFormat = clusterv1alpha1.AuditLogFormatJSON
+
+	audit.Enabled = new(bool)
+	if cluster.validateLoggingSinks() == nil {
+		t.Errorf("validation should fail for audit logging sink with auditing disabled")
+	}
+}
+
+func TestValidateAPIServerEncryption(t *testing.T) {
+	clusterConfig := config.NewClusterSingle("single", "cluster")
+	clusterConfig.Kubernetes.Version = "1.12.5"
+	clusterConfig.Kubernetes.APIServer = &clusterv1alpha1.ClusterKubernetesAPIServer{
+		Encryption: &clusterv1alpha1.ClusterKubernetesAPIServerEncryption{},
+	}
+	config.ApplyDefaults(clusterConfig)
+
+	cluster := &Cluster{
+		conf: clusterConfig,
+	}
+	encryption := clusterConfig.Kubernetes.APIServer.Encryption
+
+	if err := cluster.validateAPIServerEncryption(); err != nil {
+		t.Errorf("validation should pass with defaulted encryption configuration: %s", err)
+	}
+
+	// unknown provider
+	encryption.Provider = "secretbox"
+	if cluster.validateAPIServerEncryption() == nil {
+		t.Errorf("validation should fail with an unknown provider")
+	}
+
+	// kms provider without key
+	encryption.Provider = clusterv1alpha1.EncryptionProviderKMS
+	if cluster.validateAPIServerEncryption() == nil {
+		t.Errorf("validation should fail for kms provider without a key ARN")
+	}
+
+	encryption.KMS = &clusterv1alpha1.ClusterKubernetesAPIServerEncryptionKMS{
+		KeyARN: "arn:aws:s3:::my-bucket",
+	}
+	config.ApplyDefaults(clusterConfig)
+	if cluster.validateAPIServerEncryption() == nil {
+		t.Errorf("validation should fail for kms provider with a non KMS ARN")
+	}
+
+	encryption.KMS.KeyARN = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	if err := cluster.validateAPIServerEncryption(); err != nil {
+		t.Errorf("validation should pass with valid kms configuration: %s", err)
+	}
+
+	encryption.KMS.Timeout = "three seconds"
+	if cluster.validateAPIServerEncryption() == nil {
+		t.Errorf("validation should fail with an invalid kms timeout")
+	}
+	encryption.KMS.Timeout = "3s"
+
+	clusterConfig.Kubernetes.Version = "1.9.8"
+	if cluster.validateAPIServerEncryption() == nil {
+		t.Errorf("validation should fail for kms provider with kubernetes < 1.10")
+	}
+	clusterConfig.Kubernetes.Version = "1.12.5"
+
+	// kms options with aescbc provider
+	encryption.Provider = clusterv1alpha1.EncryptionProviderAESCBC
+	if cluster.validateAPIServerEncryption() == nil {
+		t.Errorf("validation should fail for kms options with aescbc provider")
+	}
+}
+
+func TestValidateNetworking(t *testing.T) {
+	clusterConfig := config.NewClusterSingle("single", "cluster")
+	config.ApplyDefaults(clusterConfig)
+
+	cluster := &Cluster{
+		conf: clusterConfig,
+	}
+	k := clusterConfig.Kubernetes
+
+	if err := cluster.validateNetworking(); err != nil {
+		t.Errorf("validation should pass with defaulted networking configuration: %s", err)
+	}
+
+	k.Networking.Provider = "flannel"
+	if cluster.validateNetworking() == nil {
+		t.Errorf("validation should fail with an unknown provider")
+	}
+
+	k.Networking.Provider = clusterv1alpha1.NetworkingProviderCilium
+	k.Networking.Cilium = &clusterv1alpha1.ClusterKubernetesNetworkingCilium{}
+	config.ApplyDefaults(clusterConfig)
+	if err := cluster.validateNetworking(); err != nil {
+		t.Errorf("validation should pass with defaulted cilium configuration: %s", err)
+	}
+
+	k.Networking.Cilium.Tunnel = "ipip"
+	if cluster.validateNetworking() == nil {
+		t.Errorf("validation should fail with an unknown cilium tunnel")
+	}
+	k.Networking.Cilium.Tunnel = clusterv1alpha1.CiliumTunnelGeneve
+
+	k.ServiceCIDR = "100.64.128.0/20"
+	if cluster.validateNetworking() == nil {
+		t.Errorf("validation should fail with overlapping pod and service CIDR")
+	}
+
+	k.ServiceCIDR = "10.99.0.0/20"
+	if cluster.validateNetworking() == nil {
+		t.Errorf("validation should fail with service CIDR overlapping the network")
+	}
+	k.ServiceCIDR = "10.254.0.0/16"
+
+	k.PodCIDR = "10.0.0.0/8"
+	if cluster.validateNetworking() == nil {
+		t.Errorf("validation should fail with pod CIDR overlapping the network")
+	}
+
+	k.Networking.Provider = clusterv1alpha1.NetworkingProviderAmazonVPC
+	if cluster.validateNetworking() == nil {
+		t.Errorf("validation should fail for aws-vpc-cni with pod CIDR larger than the network")
+	}
+
+	k.PodCIDR = "100.64.0.0/16"
+	if cluster.validateNetworking() == nil {
+		t.Errorf("validation should fail for aws-vpc-cni with pod CIDR outside of the network")
+	}
+
+	k.PodCIDR = clusterConfig.Network.CIDR
+	if err := cluster.validateNetworking(); err != nil {
+		t.Errorf("validation should pass for aws-vpc-cni with pod CIDR matching the network: %s", err)
+	}
+}
+
+func TestValidateIngress(t *testing.T) {
+	clusterConfig := config.NewClusterSingle("single", "cluster")
+	clusterConfig.Kubernetes = &clusterv1alpha1.ClusterKubernetes{
+		Ingress: &clusterv1alpha1.ClusterKubernetesIngress{
+			Enabled: true,
+		},
+	}
+	config.ApplyDefaults(clusterConfig)
+
+	cluster := &Cluster{
+		conf: clusterConfig,
+	}
+	ingress := clusterConfig.Kubernetes.Ingress
+
+	if err := cluster.validateIngress(); err != nil {
+		t.Errorf("validation should pass with defaulted ingress configuration: %s", err)
+	}
+
+	ingress.LoadBalancerType = "alb"
+	if cluster.validateIngress() == nil {
+		t.Errorf("validation should fail with an unknown load balancer type")
+	}
+	ingress.LoadBalancerType = clusterv1alpha1.IngressLoadBalancerTypeNLB
+
+	ingress.InstancePool = "does-not-exist"
+	if cluster.validateIngress() == nil {
+		t.Errorf("validation should fail with an unknown instance pool")
+	}
+
+	ingress.InstancePool = "master"
+	if cluster.validateIngress() == nil {
+		t.Errorf("validation should fail with a non worker instance pool")
+	}
+
+	ingress.InstancePool = "worker"
+	if err := cluster.validateIngress(); err != nil {
+		t.Errorf("validation should pass with a worker instance pool: %s", err)
+	}
+
+	ingress.ServiceType = clusterv1alpha1.IngressServiceTypeNodePort
+	if cluster.validateIngress() == nil {
+		t.Errorf("validation should fail with a load balancer type for service type NodePort")
+	}
+
+	ingress.LoadBalancerType = ""
+	if err := cluster.validateIngress(); err != nil {
+		t.Errorf("validation should pass with service type NodePort: %s", err)
+	}
+
+	zero := 0
+	ingress.Replicas = &zero
+	if cluster.validateIngress() == nil {
+		t.Errorf("validation should fail without replicas")
+	}
+}
+
+func TestValidatePodIAM(t *testing.T) {
+	clusterConfig := config.NewClusterSingle("single", "cluster")
+	clusterConfig.Kubernetes = &clusterv1alpha1.ClusterKubernetes{
+		PodIAM: &clusterv1alpha1.ClusterKubernetesPodIAM{},
+	}
+	config.ApplyDefaults(clusterConfig)
+
+	cluster := &Cluster{
+		conf: clusterConfig,
+	}
+	k := clusterConfig.Kubernetes
+
+	if err := cluster.validatePodIAM(); err != nil {
+		t.Errorf("validation should pass with defaulted pod IAM configuration: %s", err)
+	}
+
+	k.PodIAM.Provider = "kube-iam"
+	if cluster.validatePodIAM() == nil {
+		t.Errorf("validation should fail with an unknown provider")
+	}
+
+	k.PodIAM.Provider = clusterv1alpha1.PodIAMProviderKiam
+	if cluster.validatePodIAM() == nil {
+		t.Errorf("validation should fail for kiam without cert-manager")
+	}
+
+	k.CertManager = &clusterv1alpha1.ClusterKubernetesCertManager{Enabled: true}
+	if err := cluster.validatePodIAM(); err != nil {
+		t.Errorf("validation should pass for kiam with cert-manager: %s", err)
+	}
+}
+
+func TestValidateCalico(t *testing.T) {
+	clusterConfig := config.NewClusterSingle("single", "cluster")
+	config.ApplyDefaults(clusterConfig)
+
+	cluster := &Cluster{
+		conf: clusterConfig,
+	}
+	calico := clusterConfig.Kubernetes.Calico
+
+	if err := cluster.validateCalico(); err != nil {
+		t.Errorf("validation should pass with defaulted calico configuration: %s", err)
+	}
+
+	calico.Encapsulation = "gre"
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with an unknown encapsulation")
+	}
+
+	calico.Encapsulation = clusterv1alpha1.CalicoEncapsulationVXLAN
+	calico.EncapsulationMode = clusterv1alpha1.CalicoEncapsulationModeCrossSubnet
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with vxlan in cross-subnet mode")
+	}
+	calico.EncapsulationMode = clusterv1alpha1.CalicoEncapsulationModeAlways
+
+	mtu := 65000
+	calico.MTU = &mtu
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with an out of range mtu")
+	}
+	mtu = 1450
+
+	calico.DefaultDeny = true
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with default deny on the etcd backend")
+	}
+
+	calico.Backend = clusterv1alpha1.CalicoBackendKubernetes
+	if err := cluster.validateCalico(); err != nil {
+		t.Errorf("validation should pass with default deny on the kubernetes backend: %s", err)
+	}
+
+	calico.BGPPeers = []clusterv1alpha1.ClusterKubernetesCalicoBGPPeer{
+		{Name: "rack1-tor", PeerIP: "10.99.0.1", ASNumber: 64512},
+	}
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with bgp peers and vxlan encapsulation")
+	}
+
+	calico.Encapsulation = clusterv1alpha1.CalicoEncapsulationIPIP
+	if err := cluster.validateCalico(); err != nil {
+		t.Errorf("validation should pass with a valid bgp peer: %s", err)
+	}
+
+	calico.BGPPeers = append(calico.BGPPeers, clusterv1alpha1.ClusterKubernetesCalicoBGPPeer{
+		Name: "rack1-tor", PeerIP: "not-an-ip", ASNumber: 0,
+	})
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with an invalid bgp peer")
+	}
+	calico.BGPPeers = nil
+
+	calico.IPPools = []clusterv1alpha1.ClusterKubernetesCalicoIPPool{
+		{Name: "pool-a", CIDR: "100.64.0.0/17"},
+		{Name: "pool-b", CIDR: "100.64.128.0/17"},
+	}
+	config.ApplyDefaults(clusterConfig)
+	if err := cluster.validateCalico(); err != nil {
+		t.Errorf("validation should pass with valid ip pools: %s", err)
+	}
+
+	calico.IPPools[1].CIDR = "100.64.64.0/18"
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with overlapping ip pools")
+	}
+
+	calico.IPPools[1].CIDR = "100.65.0.0/17"
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with an ip pool outside of the pod CIDR")
+	}
+
+	calico.IPPools[1].CIDR = "100.64.128.0/17"
+	blockSize := 16
+	calico.IPPools[1].BlockSize = &blockSize
+	if cluster.validateCalico() == nil {
+		t.Errorf("validation should fail with an invalid block size")
+	}
+}
+
 func TestCluster_ValidateClusterInstancePoolTypesHub(t *testing.T) {
 	clusterConfig := config.NewHub("multi")
 	config.ApplyDefaults(clusterConfig)
@@ -496,6 +888,69 @@ func TestClusterValidateSubnetsMulti(t *testing.T) {
 
 }
 
+func TestClusterValidateRegion(t *testing.T) {
+	clusterConfig := config.NewClusterMulti("multi", "cluster")
+	config.ApplyDefaults(clusterConfig)
+	clusterConfig.Location = "other-region"
+	c := newFakeCluster(t, clusterConfig)
+	defer c.Finish()
+
+	hubConfig := config.NewHub("multi")
+	config.ApplyDefaults(hubConfig)
+	hubConfig.Network.CIDR = "10.98.0.0/20"
+	hub := newFakeCluster(t, hubConfig)
+	c.fakeEnvironment.EXPECT().Hub().AnyTimes().Return(hub)
+
+	if !c.CrossRegion() {
+		t.Errorf("expected cluster in region %s to be cross region", c.Region())
+	}
+
+	clusterConfig.Network.CIDR = "10.99.0.0/20"
+	if err := c.validateNetwork(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.validateRegion(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	clusterConfig.Network.CIDR = "10.98.8.0/21"
+	if err := c.validateNetwork(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.validateRegion(); err == nil {
+		t.Errorf("expected error due to network overlapping the hub's, got=none")
+	}
+	clusterConfig.Network.CIDR = "10.99.0.0/20"
+
+	clusterConfig.Network.ObjectMeta.Annotations = map[string]string{
+		clusterv1alpha1.ExistingVPCAnnotationKey: "vpc-12345",
+	}
+	if err := c.validateRegion(); err == nil {
+		t.Errorf("expected error due to existing VPC, got=none")
+	}
+	clusterConfig.Network.ObjectMeta.Annotations = nil
+
+	clusterConfig.Type = clusterv1alpha1.ClusterTypeClusterSingle
+	if c.CrossRegion() {
+		t.Errorf("expected cluster of type %s not to be cross region", c.Type())
+	}
+	if err := c.validateRegion(); err == nil {
+		t.Errorf("expected error due to cluster type %s, got=none", c.Type())
+	}
+
+	clusterConfig.Type = clusterv1alpha1.ClusterTypeClusterMulti
+	clusterConfig.Location = ""
+	if exp, act := "my-region", c.Region(); exp != act {
+		t.Errorf("expected region %s, got %s", exp, act)
+	}
+	if c.CrossRegion() {
+		t.Errorf("expected cluster without location not to be cross region")
+	}
+	if err := c.validateRegion(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func instancePoolsWithZones(zones []string) []clusterv1alpha1.InstancePool {
 	pool := clusterv1alpha1.InstancePool{}
 
@@ -592,3 +1047,35 @@ func TestNewFromConfigDuplicateNetwork(t *testing.T) {
 }
 
 */
+
+func TestFindManifest(t *testing.T) {
+	history := []*tarmakv1alpha1.ManifestHistoryEntry{
+		{Hash: "sha256:abc123", Path: "s3://bucket/cluster/puppet-manifests/1-puppet.tar.gz"},
+		{Hash: "sha256:abd456", Path: "s3://bucket/cluster/puppet-manifests/2-puppet.tar.gz"},
+	}
+
+	for _, hash := range []string{"sha256:abc123", "abc123", "abc", "sha256:abd"} {
+		entry, err := findManifest(history, hash)
+		if err != nil {
+			t.Errorf("unexpected error for hash '%s': %s", hash, err)
+			continue
+		}
+		if !strings.HasPrefix(strings.TrimPrefix(entry.Hash, "sha256:"), strings.TrimPrefix(hash, "sha256:")) {
+			t.Errorf("unexpected manifest %s for hash '%s'", entry.Hash, hash)
+		}
+	}
+
+	for hash, expErr := range map[string]string{
+		"ab":      "ambiguous",
+		"ffff":    "not found",
+		"":        "no manifest hash",
+		"sha256:": "no manifest hash",
+	} {
+		_, err := findManifest(history, hash)
+		if err == nil {
+			t.Errorf("expected error for hash '%s'", hash)
+		} else if !strings.Contains(err.Error(), expErr) {
+			t.Errorf("unexpected error for hash '%s': %s", hash, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+const (
+	// time for wing agents to pick up a command, in addition to its timeout
+	commandPickupTimeout = time.Minute
+
+	commandPollInterval = 2 * time.Second
+)
+
+// RunCommand runs a command through wing on all instances of an instance
+// pool, or all instances if the pool is empty. It waits for the results of
+// the instances known to wing, instances that did not report a result in time
+// have a nil result.
+func (c *Cluster) RunCommand(instancePool string, command []string, timeout time.Duration) (map[string]*wingv1alpha1.CommandResult, error) {
+	instances, err := c.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*wingv1alpha1.CommandResult)
+	for _, instance := range instances {
+		if instancePool == "" || instance.InstancePool == instancePool {
+			results[instance.Name] = nil
+		}
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no instances found in instance pool '%s'", instancePool)
+	}
+
+	client, err := c.wingCommandClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to wing API on bastion: %s", err)
+	}
+
+	cmd, err := client.Create(&wingv1alpha1.Command{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "exec-",
+		},
+		Spec: &wingv1alpha1.CommandSpec{
+			InstancePool: instancePool,
+			Command:      command,
+			Timeout:      metav1.Duration{Duration: timeout},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating command: %s", err)
+	}
+	c.log.Debugf("created command %s for %d instances", cmd.Name, len(results))
+
+	defer func() {
+		if err := client.Delete(cmd.Name, &metav1.DeleteOptions{}); err != nil {
+			c.log.Warnf("error deleting command %s: %s", cmd.Name, err)
+		}
+	}()
+
+	deadline := time.Now().Add(timeout + commandPickupTimeout)
+	for {
+		cmd, err = client.Get(cmd.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting command: %s", err)
+		}
+
+		pending := 0
+		for name := range results {
+			result := commandResultOf(cmd, name)
+			results[name] = result
+			if result == nil || result.State == wingv1alpha1.CommandStateRunning {
+				pending++
+			}
+		}
+
+		if pending == 0 {
+			return results, nil
+		}
+
+		if time.Now().After(deadline) {
+			c.log.Warnf("%d instances did not complete the command in time", pending)
+			return results, nil
+		}
+
+		c.log.Debugf("waiting for %d instances to complete the command", pending)
+
+		select {
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		case <-time.After(commandPollInterval):
+		}
+	}
+}
+
+func commandResultOf(command *wingv1alpha1.Command, instanceName string) *wingv1alpha1.CommandResult {
+	if command.Status == nil {
+		return nil
+	}
+	for pos := range command.Status.Results {
+		if command.Status.Results[pos].InstanceName == instanceName {
+			return &command.Status.Results[pos]
+		}
+	}
+	return nil
+}
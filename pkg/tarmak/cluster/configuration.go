@@ -4,10 +4,15 @@ package cluster
 import (
 	"bytes"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
 	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
 )
 
@@ -17,25 +22,168 @@ const (
 
 // This upload the puppet.tar.gz to the cluster, warning there is some duplication as terraform is also uploading this puppet.tar.gz
 func (c *Cluster) UploadConfiguration() error {
+	buffer, md5Hash, hash, err := c.configuration()
+	if err != nil {
+		return err
+	}
+
+	manifestSignature, err := c.signConfiguration(buffer.Bytes())
+	if err != nil {
+		return err
+	}
+
+	err = c.Environment().Provider().UploadConfiguration(
+		c,
+		bytes.NewReader(buffer.Bytes()),
+		md5Hash,
+		manifestSignature,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Environment().Provider().RecordConfiguration(c, md5Hash, hash)
+}
+
+// This records the current puppet.tar.gz in the cluster's manifest history
+func (c *Cluster) RecordConfiguration() error {
+	_, md5Hash, hash, err := c.configuration()
+	if err != nil {
+		return err
+	}
+
+	return c.Environment().Provider().RecordConfiguration(c, md5Hash, hash)
+}
 
-	buffer := new(bytes.Buffer)
+// This returns the history of manifests uploaded to the cluster
+func (c *Cluster) ConfigurationHistory() ([]*tarmakv1alpha1.ManifestHistoryEntry, error) {
+	return c.Environment().Provider().ConfigurationHistory(c)
+}
+
+// build the puppet.tar.gz and return it with its md5 and sha256 hash
+func (c *Cluster) configuration() (buffer *bytes.Buffer, md5Hash string, hash string, err error) {
+	buffer = new(bytes.Buffer)
 
 	// get puppet config
-	err := c.Environment().Tarmak().Puppet().TarGz(buffer)
+	err = c.Environment().Tarmak().Puppet().TarGz(buffer)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	md5Sum := md5.Sum(buffer.Bytes())
+	sha256Sum := sha256.Sum256(buffer.Bytes())
+
+	return buffer, hex.EncodeToString(md5Sum[:]), fmt.Sprintf("sha256:%x", sha256Sum), nil
+}
+
+// sign the puppet.tar.gz with the environment's manifest signing key, wing
+// rejects manifests without a valid signature
+func (c *Cluster) signConfiguration(manifest []byte) ([]byte, error) {
+	sig, err := c.Environment().Provider().SignManifest(c, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign manifest: %s", err)
+	}
+
+	return sig, nil
+}
+
+// find a manifest in the history by its full or abbreviated hash
+func findManifest(history []*tarmakv1alpha1.ManifestHistoryEntry, hash string) (*tarmakv1alpha1.ManifestHistoryEntry, error) {
+	hash = strings.TrimPrefix(hash, "sha256:")
+	if hash == "" {
+		return nil, fmt.Errorf("no manifest hash specified")
+	}
+
+	var found *tarmakv1alpha1.ManifestHistoryEntry
+	for _, entry := range history {
+		if !strings.HasPrefix(strings.TrimPrefix(entry.Hash, "sha256:"), hash) {
+			continue
+		}
+		if found != nil && found.Hash != entry.Hash {
+			return nil, fmt.Errorf("manifest hash '%s' is ambiguous", hash)
+		}
+		found = entry
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("manifest hash '%s' not found in manifest history", hash)
+	}
+
+	return found, nil
+}
+
+// This pins instances to a manifest from the history, optionally limited to an
+// instance pool. Only existing instances are pinned, instances launched
+// afterwards by the auto scaling groups apply the latest manifest.
+func (c *Cluster) RollbackConfiguration(hash, instancePool string) error {
+	if instancePool != "" {
+		found := false
+		for _, pool := range c.InstancePools() {
+			if pool.Name() == instancePool {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("instance pool '%s' not found in cluster", instancePool)
+		}
+	}
+
+	history, err := c.ConfigurationHistory()
+	if err != nil {
+		return fmt.Errorf("failed to get manifest history: %s", err)
+	}
+
+	manifest, err := findManifest(history, hash)
 	if err != nil {
 		return err
 	}
 
-	// build reader from config
-	reader := bytes.NewReader(buffer.Bytes())
-	hasher := md5.New()
-	hasher.Write(buffer.Bytes())
+	c.log.Infof("rolling back instances to manifest %s uploaded at %s", manifest.Hash, manifest.Timestamp)
 
-	return c.Environment().Provider().UploadConfiguration(
-		c,
-		reader,
-		hex.EncodeToString(hasher.Sum(nil)),
-	)
+	// connect to wing
+	client, err := c.wingInstanceClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to wing API on bastion: %s", err)
+	}
+
+	// list instances
+	instances, err := c.listInstances()
+	if err != nil {
+		return fmt.Errorf("failed to list instances: %s", err)
+	}
+
+	count := 0
+	for pos := range instances {
+		instance := instances[pos]
+		if instancePool != "" && instance.InstancePool != instancePool {
+			continue
+		}
+
+		if instance.Spec == nil {
+			instance.Spec = &wingv1alpha1.InstanceSpec{}
+		}
+		instance.Spec.Converge = &wingv1alpha1.InstanceSpecManifest{
+			Path:             manifest.Path,
+			Hash:             manifest.Hash,
+			RequestTimestamp: metav1.Now(),
+		}
+
+		if _, err := client.Update(instance); err != nil {
+			c.log.Warnf("error updating instance %s in wing API: %s", instance.Name, err)
+			continue
+		}
+		count++
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no instances found to roll back")
+	}
+
+	c.log.Infof("pinned %d instances to manifest %s", count, manifest.Hash)
+	c.log.Warnf("instances launched from now on apply the latest manifest, run 'tarmak cluster configuration rollback' again after scaling up or replacing instances")
+
+	return nil
 }
 
 // This enforces a reapply of the puppet.tar.gz on every instance in the cluster
@@ -76,9 +224,11 @@ func (c *Cluster) ReapplyConfiguration() error {
 func (c *Cluster) WaitForConvergance() error {
 	c.log.Debugf("making sure all instances have converged using puppet")
 
+	var instances []*wingv1alpha1.Instance
 	retries := retries
 	for {
-		instances, err := c.listInstances()
+		var err error
+		instances, err = c.listInstances()
 		if err != nil {
 			return fmt.Errorf("failed to list instances: %s", err)
 		}
@@ -126,5 +276,13 @@ func (c *Cluster) WaitForConvergance() error {
 
 	}
 
+	// show what prevented instances from converging
+	for _, instance := range instances {
+		if instance.Status != nil && instance.Status.Converge != nil && instance.Status.Converge.State == wingv1alpha1.InstanceManifestStateConverged {
+			continue
+		}
+		c.log.Error(outputInstanceReport(instance))
+	}
+
 	return fmt.Errorf("instances failed to converge in time")
 }
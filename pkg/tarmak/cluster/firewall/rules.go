@@ -2,7 +2,10 @@
 package firewall
 
 import (
+	"fmt"
 	"net"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
 )
 
 type Host struct {
@@ -44,10 +47,20 @@ var (
 	consulSerfPort               = uint16(8301)
 	vaultPort                    = uint16(8200)
 	clusterAutoscalerMetricsPort = uint16(8085)
+	calicoVXLANPort              = uint16(4789)
+	vxlanPort                    = uint16(8472)
+	genevePort                   = uint16(6081)
+	ciliumHealthPort             = uint16(4240)
+	ciliumMetricsPort            = uint16(9090)
 	calicoMetricsPort            = uint16(9091)
 	nodePort                     = uint16(9100)
 	blackboxPort                 = uint16(9115)
 	wingPort                     = uint16(9443)
+	wingMetricsPort              = uint16(9102)
+	httpPort                     = uint16(80)
+	httpsPort                    = uint16(443)
+	ingressNodePortHTTP          = uint16(32080)
+	ingressNodePortHTTPS         = uint16(32443)
 	maxPort                      = uint16(65535)
 
 	k8sIdentifier       = "k8s"
@@ -65,6 +78,16 @@ func newWingService() Service {
 	}
 }
 
+func newWingMetricsService() Service {
+	return Service{
+		Name:     "wing_metrics",
+		Protocol: "tcp",
+		Ports: []Port{
+			Port{Single: &wingMetricsPort},
+		},
+	}
+}
+
 func newAllServices() Service {
 	return Service{
 		Name:     "all",
@@ -105,6 +128,41 @@ func newCalicoMetricsService() Service {
 	}
 }
 
+func newCiliumTunnelService(tunnel string) Service {
+	port := &vxlanPort
+	if tunnel == clusterv1alpha1.CiliumTunnelGeneve {
+		port = &genevePort
+	}
+
+	return Service{
+		Name:     tunnel,
+		Protocol: "udp",
+		Ports: []Port{
+			Port{Single: port},
+		},
+	}
+}
+
+func newCiliumHealthService() Service {
+	return Service{
+		Name:     "cilium_health",
+		Protocol: "tcp",
+		Ports: []Port{
+			Port{Single: &ciliumHealthPort},
+		},
+	}
+}
+
+func newCiliumMetricsService() Service {
+	return Service{
+		Name:     "cilium",
+		Protocol: "tcp",
+		Ports: []Port{
+			Port{Single: &ciliumMetricsPort},
+		},
+	}
+}
+
 func newClusterAutoscalerMetricsService() Service {
 	return Service{
 		Name:     "cluster_autoscaler",
@@ -115,6 +173,16 @@ func newClusterAutoscalerMetricsService() Service {
 	}
 }
 
+func newCalicoVXLANService() Service {
+	return Service{
+		Name:     "vxlan",
+		Protocol: "udp",
+		Ports: []Port{
+			Port{Single: &calicoVXLANPort},
+		},
+	}
+}
+
 func newIPIPService() Service {
 	return Service{
 		Name:     "ipip",
@@ -194,6 +262,30 @@ func newEtcdOverlayService() Service {
 	}
 }
 
+func newHTTPService() Service {
+	return Service{
+		Name:     "http",
+		Protocol: "tcp",
+		Ports:    []Port{Port{Single: &httpPort}},
+	}
+}
+
+func newHTTPSService() Service {
+	return Service{
+		Name:     "https",
+		Protocol: "tcp",
+		Ports:    []Port{Port{Single: &httpsPort}},
+	}
+}
+
+func newIngressNodePortService() Service {
+	return Service{
+		Name:     "ingress",
+		Protocol: "tcp",
+		Ports:    []Port{Port{Single: &ingressNodePortHTTP}, Port{Single: &ingressNodePortHTTPS}},
+	}
+}
+
 func newBGPService() Service {
 	return Service{
 		Name:     "bgp",
@@ -210,8 +302,169 @@ func cidrAll() *net.IPNet {
 	return ipNet
 }
 
-func Rules() (rules []*Rule) {
+// Rules returns the firewall rules of a cluster using the pod network of the
+// given kubernetes configuration, nil defaults to calico using IPIP
+func Rules(k *clusterv1alpha1.ClusterKubernetes) (rules []*Rule) {
+	rules = append(baseRules(), networkingRules(k)...)
+	return append(rules, ingressRules(k)...)
+}
+
+// rules required to expose the ingress controller through the ELB of the
+// worker role
+func ingressRules(k *clusterv1alpha1.ClusterKubernetes) []*Rule {
+	if k == nil || k.Ingress == nil || !k.Ingress.Enabled ||
+		k.Ingress.ServiceType != clusterv1alpha1.IngressServiceTypeNodePort {
+		return nil
+	}
+
+	return []*Rule{
+		&Rule{
+			Comment:      "allow everyone to connect to the ingress ELB",
+			Services:     []Service{newHTTPService(), newHTTPSService()},
+			Direction:    "ingress",
+			Sources:      []Host{Host{Name: "internet", CIDR: cidrAll()}},
+			Destinations: []Host{Host{Role: "worker_elb"}},
+		},
+		&Rule{
+			Comment:      "allow ingress ELB to connect to the ingress controller",
+			Services:     []Service{newIngressNodePortService()},
+			Direction:    "ingress",
+			Sources:      []Host{Host{Role: "worker_elb"}},
+			Destinations: []Host{Host{Role: "worker"}},
+		},
+		&Rule{
+			Comment:      "allow ingress ELB to connect to the ingress controller",
+			Services:     []Service{newIngressNodePortService()},
+			Direction:    "egress",
+			Sources:      []Host{Host{Role: "worker"}},
+			Destinations: []Host{Host{Role: "worker_elb"}},
+		},
+	}
+}
+
+// rules required by the pod network provider
+func networkingRules(k *clusterv1alpha1.ClusterKubernetes) []*Rule {
+	var networking *clusterv1alpha1.ClusterKubernetesNetworking
+	var calico *clusterv1alpha1.ClusterKubernetesCalico
+	if k != nil {
+		networking = k.Networking
+		calico = k.Calico
+	}
+
+	provider := clusterv1alpha1.NetworkingProviderCalico
+	if networking != nil && networking.Provider != "" {
+		provider = networking.Provider
+	}
+
+	switch provider {
+	case clusterv1alpha1.NetworkingProviderCilium:
+		tunnel := clusterv1alpha1.CiliumTunnelVXLAN
+		if networking.Cilium != nil && networking.Cilium.Tunnel != "" {
+			tunnel = networking.Cilium.Tunnel
+		}
+
+		return []*Rule{
+			&Rule{
+				Comment:   "allow workers/master to connect to cilium's overlay, health and metrics service",
+				Services:  []Service{newCiliumTunnelService(tunnel), newCiliumHealthService(), newCiliumMetricsService()},
+				Direction: "ingress",
+				Sources: []Host{
+					Host{Role: "master"},
+					Host{Role: "worker"},
+				},
+				Destinations: []Host{Host{Role: "master"}},
+			},
+		}
+
+	case clusterv1alpha1.NetworkingProviderAmazonVPC:
+		// pods use addresses of the VPC and share the security group of
+		// their node, so they need to reach any port of pods on the masters
+		return []*Rule{
+			&Rule{
+				Comment:   "allow workers to connect to pods running on the masters",
+				Services:  []Service{newAllServices()},
+				Direction: "ingress",
+				Sources: []Host{
+					Host{Role: "worker"},
+				},
+				Destinations: []Host{Host{Role: "master"}},
+			},
+		}
+
+	default:
+		encapsulation := clusterv1alpha1.CalicoEncapsulationIPIP
+		if calico != nil && calico.Encapsulation != "" {
+			encapsulation = calico.Encapsulation
+		}
+
+		var services []Service
+		switch encapsulation {
+		case clusterv1alpha1.CalicoEncapsulationVXLAN:
+			// routes are distributed by felix, no BGP is used
+			services = []Service{newCalicoVXLANService()}
+		case clusterv1alpha1.CalicoEncapsulationNone:
+			services = []Service{newBGPService()}
+		default:
+			services = []Service{newBGPService(), newIPIPService()}
+		}
+
+		rules := []*Rule{
+			&Rule{
+				Comment:   "allow workers/master to connect to calico's service",
+				Services:  append(services, newCalicoMetricsService()),
+				Direction: "ingress",
+				Sources: []Host{
+					Host{Role: "master"},
+					Host{Role: "worker"},
+				},
+				Destinations: []Host{Host{Role: "master"}},
+			},
+		}
+
+		if peers := bgpPeerHosts(calico); len(peers) > 0 {
+			rules = append(rules, &Rule{
+				Comment:   "allow external BGP peers to connect to calico's BGP service",
+				Services:  []Service{newBGPService()},
+				Direction: "ingress",
+				Sources:   peers,
+				Destinations: []Host{
+					Host{Role: "master"},
+					Host{Role: "worker"},
+				},
+			})
+		}
+
+		return rules
+	}
+}
+
+func bgpPeerHosts(calico *clusterv1alpha1.ClusterKubernetesCalico) []Host {
+	if calico == nil {
+		return nil
+	}
 
+	var hosts []Host
+	for _, peer := range calico.BGPPeers {
+		ip := net.ParseIP(peer.PeerIP)
+		if ip == nil {
+			continue
+		}
+
+		bits := 32
+		if ip.To4() == nil {
+			bits = 128
+		}
+
+		hosts = append(hosts, Host{
+			Name: fmt.Sprintf("bgp_peer_%s", peer.Name),
+			CIDR: &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)},
+		})
+	}
+
+	return hosts
+}
+
+func baseRules() []*Rule {
 	return []*Rule{
 		// All egress
 		&Rule{
@@ -304,8 +557,8 @@ func Rules() (rules []*Rule) {
 		},
 
 		&Rule{
-			Comment:      "allow prometheus connections to node_exporter and blackbox_exporter",
-			Services:     []Service{newBlackboxExporterService(), newNodeExporterService()},
+			Comment:      "allow prometheus connections to node_exporter, blackbox_exporter and wing's metrics",
+			Services:     []Service{newBlackboxExporterService(), newNodeExporterService(), newWingMetricsService()},
 			Direction:    "ingress",
 			Sources:      []Host{Host{Role: "worker"}},
 			Destinations: []Host{Host{Role: "etcd"}},
@@ -313,8 +566,8 @@ func Rules() (rules []*Rule) {
 
 		//// Master
 		&Rule{
-			Comment:   "allow workers/master to connect to calico's service, cluster autoscaler's service + api server",
-			Services:  []Service{newBGPService(), newIPIPService(), newCalicoMetricsService(), newClusterAutoscalerMetricsService(), newAPIService()},
+			Comment:   "allow workers/master to connect to cluster autoscaler's service + api server",
+			Services:  []Service{newClusterAutoscalerMetricsService(), newAPIService()},
 			Direction: "ingress",
 			Sources: []Host{
 				Host{Role: "master"},
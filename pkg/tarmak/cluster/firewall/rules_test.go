@@ -0,0 +1,144 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package firewall
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+)
+
+func servicesTo(rules []*Rule, role string) map[string]bool {
+	services := make(map[string]bool)
+	for _, rule := range rules {
+		for _, destination := range rule.Destinations {
+			if destination.Role != role {
+				continue
+			}
+			for _, service := range rule.Services {
+				services[service.Name] = true
+			}
+		}
+	}
+	return services
+}
+
+func TestRules_Networking(t *testing.T) {
+	for _, test := range []struct {
+		kubernetes *clusterv1alpha1.ClusterKubernetes
+		expected   []string
+		unexpected []string
+	}{
+		{
+			kubernetes: nil,
+			expected:   []string{"bgp", "ipip", "calico", "api"},
+			unexpected: []string{"vxlan", "cilium_health"},
+		},
+		{
+			kubernetes: &clusterv1alpha1.ClusterKubernetes{
+				Calico: &clusterv1alpha1.ClusterKubernetesCalico{
+					Encapsulation: clusterv1alpha1.CalicoEncapsulationVXLAN,
+				},
+			},
+			expected:   []string{"vxlan", "calico"},
+			unexpected: []string{"bgp", "ipip"},
+		},
+		{
+			kubernetes: &clusterv1alpha1.ClusterKubernetes{
+				Calico: &clusterv1alpha1.ClusterKubernetesCalico{
+					Encapsulation: clusterv1alpha1.CalicoEncapsulationNone,
+				},
+			},
+			expected:   []string{"bgp", "calico"},
+			unexpected: []string{"ipip", "vxlan"},
+		},
+		{
+			kubernetes: &clusterv1alpha1.ClusterKubernetes{
+				Networking: &clusterv1alpha1.ClusterKubernetesNetworking{
+					Provider: clusterv1alpha1.NetworkingProviderCilium,
+				},
+			},
+			expected:   []string{"vxlan", "cilium_health", "cilium", "api"},
+			unexpected: []string{"bgp", "ipip", "calico"},
+		},
+		{
+			kubernetes: &clusterv1alpha1.ClusterKubernetes{
+				Networking: &clusterv1alpha1.ClusterKubernetesNetworking{
+					Provider: clusterv1alpha1.NetworkingProviderCilium,
+					Cilium: &clusterv1alpha1.ClusterKubernetesNetworkingCilium{
+						Tunnel: clusterv1alpha1.CiliumTunnelGeneve,
+					},
+				},
+			},
+			expected:   []string{"geneve", "cilium_health"},
+			unexpected: []string{"vxlan"},
+		},
+		{
+			kubernetes: &clusterv1alpha1.ClusterKubernetes{
+				Networking: &clusterv1alpha1.ClusterKubernetesNetworking{
+					Provider: clusterv1alpha1.NetworkingProviderAmazonVPC,
+				},
+			},
+			expected:   []string{"all", "api"},
+			unexpected: []string{"bgp", "ipip", "calico", "vxlan"},
+		},
+	} {
+		services := servicesTo(Rules(test.kubernetes), "master")
+		for _, name := range test.expected {
+			if !services[name] {
+				t.Errorf("expected service %s to master for %+v", name, test.kubernetes)
+			}
+		}
+		for _, name := range test.unexpected {
+			if services[name] {
+				t.Errorf("unexpected service %s to master for %+v", name, test.kubernetes)
+			}
+		}
+	}
+}
+
+func TestRules_CalicoBGPPeers(t *testing.T) {
+	rules := Rules(&clusterv1alpha1.ClusterKubernetes{
+		Calico: &clusterv1alpha1.ClusterKubernetesCalico{
+			BGPPeers: []clusterv1alpha1.ClusterKubernetesCalicoBGPPeer{
+				{Name: "rack1-tor", PeerIP: "10.99.0.1", ASNumber: 64512},
+			},
+		},
+	})
+
+	found := false
+	for _, rule := range rules {
+		for _, source := range rule.Sources {
+			if source.Name != "bgp_peer_rack1-tor" {
+				continue
+			}
+			found = true
+			if source.CIDR == nil || source.CIDR.String() != "10.99.0.1/32" {
+				t.Errorf("unexpected bgp peer source: %+v", source)
+			}
+		}
+	}
+
+	if !found {
+		t.Error("expected a rule allowing the bgp peer")
+	}
+}
+
+func TestRules_IngressNodePort(t *testing.T) {
+	ingress := &clusterv1alpha1.ClusterKubernetesIngress{
+		Enabled:     true,
+		ServiceType: clusterv1alpha1.IngressServiceTypeLoadBalancer,
+	}
+	k := &clusterv1alpha1.ClusterKubernetes{Ingress: ingress}
+
+	if services := servicesTo(Rules(k), "worker_elb"); len(services) > 0 {
+		t.Errorf("unexpected services to worker ELB for service type LoadBalancer: %v", services)
+	}
+
+	ingress.ServiceType = clusterv1alpha1.IngressServiceTypeNodePort
+	if services := servicesTo(Rules(k), "worker_elb"); !services["http"] || !services["https"] || !services["ingress"] {
+		t.Errorf("expected http, https and ingress services to worker ELB, got=%v", services)
+	}
+	if services := servicesTo(Rules(k), "worker"); !services["ingress"] {
+		t.Errorf("expected ingress service to worker, got=%v", services)
+	}
+}
@@ -57,7 +57,7 @@ func Init(init interfaces.Initialize) (cluster *clusterv1alpha1.Cluster, err err
 		}
 		if !hubExists {
 			clusterHub := config.NewHub(environment.Name())
-			addAvailabilityZones(clusterHub, availabilityZones)
+			AddAvailabilityZones(clusterHub, availabilityZones)
 			err := init.Config().AppendCluster(clusterHub)
 			if err != nil {
 				return nil, err
@@ -72,7 +72,7 @@ func Init(init interfaces.Initialize) (cluster *clusterv1alpha1.Cluster, err err
 	if err != nil {
 		return nil, err
 	}
-	addAvailabilityZones(cluster, availabilityZones)
+	AddAvailabilityZones(cluster, availabilityZones)
 
 	return cluster, nil
 }
@@ -128,7 +128,9 @@ func askClusterType(init interfaces.Initialize) (clusterType string, err error)
 	return "", errors.New("no valid selection")
 }
 
-func addAvailabilityZones(cluster *clusterv1alpha1.Cluster, zones []string) {
+// AddAvailabilityZones places all instance pools of the cluster into the
+// given zones
+func AddAvailabilityZones(cluster *clusterv1alpha1.Cluster, zones []string) {
 
 	subnets := make([]*clusterv1alpha1.Subnet, len(zones))
 
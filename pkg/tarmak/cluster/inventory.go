@@ -0,0 +1,120 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+// VersionDrift lists the differing versions of a software or operating system
+// fact between the instances of an instance pool
+type VersionDrift struct {
+	InstancePool string
+	Fact         string
+	Instances    map[string][]string // instance names by version
+}
+
+func (d *VersionDrift) String() string {
+	var versions []string
+	for version := range d.Instances {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	var output []string
+	for _, version := range versions {
+		name := version
+		if name == "" {
+			name = "unknown"
+		}
+		output = append(output, fmt.Sprintf("%s (%s)", name, strings.Join(d.Instances[version], ", ")))
+	}
+
+	return fmt.Sprintf("instance pool %s has differing %s versions: %s", d.InstancePool, d.Fact, strings.Join(output, ", "))
+}
+
+// facts compared between instances of an instance pool
+var driftFacts = []struct {
+	name  string
+	value func(*wingv1alpha1.InstanceFacts) string
+}{
+	{"kernel", func(f *wingv1alpha1.InstanceFacts) string { return f.Kernel }},
+	{"os", func(f *wingv1alpha1.InstanceFacts) string { return f.OSRelease }},
+	{"puppet", func(f *wingv1alpha1.InstanceFacts) string { return f.PuppetVersion }},
+	{"kubelet", func(f *wingv1alpha1.InstanceFacts) string { return f.KubeletVersion }},
+	{"docker", func(f *wingv1alpha1.InstanceFacts) string { return f.DockerVersion }},
+}
+
+// Inventory returns the instances known to wing, including the facts they
+// have reported. It does not query the cloud provider, but ignores instances
+// the wing server has marked as terminated.
+func (c *Cluster) Inventory() ([]*wingv1alpha1.Instance, error) {
+	client, err := c.wingInstanceClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to wing API on bastion: %s", err)
+	}
+
+	list, err := client.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var instances []*wingv1alpha1.Instance
+	for pos := range list.Items {
+		if status := list.Items[pos].Status; status != nil && status.Terminated != nil {
+			continue
+		}
+		instances = append(instances, &list.Items[pos])
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].InstancePool != instances[j].InstancePool {
+			return instances[i].InstancePool < instances[j].InstancePool
+		}
+		return instances[i].Name < instances[j].Name
+	})
+
+	return instances, nil
+}
+
+// InventoryDrift compares the facts of instances within the same instance
+// pool. Instances that haven't reported facts are ignored.
+func InventoryDrift(instances []*wingv1alpha1.Instance) []*VersionDrift {
+	byPool := make(map[string][]*wingv1alpha1.Instance)
+	var pools []string
+	for _, instance := range instances {
+		if instance.Status == nil || instance.Status.Facts == nil {
+			continue
+		}
+		if _, ok := byPool[instance.InstancePool]; !ok {
+			pools = append(pools, instance.InstancePool)
+		}
+		byPool[instance.InstancePool] = append(byPool[instance.InstancePool], instance)
+	}
+	sort.Strings(pools)
+
+	var drifts []*VersionDrift
+	for _, pool := range pools {
+		for _, fact := range driftFacts {
+			drift := &VersionDrift{
+				InstancePool: pool,
+				Fact:         fact.name,
+				Instances:    make(map[string][]string),
+			}
+			for _, instance := range byPool[pool] {
+				version := fact.value(instance.Status.Facts)
+				drift.Instances[version] = append(drift.Instances[version], instance.Name)
+			}
+			if len(drift.Instances) > 1 {
+				drifts = append(drifts, drift)
+			}
+		}
+	}
+
+	return drifts
+}
@@ -0,0 +1,61 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+func inventoryInstance(name, pool, kernel, kubelet string) *wingv1alpha1.Instance {
+	return &wingv1alpha1.Instance{
+		ObjectMeta:   metav1.ObjectMeta{Name: name},
+		InstancePool: pool,
+		Status: &wingv1alpha1.InstanceStatus{
+			Facts: &wingv1alpha1.InstanceFacts{
+				Kernel:         kernel,
+				OSRelease:      "CentOS Linux 7 (Core)",
+				KubeletVersion: kubelet,
+			},
+		},
+	}
+}
+
+func TestInventoryDrift(t *testing.T) {
+	instances := []*wingv1alpha1.Instance{
+		inventoryInstance("i-1", "worker", "3.10.0-862", "v1.11.5"),
+		inventoryInstance("i-2", "worker", "3.10.0-957", "v1.11.5"),
+		inventoryInstance("i-3", "worker", "3.10.0-957", "v1.11.5"),
+		inventoryInstance("i-4", "master", "3.10.0-957", "v1.11.5"),
+		inventoryInstance("i-5", "master", "3.10.0-957", "v1.11.4"),
+		// instances without facts are ignored
+		{ObjectMeta: metav1.ObjectMeta{Name: "i-6"}, InstancePool: "master"},
+	}
+
+	drifts := InventoryDrift(instances)
+	if exp, act := 2, len(drifts); exp != act {
+		t.Fatalf("unexpected number of drifts, exp=%d act=%d: %+v", exp, act, drifts)
+	}
+
+	if exp, act := (&VersionDrift{
+		InstancePool: "master",
+		Fact:         "kubelet",
+		Instances: map[string][]string{
+			"v1.11.4": []string{"i-5"},
+			"v1.11.5": []string{"i-4"},
+		},
+	}), drifts[0]; !reflect.DeepEqual(exp, act) {
+		t.Errorf("unexpected drift, exp=%+v act=%+v", exp, act)
+	}
+
+	if exp, act := "instance pool worker has differing kernel versions: 3.10.0-862 (i-1), 3.10.0-957 (i-2, i-3)", drifts[1].String(); exp != act {
+		t.Errorf("unexpected drift output\nexp=%s\nact=%s", exp, act)
+	}
+
+	if drifts := InventoryDrift(instances[1:4]); len(drifts) != 0 {
+		t.Errorf("expected no drift, got %+v", drifts)
+	}
+}
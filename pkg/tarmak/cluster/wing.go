@@ -16,6 +16,24 @@ import (
 )
 
 func (c *Cluster) wingInstanceClient() (wingclientv1alpha1.InstanceInterface, error) {
+	client, err := c.wingClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Instances(c.ClusterName()), nil
+}
+
+func (c *Cluster) wingCommandClient() (wingclientv1alpha1.CommandInterface, error) {
+	client, err := c.wingClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Commands(c.ClusterName()), nil
+}
+
+func (c *Cluster) wingClient() (wingclientv1alpha1.WingV1alpha1Interface, error) {
 	var err error
 
 	if c.wingClientset == nil {
@@ -42,7 +60,7 @@ func (c *Cluster) wingInstanceClient() (wingclientv1alpha1.InstanceInterface, er
 
 	}
 
-	return c.wingClientset.WingV1alpha1().Instances(c.ClusterName()), nil
+	return c.wingClientset.WingV1alpha1(), nil
 }
 
 func (c *Cluster) listInstances() (instances []*wingv1alpha1.Instance, err error) {
@@ -73,12 +91,16 @@ func (c *Cluster) listInstances() (instances []*wingv1alpha1.Instance, err error
 	for pos, _ := range wingInstances.Items {
 		instance := &wingInstances.Items[pos]
 
-		// removes instances not in AWS
+		// instances marked terminated are garbage collected by the wing
+		// server
+		if instance.Status != nil && instance.Status.Terminated != nil {
+			c.log.Debugf("ignoring terminated instance %s in wing API", instance.Name)
+			continue
+		}
+
+		// ignore instances the wing server hasn't noticed vanishing yet
 		if _, ok := providerInstaceMap[instance.Name]; !ok {
-			c.log.Debugf("deleting unused instance %s in wing API", instance.Name)
-			if err := client.Delete(instance.Name, &metav1.DeleteOptions{}); err != nil {
-				c.log.Warnf("error deleting instance %s in wing API: %s", instance.Name, err)
-			}
+			c.log.Debugf("ignoring instance %s in wing API, not found in provider", instance.Name)
 			continue
 		}
 		instances = append(instances, instance)
@@ -114,3 +136,50 @@ func outputInstances(instances []*wingv1alpha1.Instance) string {
 	}
 	return strings.Join(output, ", ")
 }
+
+// outputInstanceReport renders the converge state of an instance including
+// the failed resources of its last puppet run
+func outputInstanceReport(instance *wingv1alpha1.Instance) string {
+	if instance.Status == nil || instance.Status.Converge == nil || instance.Status.Converge.State == "" {
+		return fmt.Sprintf("instance %s has not reported its converge state", instance.Name)
+	}
+
+	converge := instance.Status.Converge
+	report := converge.Report
+	if report == nil {
+		output := fmt.Sprintf("instance %s is in state %s without a puppet report", instance.Name, converge.State)
+		if len(converge.Messages) > 0 {
+			output = fmt.Sprintf("%s, last message:\n%s", output, converge.Messages[len(converge.Messages)-1])
+		}
+		return output
+	}
+
+	output := []string{fmt.Sprintf(
+		"instance %s is in state %s, last puppet run %s at %s: %d resources, %d changed, %d failed, %d out of sync, %d skipped",
+		instance.Name,
+		converge.State,
+		report.Status,
+		report.Time.UTC().Format(time.RFC3339),
+		report.Resources.Total,
+		report.Resources.Changed,
+		report.Resources.Failed,
+		report.Resources.OutOfSync,
+		report.Resources.Skipped,
+	)}
+
+	for _, resource := range report.OutOfSync {
+		if !resource.Failed {
+			continue
+		}
+		output = append(output, fmt.Sprintf("  failed %s (%s:%d)", resource.Resource, resource.File, resource.Line))
+		for _, event := range resource.Events {
+			output = append(output, fmt.Sprintf("    %s: %s", event.Property, event.Message))
+		}
+	}
+
+	if report.Omitted > 0 {
+		output = append(output, fmt.Sprintf("  %d more resources omitted", report.Omitted))
+	}
+
+	return strings.Join(output, "\n")
+}
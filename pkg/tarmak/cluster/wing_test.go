@@ -0,0 +1,77 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cluster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+func TestOutputInstanceReport(t *testing.T) {
+	instance := &wingv1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "i-1"},
+		Status: &wingv1alpha1.InstanceStatus{
+			Converge: &wingv1alpha1.InstanceStatusManifest{
+				State: wingv1alpha1.InstanceManifestStateConverging,
+				Report: &wingv1alpha1.PuppetReport{
+					Status: "failed",
+					Time:   metav1.NewTime(time.Date(2018, 12, 18, 10, 59, 5, 0, time.UTC)),
+					Resources: wingv1alpha1.PuppetResourceCounts{
+						Total:     312,
+						Changed:   1,
+						Failed:    1,
+						OutOfSync: 2,
+					},
+					OutOfSync: []wingv1alpha1.PuppetResource{
+						{
+							Resource: "Service[kubelet.service]",
+							File:     "/tmp/modules/kubernetes/manifests/kubelet.pp",
+							Line:     140,
+							Failed:   true,
+							Events: []wingv1alpha1.PuppetEvent{
+								{Property: "ensure", Status: "failure", Message: "Could not start Service[kubelet.service]"},
+							},
+						},
+						{
+							Resource: "File[/etc/kubernetes/kubelet.yaml]",
+							Changed:  true,
+						},
+					},
+					Omitted: 3,
+				},
+			},
+		},
+	}
+
+	output := outputInstanceReport(instance)
+	for _, exp := range []string{
+		"instance i-1 is in state converging, last puppet run failed at 2018-12-18T10:59:05Z",
+		"312 resources, 1 changed, 1 failed",
+		"failed Service[kubelet.service] (/tmp/modules/kubernetes/manifests/kubelet.pp:140)",
+		"ensure: Could not start Service[kubelet.service]",
+		"3 more resources omitted",
+	} {
+		if !strings.Contains(output, exp) {
+			t.Errorf("expected output to contain '%s':\n%s", exp, output)
+		}
+	}
+
+	if strings.Contains(output, "kubelet.yaml") {
+		t.Errorf("expected output to only contain failed resources:\n%s", output)
+	}
+
+	instance.Status.Converge.Report = nil
+	instance.Status.Converge.Messages = []string{"first run", "Error: last run"}
+	if output := outputInstanceReport(instance); !strings.HasSuffix(output, "Error: last run") {
+		t.Errorf("expected last message without report:\n%s", output)
+	}
+
+	instance.Status = nil
+	if output := outputInstanceReport(instance); !strings.Contains(output, "has not reported") {
+		t.Errorf("unexpected output without status:\n%s", output)
+	}
+}
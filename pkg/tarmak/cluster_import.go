@@ -0,0 +1,125 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
+)
+
+const (
+	importStatusImport  = "import"
+	importStatusManaged = "managed"
+	importStatusMissing = "not found"
+)
+
+// ClusterImport adopts existing resources of the cluster into its terraform
+// state. Resources are discovered by their tags and names, the report of
+// what would be imported is shown before importing anything.
+func (c *CmdTarmak) ClusterImport() error {
+	flags := c.flags.Cluster.Import
+
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	c.log.Info("discovering existing resources")
+	resources, err := c.Cluster().Environment().Provider().ImportableResources(c.Cluster())
+	if err != nil {
+		return err
+	}
+
+	managed, err := c.terraform.StateList(c.Cluster())
+	if err != nil {
+		return err
+	}
+
+	varMaps, imports := importReport(resources, managed)
+	utils.ListParameters(os.Stdout, []string{"address", "id", "status", "match"}, varMaps)
+
+	if len(imports) == 0 {
+		c.log.Infof("no resources to import into cluster %s", c.Cluster().ClusterName())
+		return nil
+	}
+
+	if flags.DryRun {
+		c.log.Infof("dry run, not importing %d resources", len(imports))
+		return nil
+	}
+
+	if !flags.AutoApprove {
+		approved, err := input.New(os.Stdin, os.Stdout).AskYesNo(&input.AskYesNo{
+			Default: false,
+			Query:   fmt.Sprintf("Import %d resources into cluster %s?", len(imports), c.Cluster().ClusterName()),
+		})
+		if err != nil {
+			return err
+		}
+		if !approved {
+			return fmt.Errorf("not importing resources into cluster %s", c.Cluster().ClusterName())
+		}
+	}
+
+	// a failed import leaves the others untouched, so all are attempted
+	var result *multierror.Error
+	for _, resource := range imports {
+		c.log.Infof("importing %s as %s", resource.ID, resource.Address)
+		if err := c.terraform.Import(c.Cluster(), resource.Address, resource.ID); err != nil {
+			result = multierror.Append(result, fmt.Errorf("error importing %s: %s", resource.Address, err))
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		default:
+		}
+	}
+
+	if err := result.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	c.log.Infof("imported %d resources, run 'tarmak cluster plan' to review remaining differences", len(imports))
+	return nil
+}
+
+// importReport returns a row per resource for the report and the resources
+// that have been found but are not yet part of the state
+func importReport(resources []*tarmakv1alpha1.ImportResource, managed []string) (varMaps []map[string]string, imports []*tarmakv1alpha1.ImportResource) {
+	state := make(map[string]bool)
+	for _, address := range managed {
+		state[unindexedAddress(address)] = true
+	}
+
+	for _, resource := range resources {
+		status := importStatusImport
+		switch {
+		case state[unindexedAddress(resource.Address)]:
+			status = importStatusManaged
+		case resource.ID == "":
+			status = importStatusMissing
+		default:
+			imports = append(imports, resource)
+		}
+
+		varMaps = append(varMaps, map[string]string{
+			"address": resource.Address,
+			"id":      resource.ID,
+			"status":  status,
+			"match":   resource.Match,
+		})
+	}
+
+	return varMaps, imports
+}
+
+// resources with a count of one are listed with and without index
+func unindexedAddress(address string) string {
+	return strings.TrimSuffix(address, "[0]")
+}
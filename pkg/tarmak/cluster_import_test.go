@@ -0,0 +1,36 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"reflect"
+	"testing"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+)
+
+func TestImportReport(t *testing.T) {
+	resources := []*tarmakv1alpha1.ImportResource{
+		{Address: "module.network.aws_vpc.main", ID: "vpc-1"},
+		{Address: "module.network.aws_subnet.public[0]", ID: "subnet-1"},
+		{Address: "module.network.aws_subnet.public[1]", ID: "subnet-2"},
+		{Address: "module.bastion.aws_instance.bastion"},
+	}
+	managed := []string{
+		"module.network.aws_vpc.main",
+		"module.network.aws_subnet.public",
+	}
+
+	varMaps, imports := importReport(resources, managed)
+
+	var statuses []string
+	for _, varMap := range varMaps {
+		statuses = append(statuses, varMap["status"])
+	}
+	if exp := []string{importStatusManaged, importStatusManaged, importStatusImport, importStatusMissing}; !reflect.DeepEqual(exp, statuses) {
+		t.Errorf("unexpected statuses:\nexp: %v\nact: %v", exp, statuses)
+	}
+
+	if len(imports) != 1 || imports[0].ID != "subnet-2" {
+		t.Errorf("expected only subnet-2 to be imported, got %+v", imports)
+	}
+}
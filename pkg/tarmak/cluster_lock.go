@@ -0,0 +1,70 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+)
+
+// ClusterLockStatus shows who holds the lock on the terraform state of the
+// cluster, since when and for which operation
+func (c *CmdTarmak) ClusterLockStatus() error {
+	lock, err := c.Cluster().Environment().Provider().StateLock(c.Cluster())
+	if err != nil {
+		return err
+	}
+
+	if lock == nil {
+		c.log.Infof("the terraform state of cluster %s is not locked", c.Cluster().ClusterName())
+		return nil
+	}
+
+	c.listStateLock(lock)
+	return nil
+}
+
+func (c *CmdTarmak) listStateLock(lock *tarmakv1alpha1.StateLock) {
+	utils.ListParameters(
+		os.Stdout,
+		[]string{"id", "operation", "who", "created", "age", "tarmak", "terraform"},
+		[]map[string]string{stateLockParameters(lock, time.Now())},
+	)
+}
+
+// locks younger than the minimum age might still be held by a running
+// operation, they are only broken when forced
+func checkStateLockAge(lock *tarmakv1alpha1.StateLock, flags tarmakv1alpha1.ClusterForceUnlockFlags, now time.Time) error {
+	age := now.Sub(lock.Created.Time)
+	if age >= flags.MinAge || flags.Force {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"lock %s has been taken %s ago by %s, which is less than --min-age=%s. It might be held by an operation that is still running, use --force to break it anyway",
+		lock.ID,
+		age.Truncate(time.Second),
+		lock.Who,
+		flags.MinAge,
+	)
+}
+
+func stateLockParameters(lock *tarmakv1alpha1.StateLock, now time.Time) map[string]string {
+	tarmakVersion := lock.TarmakVersion
+	if tarmakVersion == "" {
+		tarmakVersion = "unknown"
+	}
+
+	return map[string]string{
+		"id":        lock.ID,
+		"operation": lock.Operation,
+		"who":       lock.Who,
+		"created":   lock.Created.UTC().Format("2006-01-02 15:04:05"),
+		"age":       now.Sub(lock.Created.Time).Truncate(time.Second).String(),
+		"tarmak":    tarmakVersion,
+		"terraform": lock.TerraformVersion,
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+)
+
+func TestCheckStateLockAge(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	lock := &tarmakv1alpha1.StateLock{
+		ID:      "b3c1ad0e",
+		Who:     "jane@laptop",
+		Created: metav1.NewTime(now.Add(-5 * time.Minute)),
+	}
+
+	for _, tc := range []struct {
+		flags tarmakv1alpha1.ClusterForceUnlockFlags
+		err   bool
+	}{
+		{flags: tarmakv1alpha1.ClusterForceUnlockFlags{MinAge: 15 * time.Minute}, err: true},
+		{flags: tarmakv1alpha1.ClusterForceUnlockFlags{MinAge: 15 * time.Minute, Force: true}},
+		{flags: tarmakv1alpha1.ClusterForceUnlockFlags{MinAge: 5 * time.Minute}},
+		{flags: tarmakv1alpha1.ClusterForceUnlockFlags{}},
+	} {
+		err := checkStateLockAge(lock, tc.flags, now)
+		if tc.err && err == nil {
+			t.Errorf("expected an error for %+v", tc.flags)
+		}
+		if !tc.err && err != nil {
+			t.Errorf("unexpected error for %+v: %s", tc.flags, err)
+		}
+	}
+}
+
+func TestStateLockParameters(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	params := stateLockParameters(&tarmakv1alpha1.StateLock{
+		ID:      "b3c1ad0e",
+		Created: metav1.NewTime(now.Add(-90 * time.Second)),
+	}, now)
+
+	if exp, act := "1m30s", params["age"]; exp != act {
+		t.Errorf("expected age %s, got %s", exp, act)
+	}
+	if exp, act := "unknown", params["tarmak"]; exp != act {
+		t.Errorf("expected tarmak version %s, got %s", exp, act)
+	}
+}
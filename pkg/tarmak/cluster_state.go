@@ -0,0 +1,282 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
+	"github.com/jetstack/tarmak/pkg/terraform/plan"
+)
+
+// ClusterStateList prints the addresses of all resources in the terraform
+// state of the cluster
+func (c *CmdTarmak) ClusterStateList() error {
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	addresses, err := c.terraform.StateList(c.Cluster())
+	if err != nil {
+		return err
+	}
+
+	for _, address := range addresses {
+		fmt.Fprintln(os.Stdout, address)
+	}
+
+	return nil
+}
+
+// ClusterStateShow prints the attributes of a resource in the terraform
+// state of the cluster
+func (c *CmdTarmak) ClusterStateShow() error {
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	output, err := c.terraform.StateShow(c.Cluster(), c.args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(os.Stdout, output)
+	return nil
+}
+
+// ClusterStateBackup stores a copy of the terraform state of the cluster in
+// the backups of its environment
+func (c *CmdTarmak) ClusterStateBackup() error {
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	return c.backupState()
+}
+
+// ClusterStateRestore replaces the terraform state of the cluster with one
+// of its backups. Without a backup name the available backups are listed.
+func (c *CmdTarmak) ClusterStateRestore() error {
+	flags := c.flags.Cluster.State.Restore
+
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	provider := c.Cluster().Environment().Provider()
+	if len(c.args) == 0 {
+		backups, err := provider.StateBackups(c.Cluster())
+		if err != nil {
+			return err
+		}
+
+		utils.ListParameters(os.Stdout, []string{"name", "timestamp", "size", "path"}, stateBackupParameters(backups))
+		return nil
+	}
+
+	name := c.args[0]
+	state, err := provider.ReadStateBackup(c.Cluster(), name)
+	if err != nil {
+		return err
+	}
+	if _, err := plan.ReadState(state); err != nil {
+		return fmt.Errorf("error reading state backup '%s': %s", name, err)
+	}
+
+	if !flags.AutoApprove {
+		if err := c.confirmState(fmt.Sprintf("Replace the terraform state of cluster %s with backup %s?", c.Cluster().ClusterName(), name)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.backupState(); err != nil {
+		return err
+	}
+
+	// backups are older than the current state, so pushing them is forced
+	if err := c.terraform.StatePush(c.Cluster(), state, true); err != nil {
+		return err
+	}
+
+	c.log.Infof("restored terraform state of cluster %s from backup %s", c.Cluster().ClusterName(), name)
+	return nil
+}
+
+// ClusterStateMove moves a resource to a different address in the terraform
+// state of the cluster, after backing up the state
+func (c *CmdTarmak) ClusterStateMove() error {
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	if err := c.backupState(); err != nil {
+		return err
+	}
+
+	if err := c.terraform.StateMove(c.Cluster(), c.args[0], c.args[1]); err != nil {
+		return err
+	}
+
+	c.log.Infof("moved %s to %s", c.args[0], c.args[1])
+	return nil
+}
+
+// ClusterStateRemove removes resources from the terraform state of the
+// cluster, after backing up the state. The resources themselves are kept.
+func (c *CmdTarmak) ClusterStateRemove() error {
+	flags := c.flags.Cluster.State.Remove
+
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	if !flags.AutoApprove {
+		if err := c.confirmState(fmt.Sprintf("Remove %s from the terraform state of cluster %s? The resources will no longer be managed by tarmak.", strings.Join(c.args, ", "), c.Cluster().ClusterName())); err != nil {
+			return err
+		}
+	}
+
+	if err := c.backupState(); err != nil {
+		return err
+	}
+
+	if err := c.terraform.StateRemove(c.Cluster(), c.args); err != nil {
+		return err
+	}
+
+	c.log.Infof("removed %d resources from the terraform state", len(c.args))
+	return nil
+}
+
+// ClusterStateMigrate copies the terraform state of the cluster from another
+// location, like the state bucket of a different provider or region, into the
+// remote state of the cluster. The source is left in place.
+func (c *CmdTarmak) ClusterStateMigrate() error {
+	flags := c.flags.Cluster.State.Migrate
+
+	if err := c.setupTerraform(); err != nil {
+		return err
+	}
+
+	destination := c.Cluster().Environment().Provider()
+	source := destination
+	if flags.FromProvider != "" {
+		var err error
+		source, err = c.ProviderByName(flags.FromProvider)
+		if err != nil {
+			return err
+		}
+	}
+
+	bucket, key := stateLocation(source, flags, c.Cluster().Environment().Name(), c.Cluster().Name())
+	if source == destination &&
+		bucket == destination.RemoteStateBucketName() &&
+		key == destination.RemoteStateObjectKey(c.Cluster().Environment().Name(), c.Cluster().Name()) {
+		return fmt.Errorf("state is already stored in s3://%s/%s, specify a different source", bucket, key)
+	}
+
+	c.log.Infof("reading terraform state from s3://%s/%s", bucket, key)
+	state, err := source.ReadRemoteState(bucket, key)
+	if err != nil {
+		return err
+	}
+	if _, err := plan.ReadState(state); err != nil {
+		return fmt.Errorf("error reading state s3://%s/%s: %s", bucket, key, err)
+	}
+
+	current, err := c.terraform.StatePull(c.Cluster())
+	if err != nil {
+		return err
+	}
+	currentState, err := plan.ReadState(current)
+	if err != nil {
+		return err
+	}
+	if currentState.HasResources() && !flags.Force {
+		return fmt.Errorf("the terraform state of cluster %s already contains resources, use --force to replace it", c.Cluster().ClusterName())
+	}
+
+	if !flags.AutoApprove {
+		if err := c.confirmState(fmt.Sprintf("Migrate the terraform state of cluster %s from s3://%s/%s?", c.Cluster().ClusterName(), bucket, key)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.backupState(); err != nil {
+		return err
+	}
+
+	// a state without resources has nothing to protect from a different lineage
+	if err := c.terraform.StatePush(c.Cluster(), state, flags.Force || !currentState.HasResources()); err != nil {
+		return err
+	}
+
+	c.log.Infof("migrated terraform state of cluster %s, remove s3://%s/%s once the migration has been verified", c.Cluster().ClusterName(), bucket, key)
+	return nil
+}
+
+// backs up the current state before it is changed, clusters without a state
+// have nothing to back up
+func (c *CmdTarmak) backupState() error {
+	state, err := c.terraform.StatePull(c.Cluster())
+	if err != nil {
+		return err
+	}
+
+	if len(strings.TrimSpace(string(state))) == 0 {
+		c.log.Infof("cluster %s has no terraform state to back up", c.Cluster().ClusterName())
+		return nil
+	}
+
+	backup, err := c.Cluster().Environment().Provider().BackupState(c.Cluster(), state)
+	if err != nil {
+		return fmt.Errorf("error backing up terraform state: %s", err)
+	}
+
+	c.log.Infof("backed up terraform state to %s", backup.Path)
+	return nil
+}
+
+func (c *CmdTarmak) confirmState(query string) error {
+	approved, err := input.New(os.Stdin, os.Stdout).AskYesNo(&input.AskYesNo{
+		Default: false,
+		Query:   query,
+	})
+	if err != nil {
+		return err
+	}
+	if !approved {
+		return fmt.Errorf("not changing the terraform state of cluster %s", c.Cluster().ClusterName())
+	}
+	return nil
+}
+
+// the source location defaults to where the provider would store the state
+func stateLocation(provider interfaces.Provider, flags tarmakv1alpha1.ClusterStateMigrateFlags, environment, cluster string) (bucket, key string) {
+	bucket = flags.FromBucket
+	if bucket == "" {
+		bucket = provider.RemoteStateBucketName()
+	}
+	key = flags.FromKey
+	if key == "" {
+		key = provider.RemoteStateObjectKey(environment, cluster)
+	}
+	return bucket, key
+}
+
+func stateBackupParameters(backups []*tarmakv1alpha1.StateBackup) []map[string]string {
+	varMaps := make([]map[string]string, 0)
+	for _, backup := range backups {
+		varMaps = append(varMaps, map[string]string{
+			"name":      backup.Name,
+			"timestamp": backup.Timestamp.UTC().Format("2006-01-02 15:04:05"),
+			"size":      fmt.Sprintf("%d", backup.Size),
+			"path":      backup.Path,
+		})
+	}
+	return varMaps
+}
@@ -0,0 +1,46 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
+)
+
+func TestStateLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().RemoteStateBucketName().AnyTimes().Return("old-eu-west-1-terraform-state")
+	provider.EXPECT().RemoteStateObjectKey("production", "applications").AnyTimes().Return("production/applications/main.tfstate")
+
+	for _, tc := range []struct {
+		flags  tarmakv1alpha1.ClusterStateMigrateFlags
+		bucket string
+		key    string
+	}{
+		{
+			bucket: "old-eu-west-1-terraform-state",
+			key:    "production/applications/main.tfstate",
+		},
+		{
+			flags:  tarmakv1alpha1.ClusterStateMigrateFlags{FromBucket: "legacy-state"},
+			bucket: "legacy-state",
+			key:    "production/applications/main.tfstate",
+		},
+		{
+			flags:  tarmakv1alpha1.ClusterStateMigrateFlags{FromKey: "prod/apps.tfstate"},
+			bucket: "old-eu-west-1-terraform-state",
+			key:    "prod/apps.tfstate",
+		},
+	} {
+		bucket, key := stateLocation(provider, tc.flags, "production", "applications")
+		if bucket != tc.bucket || key != tc.key {
+			t.Errorf("unexpected location for %+v: exp=%s/%s act=%s/%s", tc.flags, tc.bucket, tc.key, bucket, key)
+		}
+	}
+}
@@ -4,10 +4,13 @@ package tarmak
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,10 +19,13 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 
+	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/cluster"
 	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
+	"github.com/jetstack/tarmak/pkg/terraform/plan"
 )
 
 type CmdTarmak struct {
@@ -68,6 +74,11 @@ func (c *CmdTarmak) Apply() error {
 		if err != nil {
 			return err
 		}
+
+		// terraform uploads the puppet.tar.gz, so record it in the history
+		if err := c.Cluster().RecordConfiguration(); err != nil {
+			c.log.Warnf("failed to record manifest in history: %s", err)
+		}
 	}
 
 	// upload tar gz only if terraform hasn't uploaded it yet
@@ -228,6 +239,124 @@ func (c *CmdTarmak) DestroyEnvironment() error {
 	return nil
 }
 
+// drift report of a single cluster
+type clusterDrift struct {
+	Cluster string         `json:"cluster"`
+	Changes []*plan.Change `json:"changes"`
+	Error   string         `json:"error,omitempty"`
+}
+
+func (d *clusterDrift) count(kind plan.ChangeKind) int {
+	count := 0
+	for _, change := range d.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// EnvironmentDrift plans the hub and every cluster of the environment and
+// reports changes made outside of terraform. It returns 2 if drift has been
+// found.
+func (c *CmdTarmak) EnvironmentDrift() (returnCode int, err error) {
+	flags := c.flags.Environment.Drift
+	if flags.Output != "text" && flags.Output != "json" {
+		return 1, fmt.Errorf("unsupported output format '%s', use text or json", flags.Output)
+	}
+
+	if len(c.args) > 0 {
+		c.Tarmak.environment, err = c.Tarmak.EnvironmentByName(c.args[0])
+		if err != nil {
+			return 1, err
+		}
+	}
+
+	// the hub first, like it is applied
+	var clusters []interfaces.Cluster
+	if hub := c.Environment().Hub(); hub != nil {
+		clusters = append(clusters, hub)
+	}
+	for _, cluster := range c.Environment().Clusters() {
+		if hub := c.Environment().Hub(); hub == nil || cluster.Name() != hub.Name() {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	var reports []*clusterDrift
+	var failed []string
+	for _, cluster := range clusters {
+		c.cluster = cluster
+		c.log.Infof("detecting drift of cluster %s", cluster.ClusterName())
+
+		report := &clusterDrift{Cluster: cluster.ClusterName()}
+		reports = append(reports, report)
+
+		err := c.setupTerraform()
+		if err == nil {
+			report.Changes, err = c.terraform.Drift(cluster)
+		}
+		c.terraform.ResetTerraformWrapper()
+		if err != nil {
+			c.log.Errorf("failed to detect drift of cluster %s: %s", cluster.ClusterName(), err)
+			report.Error = err.Error()
+			failed = append(failed, cluster.ClusterName())
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return 1, c.ctx.Err()
+		default:
+		}
+	}
+
+	if err := outputDrift(os.Stdout, flags.Output, reports); err != nil {
+		return 1, err
+	}
+
+	if len(failed) > 0 {
+		return 1, fmt.Errorf("failed to detect drift of clusters: %s", strings.Join(failed, ", "))
+	}
+
+	for _, report := range reports {
+		if report.count(plan.ChangeKindDrift) > 0 {
+			return 2, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func outputDrift(w io.Writer, format string, reports []*clusterDrift) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	for _, report := range reports {
+		if report.Error != "" {
+			fmt.Fprintf(w, "==> %s: error: %s\n", report.Cluster, report.Error)
+			continue
+		}
+		fmt.Fprintf(
+			w,
+			"==> %s: %d drifted, %d configuration changes\n",
+			report.Cluster,
+			report.count(plan.ChangeKindDrift),
+			report.count(plan.ChangeKindConfig),
+		)
+		for _, change := range report.Changes {
+			fmt.Fprintf(w, "  %s\n", change)
+		}
+	}
+
+	return nil
+}
+
 func (c *CmdTarmak) Shell() error {
 	if err := c.setupTerraform(); err != nil {
 		c.log.Warnf("error setting up tarmak for terrafrom shell: %v", err)
@@ -245,32 +374,58 @@ func (c *CmdTarmak) Shell() error {
 	return nil
 }
 
+// ForceUnlock breaks the lock held on the terraform state of the cluster,
+// after showing who holds it. Locks younger than the minimum age are only
+// broken when forced.
 func (c *CmdTarmak) ForceUnlock() error {
+	flags := c.flags.Cluster.ForceUnlock
+
 	if err := c.setupTerraform(); err != nil {
 		return err
 	}
 
-	if len(c.args) != 1 {
-		return fmt.Errorf("expected single lock ID argument, got=%d", len(c.args))
+	if len(c.args) > 1 {
+		return fmt.Errorf("expected at most a single lock ID argument, got=%d", len(c.args))
 	}
 
-	in := input.New(os.Stdin, os.Stdout)
-	query := fmt.Sprintf(`Attempting force-unlock using lock ID [%s]
-Are you sure you want to force-unlock the remote state? This can be potentially dangerous!`, c.args[0])
-	doUnlock, err := in.AskYesNo(&input.AskYesNo{
-		Default: false,
-		Query:   query,
-	})
+	lock, err := c.Cluster().Environment().Provider().StateLock(c.Cluster())
 	if err != nil {
 		return err
 	}
 
-	if !doUnlock {
-		c.log.Infof("aborting force unlock")
-		return nil
+	if lock == nil {
+		return fmt.Errorf("the terraform state of cluster %s is not locked", c.Cluster().ClusterName())
+	}
+
+	if len(c.args) == 1 && c.args[0] != lock.ID {
+		return fmt.Errorf("lock ID %s doesn't match the lock held on the terraform state: %s", c.args[0], lock.ID)
 	}
 
-	err = c.terraform.ForceUnlock(c.Cluster(), c.args[0])
+	c.listStateLock(lock)
+
+	if err := checkStateLockAge(lock, flags, time.Now()); err != nil {
+		return err
+	}
+
+	if !flags.AutoApprove {
+		in := input.New(os.Stdin, os.Stdout)
+		query := fmt.Sprintf(`Attempting force-unlock using lock ID [%s]
+Are you sure you want to force-unlock the remote state? This can be potentially dangerous!`, lock.ID)
+		doUnlock, err := in.AskYesNo(&input.AskYesNo{
+			Default: false,
+			Query:   query,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !doUnlock {
+			c.log.Infof("aborting force unlock")
+			return nil
+		}
+	}
+
+	err = c.terraform.ForceUnlock(c.Cluster(), lock.ID)
 	if err != nil {
 		return err
 	}
@@ -409,6 +564,170 @@ func (c *CmdTarmak) Logs() error {
 	return nil
 }
 
+func (c *CmdTarmak) EncryptionRotateKey() error {
+	if err := c.writeSSHConfigForClusterHosts(); err != nil {
+		return err
+	}
+
+	in := input.New(os.Stdin, os.Stdout)
+	query := fmt.Sprintf(`Rotating the encryption key of cluster [%s] will restart all API servers and rewrite all encrypted resources.
+Are you sure you want to continue?`, c.Cluster().ClusterName())
+	doRotate, err := in.AskYesNo(&input.AskYesNo{
+		Default: false,
+		Query:   query,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !doRotate {
+		c.log.Infof("aborting encryption key rotation")
+		return nil
+	}
+
+	path := c.kubectl.ConfigPath()
+	if _, err := c.kubectl.Kubeconfig(path, c.kubePublicAPIEndpoint()); err != nil {
+		return err
+	}
+
+	return c.encryption.RotateKey(path)
+}
+
+func (c *CmdTarmak) ConfigurationRollback() error {
+	flags := c.flags.Cluster.Configuration.Rollback
+
+	if err := c.Cluster().RollbackConfiguration(flags.To, flags.InstancePool); err != nil {
+		return err
+	}
+
+	select {
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	default:
+	}
+
+	if flags.WaitForConvergence {
+		return c.Cluster().WaitForConvergance()
+	}
+
+	return nil
+}
+
+func (c *CmdTarmak) InstancesInventory() error {
+	flags := c.flags.Cluster.Instances.Inventory
+
+	instances, err := c.Cluster().Inventory()
+	if err != nil {
+		return err
+	}
+
+	var filtered []*wingv1alpha1.Instance
+	varMaps := make([]map[string]string, 0)
+	for _, instance := range instances {
+		if flags.InstancePool != "" && instance.InstancePool != flags.InstancePool {
+			continue
+		}
+		filtered = append(filtered, instance)
+		varMaps = append(varMaps, inventoryParameters(instance))
+	}
+
+	utils.ListParameters(os.Stdout, []string{"id", "pool", "state", "type", "os", "kernel", "puppet", "kubelet", "docker", "uptime", "reboot", "disks"}, varMaps)
+
+	drifts := cluster.InventoryDrift(filtered)
+	for _, drift := range drifts {
+		c.log.Warn(drift.String())
+	}
+
+	if flags.FailOnDrift && len(drifts) > 0 {
+		return fmt.Errorf("versions differ between instances of %d instance pools", len(drifts))
+	}
+
+	return nil
+}
+
+func (c *CmdTarmak) InstancesExec() error {
+	flags := c.flags.Cluster.Instances.Exec
+
+	results, err := c.Cluster().RunCommand(flags.InstancePool, c.args, flags.Timeout)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failed := 0
+	for _, name := range names {
+		result := results[name]
+		if result == nil {
+			failed++
+			fmt.Fprintf(os.Stdout, "==> %s: no result reported\n", name)
+			continue
+		}
+
+		if result.State != wingv1alpha1.CommandStateSucceeded {
+			failed++
+		}
+		fmt.Fprintf(os.Stdout, "==> %s: %s (exit code %d)\n", name, result.State, result.ExitCode)
+		if result.Output != "" {
+			fmt.Fprintln(os.Stdout, strings.TrimRight(result.Output, "\n"))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("command failed on %d of %d instances", failed, len(names))
+	}
+
+	return nil
+}
+
+func inventoryParameters(instance *wingv1alpha1.Instance) map[string]string {
+	params := map[string]string{
+		"id":    instance.Name,
+		"pool":  instance.InstancePool,
+		"state": "unknown",
+	}
+
+	if instance.Status == nil {
+		return params
+	}
+
+	if instance.Status.Converge != nil && instance.Status.Converge.State != "" {
+		params["state"] = string(instance.Status.Converge.State)
+	}
+
+	facts := instance.Status.Facts
+	if facts == nil {
+		return params
+	}
+
+	params["type"] = facts.InstanceType
+	params["os"] = facts.OSRelease
+	params["kernel"] = facts.Kernel
+	params["puppet"] = facts.PuppetVersion
+	params["kubelet"] = facts.KubeletVersion
+	params["docker"] = facts.DockerVersion
+	params["reboot"] = fmt.Sprintf("%t", facts.RebootRequired)
+
+	if !facts.BootTimestamp.IsZero() {
+		params["uptime"] = facts.LastUpdateTimestamp.Sub(facts.BootTimestamp.Time).Truncate(time.Minute).String()
+	}
+
+	var disks []string
+	for _, disk := range facts.Disks {
+		if disk.TotalBytes == 0 {
+			continue
+		}
+		disks = append(disks, fmt.Sprintf("%s=%d%%", disk.Path, disk.UsedBytes*100/disk.TotalBytes))
+	}
+	params["disks"] = strings.Join(disks, ",")
+
+	return params
+}
+
 func (c *CmdTarmak) verifyTerraformBinaryVersion() error {
 	cmd := exec.Command("terraform", "version")
 	cmd.Env = os.Environ()
@@ -26,6 +26,9 @@ type Config struct {
 	conf  *tarmakv1alpha1.Config
 	flags *tarmakv1alpha1.Flags
 
+	// configuration is split into multiple files
+	directory bool
+
 	scheme *runtime.Scheme
 	codecs serializer.CodecFactory
 	log    *logrus.Entry
@@ -55,6 +58,7 @@ func New(tarmak interfaces.Tarmak, flags *tarmakv1alpha1.Flags) (*Config, error)
 
 func newConfig() *tarmakv1alpha1.Config {
 	c := &tarmakv1alpha1.Config{}
+	setSchemaVersions(c)
 	return c
 }
 
@@ -65,7 +69,6 @@ func (c *Config) NewAmazonConfigClusterSingle() *tarmakv1alpha1.Config {
 	}
 	provider := NewAmazonProfileProvider("dev", "jetstack-dev")
 	cluster := NewClusterSingle("dev", "cluster")
-	cluster.CloudId = provider.ObjectMeta.Name
 	conf.Providers = []tarmakv1alpha1.Provider{*provider}
 	conf.Clusters = []clusterv1alpha1.Cluster{*cluster}
 	c.scheme.Default(conf)
@@ -88,6 +91,10 @@ func ApplyDefaults(src runtime.Object) error {
 }
 
 func (c *Config) writeYAML(config *tarmakv1alpha1.Config) error {
+	if c.directory {
+		return c.writeDirectory(config)
+	}
+
 	var encoder runtime.Encoder
 	mediaTypes := c.codecs.SupportedMediaTypes()
 	for _, info := range mediaTypes {
@@ -143,6 +150,9 @@ func (c *Config) CurrentCluster() (string, error) {
 
 func (c *Config) SetCurrentCluster(clusterName string) error {
 	c.conf.CurrentCluster = clusterName
+	if c.directory {
+		return c.writeLocal(c.conf)
+	}
 	return c.writeYAML(c.conf)
 }
 
@@ -252,7 +262,7 @@ func (c *Config) UniqueProviderName(name string) error {
 
 func (c *Config) AppendProvider(prov *tarmakv1alpha1.Provider) error {
 	if c.conf == nil {
-		c.conf = &tarmakv1alpha1.Config{}
+		c.conf = newConfig()
 		c.scheme.Default(c.conf)
 	}
 
@@ -267,7 +277,7 @@ func (c *Config) AppendProvider(prov *tarmakv1alpha1.Provider) error {
 
 func (c *Config) AppendEnvironment(env *tarmakv1alpha1.Environment) error {
 	if c.conf == nil {
-		c.conf = &tarmakv1alpha1.Config{}
+		c.conf = newConfig()
 		c.scheme.Default(c.conf)
 	}
 
@@ -318,7 +328,7 @@ func (c *Config) UniqueEnvironmentName(name string) error {
 
 func (c *Config) AppendCluster(cluster *clusterv1alpha1.Cluster) error {
 	if c.conf == nil {
-		c.conf = &tarmakv1alpha1.Config{}
+		c.conf = newConfig()
 		c.scheme.Default(c.conf)
 	}
 
@@ -341,10 +351,27 @@ func (c *Config) UniqueClusterName(environment, name string) error {
 }
 
 func (c *Config) configPath() string {
-	return filepath.Join(c.tarmak.ConfigPath(), "tarmak.yaml")
+	return filepath.Join(c.tarmak.ConfigPath(), configFileName)
 }
 
 func (c *Config) ReadConfig() (*tarmakv1alpha1.Config, error) {
+	directory, err := c.directoryLayout()
+	if err != nil {
+		return nil, err
+	}
+	if directory {
+		config, err := c.readDirectory()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkSchema(config); err != nil {
+			return nil, err
+		}
+		c.directory = true
+		c.conf = config
+		return config, nil
+	}
+
 	path := c.configPath()
 
 	configBytes, err := ioutil.ReadFile(path)
@@ -362,6 +389,10 @@ func (c *Config) ReadConfig() (*tarmakv1alpha1.Config, error) {
 		return nil, fmt.Errorf("got unexpected config type: %v", gvk)
 	}
 
+	if err := c.checkSchema(config); err != nil {
+		return nil, err
+	}
+
 	c.conf = config
 	return config, nil
 }
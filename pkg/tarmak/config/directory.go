@@ -0,0 +1,319 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+)
+
+// The directory layout splits the configuration into one file per object,
+// so it can be kept in version control without merge conflicts:
+//
+//	tarmak.d/tarmak.yaml                   contact, project and schema versions
+//	tarmak.d/providers/<name>.yaml         providers
+//	tarmak.d/environments/<name>.yaml      environments
+//	tarmak.d/clusters/<env>/<name>.yaml    clusters
+//	tarmak.local.yaml                      current cluster, not to be shared
+const (
+	configFileName      = "tarmak.yaml"
+	configDirName       = "tarmak.d"
+	localConfigFileName = "tarmak.local.yaml"
+	migratedSuffix      = ".migrated"
+
+	providersDirName    = "providers"
+	environmentsDirName = "environments"
+	clustersDirName     = "clusters"
+)
+
+func (c *Config) configDirPath() string {
+	return filepath.Join(c.tarmak.ConfigPath(), configDirName)
+}
+
+func (c *Config) localConfigPath() string {
+	return filepath.Join(c.tarmak.ConfigPath(), localConfigFileName)
+}
+
+// the directory layout is used as soon as its directory exists
+func (c *Config) directoryLayout() (bool, error) {
+	info, err := os.Stat(c.configDirPath())
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !info.IsDir() {
+		return false, fmt.Errorf("'%s' is not a directory", c.configDirPath())
+	}
+
+	if _, err := os.Stat(c.configPath()); err == nil {
+		return false, fmt.Errorf("found both '%s' and '%s', remove one of them", c.configPath(), c.configDirPath())
+	}
+
+	return true, nil
+}
+
+// Migrate moves the configuration from the single tarmak.yaml file into the
+// directory layout. The old file is kept with a .migrated suffix.
+func (c *Config) Migrate() error {
+	if c.directory {
+		return fmt.Errorf("configuration in '%s' already uses the directory layout", c.configDirPath())
+	}
+	if c.conf == nil {
+		return fmt.Errorf("no configuration found in '%s'", c.configPath())
+	}
+
+	if err := c.writeDirectory(c.conf); err != nil {
+		return err
+	}
+
+	if err := os.Rename(c.configPath(), c.configPath()+migratedSuffix); err != nil {
+		return fmt.Errorf("error moving '%s' out of the way: %s", c.configPath(), err)
+	}
+	c.directory = true
+
+	c.log.Infof("migrated configuration to '%s', the current cluster is stored in '%s'", c.configDirPath(), c.localConfigPath())
+	return nil
+}
+
+func (c *Config) readDirectory() (*tarmakv1alpha1.Config, error) {
+	dir := c.configDirPath()
+	conf := &tarmakv1alpha1.Config{}
+
+	shared, err := c.readConfigObject(filepath.Join(dir, configFileName))
+	if err != nil {
+		return nil, err
+	}
+	if shared != nil {
+		if len(shared.Providers) > 0 || len(shared.Environments) > 0 || len(shared.Clusters) > 0 || shared.CurrentCluster != "" {
+			return nil, fmt.Errorf("'%s' can only contain contact and project", filepath.Join(dir, configFileName))
+		}
+		conf.Annotations = shared.Annotations
+		conf.Contact = shared.Contact
+		conf.Project = shared.Project
+	}
+
+	local, err := c.readConfigObject(c.localConfigPath())
+	if err != nil {
+		return nil, err
+	}
+	if local != nil {
+		conf.CurrentCluster = local.CurrentCluster
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, providersDirName, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		obj, err := c.readObject(path)
+		if err != nil {
+			return nil, err
+		}
+		provider, ok := obj.(*tarmakv1alpha1.Provider)
+		if !ok {
+			return nil, fmt.Errorf("'%s' does not contain a provider", path)
+		}
+		if err := checkFileName(path, provider.Name); err != nil {
+			return nil, err
+		}
+		conf.Providers = append(conf.Providers, *provider)
+	}
+
+	paths, err = filepath.Glob(filepath.Join(dir, environmentsDirName, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		obj, err := c.readObject(path)
+		if err != nil {
+			return nil, err
+		}
+		environment, ok := obj.(*tarmakv1alpha1.Environment)
+		if !ok {
+			return nil, fmt.Errorf("'%s' does not contain an environment", path)
+		}
+		if err := checkFileName(path, environment.Name); err != nil {
+			return nil, err
+		}
+		conf.Environments = append(conf.Environments, *environment)
+	}
+
+	paths, err = filepath.Glob(filepath.Join(dir, clustersDirName, "*", "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		obj, err := c.readObject(path)
+		if err != nil {
+			return nil, err
+		}
+		cluster, ok := obj.(*clusterv1alpha1.Cluster)
+		if !ok {
+			return nil, fmt.Errorf("'%s' does not contain a cluster", path)
+		}
+		if err := checkFileName(path, cluster.Name); err != nil {
+			return nil, err
+		}
+		if environment := filepath.Base(filepath.Dir(path)); cluster.Environment != environment {
+			return nil, fmt.Errorf("cluster in '%s' belongs to environment '%s' instead of '%s'", path, cluster.Environment, environment)
+		}
+		conf.Clusters = append(conf.Clusters, *cluster)
+	}
+
+	c.scheme.Default(conf)
+	return conf, nil
+}
+
+func checkFileName(path, name string) error {
+	if exp := strings.TrimSuffix(filepath.Base(path), ".yaml"); name != exp {
+		return fmt.Errorf("'%s' contains '%s', its name has to match the file name", path, name)
+	}
+	return nil
+}
+
+func (c *Config) readObject(path string) (runtime.Object, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, _, err := c.codecs.UniversalDecoder(tarmakv1alpha1.SchemeGroupVersion, clusterv1alpha1.SchemeGroupVersion).Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding '%s': %s", path, err)
+	}
+
+	return obj, nil
+}
+
+// read an optional config object
+func (c *Config) readConfigObject(path string) (*tarmakv1alpha1.Config, error) {
+	obj, err := c.readObject(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := obj.(*tarmakv1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf("'%s' does not contain a config", path)
+	}
+	return conf, nil
+}
+
+func (c *Config) writeDirectory(conf *tarmakv1alpha1.Config) error {
+	dir := c.configDirPath()
+
+	shared := &tarmakv1alpha1.Config{
+		Contact: conf.Contact,
+		Project: conf.Project,
+	}
+	shared.Annotations = conf.Annotations
+	files := map[string]runtime.Object{
+		filepath.Join(dir, configFileName): shared,
+	}
+	for pos := range conf.Providers {
+		provider := &conf.Providers[pos]
+		files[filepath.Join(dir, providersDirName, provider.Name+".yaml")] = provider
+	}
+	for pos := range conf.Environments {
+		environment := &conf.Environments[pos]
+		files[filepath.Join(dir, environmentsDirName, environment.Name+".yaml")] = environment
+	}
+	for pos := range conf.Clusters {
+		cluster := &conf.Clusters[pos]
+		files[filepath.Join(dir, clustersDirName, cluster.Environment, cluster.Name+".yaml")] = cluster
+	}
+
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := c.writeObject(path, files[path]); err != nil {
+			return err
+		}
+	}
+
+	// remove objects that no longer exist
+	for _, pattern := range []string{
+		filepath.Join(dir, providersDirName, "*.yaml"),
+		filepath.Join(dir, environmentsDirName, "*.yaml"),
+		filepath.Join(dir, clustersDirName, "*", "*.yaml"),
+	} {
+		existing, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+		for _, path := range existing {
+			if _, ok := files[path]; ok {
+				continue
+			}
+			c.log.Debugf("removing '%s'", path)
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			// only succeeds for empty environment directories
+			if filepath.Base(filepath.Dir(filepath.Dir(path))) == clustersDirName {
+				os.Remove(filepath.Dir(path))
+			}
+		}
+	}
+
+	return c.writeLocal(conf)
+}
+
+func (c *Config) writeLocal(conf *tarmakv1alpha1.Config) error {
+	return c.writeObject(c.localConfigPath(), &tarmakv1alpha1.Config{
+		CurrentCluster: conf.CurrentCluster,
+	})
+}
+
+func (c *Config) encodeObject(obj runtime.Object) ([]byte, error) {
+	gvks, _, err := c.scheme.ObjectKinds(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := json.NewYAMLSerializer(json.DefaultMetaFactory, c.scheme, c.scheme)
+	var buf bytes.Buffer
+	// encode a copy, as encoding sets the type meta
+	if err := c.codecs.EncoderForVersion(encoder, gvks[0].GroupVersion()).Encode(obj.DeepCopyObject(), &buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// objects are only written if they have changed, this keeps unrelated files
+// untouched
+func (c *Config) writeObject(path string, obj runtime.Object) error {
+	data, err := c.encodeObject(obj)
+	if err != nil {
+		return fmt.Errorf("error encoding '%s': %s", path, err)
+	}
+
+	if existing, err := ioutil.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0640)
+}
@@ -0,0 +1,100 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0640); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_ReadConfig_Directory(t *testing.T) {
+	c := newFakeConfig(t)
+	defer c.Finish()
+
+	dir := filepath.Join(c.configPath, configDirName)
+	writeTestFile(t, filepath.Join(dir, configFileName), `apiVersion: api.tarmak.io/v1alpha1
+kind: Config
+contact: admin@example.com
+project: example
+`)
+	writeTestFile(t, filepath.Join(dir, providersDirName, "aws.yaml"), `apiVersion: api.tarmak.io/v1alpha1
+kind: Provider
+metadata:
+  name: aws
+amazon:
+  profile: example
+`)
+	writeTestFile(t, filepath.Join(dir, environmentsDirName, "dev.yaml"), `apiVersion: api.tarmak.io/v1alpha1
+kind: Environment
+metadata:
+  name: dev
+provider: aws
+location: eu-west-1
+`)
+	writeTestFile(t, filepath.Join(dir, clustersDirName, "dev", "cluster.yaml"), `apiVersion: cluster.k8s.io/v1alpha1
+kind: Cluster
+metadata:
+  name: cluster
+environment: dev
+location: eu-west-1
+`)
+	writeTestFile(t, filepath.Join(c.configPath, localConfigFileName), `apiVersion: api.tarmak.io/v1alpha1
+kind: Config
+currentCluster: dev-cluster
+`)
+
+	conf, err := c.ReadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !c.directory {
+		t.Error("expected directory layout to be detected")
+	}
+
+	if act, exp := conf.Contact, "admin@example.com"; act != exp {
+		t.Errorf("unexpected contact: exp=%s act=%s", exp, act)
+	}
+	if act, exp := conf.CurrentCluster, "dev-cluster"; act != exp {
+		t.Errorf("unexpected current cluster: exp=%s act=%s", exp, act)
+	}
+	if len(conf.Providers) != 1 || conf.Providers[0].Amazon.Profile != "example" {
+		t.Errorf("unexpected providers: %+v", conf.Providers)
+	}
+	if len(conf.Environments) != 1 || conf.Environments[0].Location != "eu-west-1" {
+		t.Errorf("unexpected environments: %+v", conf.Environments)
+	}
+	if cluster, err := c.Cluster("dev", "cluster"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if cluster.Location != "eu-west-1" {
+		t.Errorf("unexpected cluster location: %s", cluster.Location)
+	}
+
+	// names have to match the file names
+	writeTestFile(t, filepath.Join(dir, environmentsDirName, "prod.yaml"), `apiVersion: api.tarmak.io/v1alpha1
+kind: Environment
+metadata:
+  name: staging
+provider: aws
+`)
+	if _, err := c.ReadConfig(); err == nil || !strings.Contains(err.Error(), "has to match the file name") {
+		t.Errorf("expected file name mismatch error, got: %v", err)
+	}
+	os.Remove(filepath.Join(dir, environmentsDirName, "prod.yaml"))
+
+	// both layouts are not allowed at the same time
+	writeTestFile(t, filepath.Join(c.configPath, configFileName), "")
+	if _, err := c.ReadConfig(); err == nil || !strings.Contains(err.Error(), "found both") {
+		t.Errorf("expected error with both layouts, got: %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+)
+
+// lines of unchanged context shown around changes
+const diffContextLines = 3
+
+// Upgrade converts the configuration to the latest schema versions and
+// returns the changes as a diff. The configuration is only written if dryRun
+// is false. An empty diff means the configuration is up to date.
+func (c *Config) Upgrade(dryRun bool) (string, error) {
+	if c.conf == nil {
+		return "", fmt.Errorf("no configuration found in '%s'", c.tarmak.ConfigPath())
+	}
+
+	upgraded := c.conf.DeepCopy()
+	changed, err := upgradeSchema(upgraded)
+	if err != nil {
+		return "", err
+	}
+	if !changed {
+		return "", nil
+	}
+
+	before, err := c.encodeObject(c.conf)
+	if err != nil {
+		return "", err
+	}
+	after, err := c.encodeObject(upgraded)
+	if err != nil {
+		return "", err
+	}
+	diff := diffLines(string(before), string(after))
+
+	if dryRun {
+		return diff, nil
+	}
+
+	if err := c.writeYAML(upgraded); err != nil {
+		return "", err
+	}
+	c.conf = upgraded
+
+	return diff, nil
+}
+
+// checkSchema refuses configs written by a newer tarmak and warns about
+// outdated ones, which are still supported
+func (c *Config) checkSchema(conf *tarmakv1alpha1.Config) error {
+	outdated, err := upgradeSchema(conf.DeepCopy())
+	if err != nil {
+		return err
+	}
+	if outdated {
+		c.log.Warn("configuration uses an outdated schema, run 'tarmak config upgrade' to update it")
+	}
+	return nil
+}
+
+// schemaVersion returns the version of an API group a config was written
+// with, newer versions than supported by this tarmak are refused
+func schemaVersion(conf *tarmakv1alpha1.Config, key string, latest int) (int, error) {
+	value, ok := conf.Annotations[key]
+	if !ok {
+		return 1, nil
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid schema version '%s' in annotation '%s'", value, key)
+	}
+	if version > latest {
+		return 0, fmt.Errorf("configuration uses schema version %d of '%s', this tarmak only supports up to version %d, please upgrade tarmak", version, strings.Split(key, "/")[0], latest)
+	}
+
+	return version, nil
+}
+
+// upgradeSchema runs all outstanding conversions on conf, it returns false if
+// conf is already up to date
+func upgradeSchema(conf *tarmakv1alpha1.Config) (bool, error) {
+	tarmakVersion, err := schemaVersion(conf, tarmakv1alpha1.SchemaVersionAnnotationKey, tarmakv1alpha1.SchemaVersion())
+	if err != nil {
+		return false, err
+	}
+	clusterVersion, err := schemaVersion(conf, clusterv1alpha1.SchemaVersionAnnotationKey, clusterv1alpha1.SchemaVersion())
+	if err != nil {
+		return false, err
+	}
+
+	if tarmakVersion == tarmakv1alpha1.SchemaVersion() && clusterVersion == clusterv1alpha1.SchemaVersion() {
+		return false, nil
+	}
+
+	for _, convert := range tarmakv1alpha1.Conversions[tarmakVersion-1:] {
+		convert(conf)
+	}
+	for _, convert := range clusterv1alpha1.Conversions[clusterVersion-1:] {
+		for pos := range conf.Clusters {
+			convert(&conf.Clusters[pos])
+		}
+	}
+	setSchemaVersions(conf)
+
+	return true, nil
+}
+
+// new configs are written with the latest schema versions
+func setSchemaVersions(conf *tarmakv1alpha1.Config) {
+	if conf.Annotations == nil {
+		conf.Annotations = make(map[string]string)
+	}
+	conf.Annotations[tarmakv1alpha1.SchemaVersionAnnotationKey] = strconv.Itoa(tarmakv1alpha1.SchemaVersion())
+	conf.Annotations[clusterv1alpha1.SchemaVersionAnnotationKey] = strconv.Itoa(clusterv1alpha1.SchemaVersion())
+}
+
+// diffLines returns the changed lines prefixed with '-' and '+', surrounded by
+// a few lines of context
+func diffLines(before, after string) string {
+	dmp := diffmatchpatch.New()
+	charsBefore, charsAfter, lineArray := dmp.DiffLinesToChars(before, after)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(charsBefore, charsAfter, false), lineArray)
+
+	var buf bytes.Buffer
+	for pos, diff := range diffs {
+		lines := strings.SplitAfter(diff.Text, "\n")
+		if lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+
+		switch diff.Type {
+		case diffmatchpatch.DiffDelete:
+			writeLines(&buf, "- ", lines)
+		case diffmatchpatch.DiffInsert:
+			writeLines(&buf, "+ ", lines)
+		default:
+			afterChange := pos > 0
+			beforeChange := pos < len(diffs)-1
+			switch {
+			case afterChange && beforeChange && len(lines) > 2*diffContextLines:
+				writeLines(&buf, "  ", lines[:diffContextLines])
+				buf.WriteString("...\n")
+				writeLines(&buf, "  ", lines[len(lines)-diffContextLines:])
+			case afterChange && !beforeChange && len(lines) > diffContextLines:
+				writeLines(&buf, "  ", lines[:diffContextLines])
+			case beforeChange && !afterChange && len(lines) > diffContextLines:
+				writeLines(&buf, "  ", lines[len(lines)-diffContextLines:])
+			default:
+				writeLines(&buf, "  ", lines)
+			}
+		}
+	}
+
+	return buf.String()
+}
+
+func writeLines(buf *bytes.Buffer, prefix string, lines []string) {
+	for _, line := range lines {
+		buf.WriteString(prefix + line)
+	}
+}
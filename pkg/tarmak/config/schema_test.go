@@ -0,0 +1,105 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+)
+
+func TestUpgradeSchema(t *testing.T) {
+	conf := &tarmakv1alpha1.Config{
+		Contact: "admin@example.com",
+		Project: "example",
+		Environments: []tarmakv1alpha1.Environment{
+			{Contact: "dev@example.com"},
+			{Project: "staging"},
+		},
+		Clusters: []clusterv1alpha1.Cluster{
+			{CloudId: "aws", GroupIdentifier: "group"},
+		},
+	}
+
+	upgraded, err := upgradeSchema(conf)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !upgraded {
+		t.Fatal("expected config without schema versions to be upgraded")
+	}
+
+	if conf.Contact != "" || conf.Project != "" {
+		t.Errorf("expected top level contact and project to be removed, got '%s' and '%s'", conf.Contact, conf.Project)
+	}
+	for pos, exp := range [][2]string{
+		{"dev@example.com", "example"},
+		{"admin@example.com", "staging"},
+	} {
+		env := conf.Environments[pos]
+		if env.Contact != exp[0] || env.Project != exp[1] {
+			t.Errorf("unexpected contact and project of environment %d: exp=%v act=[%s %s]", pos, exp, env.Contact, env.Project)
+		}
+	}
+	if cluster := conf.Clusters[0]; cluster.CloudId != "" || cluster.GroupIdentifier != "" {
+		t.Errorf("expected unused cluster fields to be removed: %+v", cluster)
+	}
+
+	if upgraded, err := upgradeSchema(conf); err != nil || upgraded {
+		t.Errorf("expected upgraded config to be up to date, got upgraded=%t err=%v", upgraded, err)
+	}
+}
+
+func TestUpgradeSchema_Newer(t *testing.T) {
+	for _, annotations := range []map[string]string{
+		{tarmakv1alpha1.SchemaVersionAnnotationKey: "99"},
+		{clusterv1alpha1.SchemaVersionAnnotationKey: "99"},
+		{tarmakv1alpha1.SchemaVersionAnnotationKey: "latest"},
+	} {
+		conf := &tarmakv1alpha1.Config{}
+		conf.Annotations = annotations
+		if _, err := upgradeSchema(conf); err == nil {
+			t.Errorf("expected error for annotations %v", annotations)
+		}
+	}
+}
+
+func TestConfig_ReadConfig_NewerSchema(t *testing.T) {
+	c := newFakeConfig(t)
+	defer c.Finish()
+
+	writeTestFile(t, filepath.Join(c.configPath, configFileName), `apiVersion: api.tarmak.io/v1alpha1
+kind: Config
+metadata:
+  annotations:
+    api.tarmak.io/schema-version: "99"
+`)
+
+	if _, err := c.ReadConfig(); err == nil || !strings.Contains(err.Error(), "please upgrade tarmak") {
+		t.Errorf("expected error about a newer schema, got: %v", err)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nK\n"
+
+	exp := `  a
+- b
++ B
+  c
+  d
+  e
+...
+  h
+  i
+  j
+- k
++ K
+`
+	if act := diffLines(before, after); act != exp {
+		t.Errorf("unexpected diff:\nexp:\n%s\nact:\n%s", exp, act)
+	}
+}
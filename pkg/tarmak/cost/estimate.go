@@ -0,0 +1,418 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cost
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+)
+
+var blockDeviceSizeKey = regexp.MustCompile(`^((root|ebs)_block_device\.[^.]+)\.volume_size$`)
+
+// Row is the estimated monthly cost of an instance pool or of the load
+// balancers and NAT gateways of a cluster
+type Row struct {
+	Name string
+
+	CurrentType     string
+	PlannedType     string
+	CurrentCount    int
+	PlannedMinCount int
+	PlannedMaxCount int
+
+	Current    float64 // monthly cost of the resources in the state
+	Planned    float64 // monthly cost with the minimum count of the configuration
+	PlannedMax float64 // monthly cost with the maximum count of the configuration
+}
+
+// Estimate compares the monthly cost of the resources in the terraform state
+// of a cluster with the cost of its configuration
+type Estimate struct {
+	Rows []*Row
+
+	// resources without a price, they are not part of the estimate
+	Missing []string
+
+	prices  *PriceTable
+	missing map[string]bool
+}
+
+// New estimates the monthly cost of the current state and of the
+// configuration of a cluster. Spot instances are estimated at their maximum
+// spot price, if it is lower than the on-demand price.
+func New(cluster interfaces.Cluster, state *terraform.State, prices *PriceTable) *Estimate {
+	e := &Estimate{
+		prices:  prices,
+		missing: make(map[string]bool),
+	}
+
+	rows := make(map[string]*Row)
+	var names []string
+	row := func(tfName, name string) *Row {
+		if r, ok := rows[tfName]; ok {
+			return r
+		}
+		r := &Row{Name: name}
+		rows[tfName] = r
+		names = append(names, tfName)
+		return r
+	}
+
+	for _, instancePool := range cluster.InstancePools() {
+		e.plannedInstancePool(row(instancePool.TFName(), instancePool.Name()), instancePool)
+	}
+
+	e.current(state, row)
+
+	for _, name := range names {
+		e.Rows = append(e.Rows, rows[name])
+	}
+
+	e.Rows = append(e.Rows, e.loadBalancers(cluster, state), e.natGateways(cluster, state))
+
+	for resource := range e.missing {
+		e.Missing = append(e.Missing, resource)
+	}
+	sort.Strings(e.Missing)
+
+	return e
+}
+
+// Current returns the monthly cost of the resources in the state
+func (e *Estimate) Current() (total float64) {
+	for _, r := range e.Rows {
+		total += r.Current
+	}
+	return total
+}
+
+// Planned returns the monthly cost of the configuration with the minimum and
+// maximum instance counts
+func (e *Estimate) Planned() (min, max float64) {
+	for _, r := range e.Rows {
+		min += r.Planned
+		max += r.PlannedMax
+	}
+	return min, max
+}
+
+// Parameters returns a row per pool and a total for printing the estimate
+// as a table
+func (e *Estimate) Parameters() []map[string]string {
+	varMaps := make([]map[string]string, 0)
+	for _, r := range e.Rows {
+		if r.CurrentCount == 0 && r.PlannedMaxCount == 0 {
+			continue
+		}
+		varMaps = append(varMaps, map[string]string{
+			"pool":      r.Name,
+			"type":      change(r.CurrentType, r.PlannedType),
+			"instances": change(strconv.Itoa(r.CurrentCount), countRange(r.PlannedMinCount, r.PlannedMaxCount)),
+			"current":   dollars(r.Current),
+			"planned":   dollarsRange(r.Planned, r.PlannedMax),
+			"change":    difference(r.Planned - r.Current),
+		})
+	}
+
+	current := e.Current()
+	min, max := e.Planned()
+	varMaps = append(varMaps, map[string]string{
+		"pool":    "total",
+		"current": dollars(current),
+		"planned": dollarsRange(min, max),
+		"change":  difference(min - current),
+	})
+
+	return varMaps
+}
+
+func (e *Estimate) plannedInstancePool(r *Row, instancePool interfaces.InstancePool) {
+	r.PlannedType = instancePool.InstanceType()
+	r.PlannedMinCount = instancePool.MinCount()
+	r.PlannedMaxCount = instancePool.MaxCount()
+	if r.PlannedMaxCount < r.PlannedMinCount {
+		r.PlannedMaxCount = r.PlannedMinCount
+	}
+
+	perInstance := e.instanceHourly(instancePool.InstanceType(), instancePool.SpotPrice()) * HoursPerMonth
+	if rootVolume := instancePool.RootVolume(); rootVolume != nil {
+		perInstance += e.volumeMonthly(rootVolume.Type(), float64(rootVolume.Size()))
+	}
+	for _, volume := range instancePool.Volumes() {
+		perInstance += e.volumeMonthly(volume.Type(), float64(volume.Size()))
+	}
+
+	r.Planned = perInstance * float64(r.PlannedMinCount)
+	r.PlannedMax = perInstance * float64(r.PlannedMaxCount)
+}
+
+// current sums up the instances, launch configurations, auto scaling groups and
+// volumes in the state per instance pool, resources of removed instance pools
+// are listed on their own
+func (e *Estimate) current(state *terraform.State, row func(tfName, name string) *Row) {
+	resources := stateResources(state)
+
+	// resources are named after the instance pool, volumes are suffixed with
+	// the volume name
+	groups := make(map[string]bool)
+	for _, r := range resources {
+		switch r.Type {
+		case "aws_instance", "aws_launch_configuration", "aws_autoscaling_group":
+			groups[r.Name] = true
+		}
+	}
+
+	type poolState struct {
+		cost        float64
+		count       int
+		perInstance float64
+		scaled      int
+	}
+	pools := make(map[string]*poolState)
+	var order []string
+
+	for _, r := range resources {
+		switch r.Type {
+		case "aws_instance", "aws_launch_configuration", "aws_autoscaling_group", "aws_ebs_volume":
+		default:
+			continue
+		}
+
+		group := matchGroup(groups, r.Name)
+		p, ok := pools[group]
+		if !ok {
+			p = &poolState{}
+			pools[group] = p
+			order = append(order, group)
+		}
+		rw := row(group, group)
+
+		switch r.Type {
+		case "aws_instance":
+			rw.CurrentType = r.Attributes["instance_type"]
+			p.cost += e.instanceHourly(r.Attributes["instance_type"], "")*HoursPerMonth + e.blockDevicesMonthly(r.Attributes)
+			p.count++
+		case "aws_launch_configuration":
+			rw.CurrentType = r.Attributes["instance_type"]
+			p.perInstance = e.instanceHourly(r.Attributes["instance_type"], r.Attributes["spot_price"])*HoursPerMonth + e.blockDevicesMonthly(r.Attributes)
+		case "aws_autoscaling_group":
+			count, err := strconv.Atoi(r.Attributes["desired_capacity"])
+			if err != nil {
+				count, _ = strconv.Atoi(r.Attributes["min_size"])
+			}
+			p.scaled += count
+		case "aws_ebs_volume":
+			size, _ := strconv.ParseFloat(r.Attributes["size"], 64)
+			p.cost += e.volumeMonthly(r.Attributes["type"], size)
+		}
+	}
+
+	for _, group := range order {
+		p := pools[group]
+		rw := row(group, group)
+		rw.Current = p.cost + p.perInstance*float64(p.scaled)
+		rw.CurrentCount = p.count + p.scaled
+	}
+}
+
+func (e *Estimate) loadBalancers(cluster interfaces.Cluster, state *terraform.State) *Row {
+	r := &Row{Name: "load balancers"}
+
+	for _, resource := range stateResources(state) {
+		if resource.Type == "aws_elb" {
+			r.CurrentCount++
+		}
+	}
+
+	roles := make(map[string]bool)
+	for _, instancePool := range cluster.InstancePools() {
+		role := instancePool.Role()
+		if role == nil || role.AWS == nil || roles[role.TFName()] {
+			continue
+		}
+		roles[role.TFName()] = true
+
+		if role.AWS.ELBAPI {
+			r.PlannedMinCount++
+			if role.AWS.ELBAPIPublic {
+				r.PlannedMinCount++
+			}
+		} else if role.AWS.ELBIngress {
+			r.PlannedMinCount++
+		}
+	}
+	r.PlannedMaxCount = r.PlannedMinCount
+
+	r.Current = float64(r.CurrentCount) * e.prices.LoadBalancer * HoursPerMonth
+	r.Planned = float64(r.PlannedMinCount) * e.prices.LoadBalancer * HoursPerMonth
+	r.PlannedMax = r.Planned
+	return r
+}
+
+func (e *Estimate) natGateways(cluster interfaces.Cluster, state *terraform.State) *Row {
+	r := &Row{Name: "NAT gateways"}
+
+	for _, resource := range stateResources(state) {
+		if resource.Type == "aws_nat_gateway" {
+			r.CurrentCount++
+		}
+	}
+
+	// only clusters creating their own network have NAT gateways
+	if cluster.Type() != clusterv1alpha1.ClusterTypeClusterMulti {
+		if network := cluster.Config().Network; network == nil || network.ObjectMeta.Annotations[clusterv1alpha1.ExistingVPCAnnotationKey] == "" {
+			zones := make(map[string]bool)
+			for _, subnet := range cluster.Subnets() {
+				zones[subnet.Zone] = true
+			}
+			r.PlannedMinCount = len(zones)
+		}
+	}
+	r.PlannedMaxCount = r.PlannedMinCount
+
+	r.Current = float64(r.CurrentCount) * e.prices.NATGateway * HoursPerMonth
+	r.Planned = float64(r.PlannedMinCount) * e.prices.NATGateway * HoursPerMonth
+	r.PlannedMax = r.Planned
+	return r
+}
+
+func (e *Estimate) instanceHourly(instanceType, spotPrice string) float64 {
+	price, ok := e.prices.Instances[instanceType]
+	if !ok {
+		e.missing[fmt.Sprintf("instance type %s", instanceType)] = true
+	}
+
+	if spot, err := strconv.ParseFloat(spotPrice, 64); err == nil && spot > 0 {
+		if !ok {
+			return spot
+		}
+		return math.Min(price, spot)
+	}
+
+	return price
+}
+
+func (e *Estimate) volumeMonthly(volumeType string, size float64) float64 {
+	if volumeType == "" {
+		volumeType = "standard"
+	}
+
+	price, ok := e.prices.Volumes[volumeType]
+	if !ok {
+		e.missing[fmt.Sprintf("volume type %s", volumeType)] = true
+	}
+
+	return price * size
+}
+
+// sums up the root and EBS block devices of instances and launch
+// configurations, which are stored as flattened attributes
+func (e *Estimate) blockDevicesMonthly(attributes map[string]string) (total float64) {
+	for key, value := range attributes {
+		match := blockDeviceSizeKey.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		size, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		total += e.volumeMonthly(attributes[match[1]+".volume_type"], size)
+	}
+	return total
+}
+
+type stateResource struct {
+	Type       string
+	Name       string
+	Attributes map[string]string
+}
+
+// returns the managed resources of all modules, resources with a count are
+// returned once per index
+func stateResources(state *terraform.State) (resources []*stateResource) {
+	if state == nil {
+		return nil
+	}
+
+	for _, module := range state.Modules {
+		keys := make([]string, 0, len(module.Resources))
+		for key := range module.Resources {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			resource := module.Resources[key]
+			parts := strings.Split(key, ".")
+			if len(parts) < 2 || parts[0] == "data" || resource.Primary == nil {
+				continue
+			}
+			resources = append(resources, &stateResource{
+				Type:       parts[0],
+				Name:       parts[1],
+				Attributes: resource.Primary.Attributes,
+			})
+		}
+	}
+
+	return resources
+}
+
+// returns the longest group the name belongs to, names without a group are
+// their own group
+func matchGroup(groups map[string]bool, name string) string {
+	match := ""
+	for group := range groups {
+		if (name == group || strings.HasPrefix(name, group+"_")) && len(group) > len(match) {
+			match = group
+		}
+	}
+	if match == "" {
+		return name
+	}
+	return match
+}
+
+func change(current, planned string) string {
+	if current == planned || current == "" || current == "0" {
+		return planned
+	}
+	if planned == "" || planned == "0" {
+		return fmt.Sprintf("%s -> none", current)
+	}
+	return fmt.Sprintf("%s -> %s", current, planned)
+}
+
+func countRange(min, max int) string {
+	if max > min {
+		return fmt.Sprintf("%d-%d", min, max)
+	}
+	return strconv.Itoa(min)
+}
+
+func dollars(amount float64) string {
+	return fmt.Sprintf("$%.2f", amount)
+}
+
+func dollarsRange(min, max float64) string {
+	if max > min {
+		return fmt.Sprintf("%s-%s", dollars(min), dollars(max))
+	}
+	return dollars(min)
+}
+
+func difference(amount float64) string {
+	if amount < 0 {
+		return fmt.Sprintf("-%s", dollars(-amount))
+	}
+	return fmt.Sprintf("+%s", dollars(amount))
+}
@@ -0,0 +1,196 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cost
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/terraform"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
+	"github.com/jetstack/tarmak/pkg/tarmak/role"
+)
+
+var testPrices = &PriceTable{
+	Instances: map[string]float64{
+		"m4.large": 0.1,
+		"m5.large": 0.2,
+	},
+	Volumes: map[string]float64{
+		"gp2": 0.1,
+	},
+	LoadBalancer: 0.01,
+	NATGateway:   0.05,
+}
+
+func testState(resources map[string]map[string]string) *terraform.State {
+	module := &terraform.ModuleState{
+		Path:      []string{"root", "kubernetes"},
+		Resources: make(map[string]*terraform.ResourceState),
+	}
+	for key, attributes := range resources {
+		module.Resources[key] = &terraform.ResourceState{
+			Primary: &terraform.InstanceState{
+				ID:         key,
+				Attributes: attributes,
+			},
+		}
+	}
+	return &terraform.State{
+		Modules: []*terraform.ModuleState{module},
+	}
+}
+
+func testVolume(ctrl *gomock.Controller, volumeType string, size int) interfaces.Volume {
+	volume := mocks.NewMockVolume(ctrl)
+	volume.EXPECT().Type().AnyTimes().Return(volumeType)
+	volume.EXPECT().Size().AnyTimes().Return(size)
+	return volume
+}
+
+func testInstancePool(ctrl *gomock.Controller, r *role.Role, instanceType, spotPrice string, min, max int, volumes ...interfaces.Volume) interfaces.InstancePool {
+	instancePool := mocks.NewMockInstancePool(ctrl)
+	instancePool.EXPECT().Name().AnyTimes().Return(r.Name())
+	instancePool.EXPECT().TFName().AnyTimes().Return(r.TFName())
+	instancePool.EXPECT().Role().AnyTimes().Return(r)
+	instancePool.EXPECT().InstanceType().AnyTimes().Return(instanceType)
+	instancePool.EXPECT().SpotPrice().AnyTimes().Return(spotPrice)
+	instancePool.EXPECT().MinCount().AnyTimes().Return(min)
+	instancePool.EXPECT().MaxCount().AnyTimes().Return(max)
+	instancePool.EXPECT().RootVolume().AnyTimes().Return(testVolume(ctrl, "gp2", 16))
+	instancePool.EXPECT().Volumes().AnyTimes().Return(volumes)
+	return instancePool
+}
+
+func testCluster(ctrl *gomock.Controller) interfaces.Cluster {
+	worker := (&role.Role{AWS: &role.RoleAWS{ELBIngress: true}}).WithName("worker").WithPrefix("kubernetes")
+	etcd := (&role.Role{Stateful: true, AWS: &role.RoleAWS{}}).WithName("etcd").WithPrefix("kubernetes")
+
+	cluster := mocks.NewMockCluster(ctrl)
+	cluster.EXPECT().Type().AnyTimes().Return(clusterv1alpha1.ClusterTypeClusterSingle)
+	cluster.EXPECT().Config().AnyTimes().Return(&clusterv1alpha1.Cluster{})
+	cluster.EXPECT().Subnets().AnyTimes().Return([]clusterv1alpha1.Subnet{
+		{Zone: "eu-west-1a"},
+		{Zone: "eu-west-1b"},
+	})
+	cluster.EXPECT().InstancePools().AnyTimes().Return([]interfaces.InstancePool{
+		testInstancePool(ctrl, worker, "m5.large", "0.1", 3, 6),
+		testInstancePool(ctrl, etcd, "m4.large", "", 1, 1, testVolume(ctrl, "gp2", 10)),
+	})
+	return cluster
+}
+
+func assertCost(t *testing.T, name string, exp, act float64) {
+	if math.Abs(exp-act) > 0.001 {
+		t.Errorf("expected %s to be %.3f, got %.3f", name, exp, act)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	state := testState(map[string]map[string]string{
+		"aws_launch_configuration.kubernetes_worker": {
+			"instance_type":                   "m4.large",
+			"root_block_device.0.volume_size": "16",
+			"root_block_device.0.volume_type": "gp2",
+		},
+		"aws_autoscaling_group.kubernetes_worker": {"desired_capacity": "2", "min_size": "1"},
+		"aws_instance.kubernetes_etcd.0": {
+			"instance_type":                   "m4.large",
+			"root_block_device.0.volume_size": "16",
+			"root_block_device.0.volume_type": "gp2",
+		},
+		"aws_ebs_volume.kubernetes_etcd_data.0": {"size": "10", "type": "gp2"},
+		"aws_instance.kubernetes_jenkins":       {"instance_type": "x1.huge"},
+		"aws_elb.kubernetes_api":                {},
+		"aws_elb.kubernetes_ingress":            {},
+		"aws_nat_gateway.public.0":              {},
+		"data.aws_ami.centos":                   {},
+	})
+
+	e := New(testCluster(ctrl), state, testPrices)
+
+	rows := make(map[string]*Row)
+	for _, r := range e.Rows {
+		rows[r.Name] = r
+	}
+
+	worker := rows["worker"]
+	if worker == nil {
+		t.Fatal("expected a row for the worker pool")
+	}
+	// spot price is lower than on-demand: 0.1 * 730 + 16 * 0.1
+	assertCost(t, "current worker cost", 2*74.6, worker.Current)
+	assertCost(t, "planned worker cost", 3*74.6, worker.Planned)
+	assertCost(t, "planned max worker cost", 6*74.6, worker.PlannedMax)
+	if exp, act := "m4.large -> m5.large", change(worker.CurrentType, worker.PlannedType); exp != act {
+		t.Errorf("expected type %s, got %s", exp, act)
+	}
+
+	etcd := rows["etcd"]
+	if etcd == nil {
+		t.Fatal("expected a row for the etcd pool")
+	}
+	assertCost(t, "current etcd cost", 75.6, etcd.Current)
+	assertCost(t, "planned etcd cost", 75.6, etcd.Planned)
+	if exp, act := 1, etcd.CurrentCount; exp != act {
+		t.Errorf("expected %d current etcd instances, got %d", exp, act)
+	}
+
+	jenkins := rows["kubernetes_jenkins"]
+	if jenkins == nil {
+		t.Fatal("expected a row for the removed jenkins instance")
+	}
+	if exp, act := 0, jenkins.PlannedMaxCount; exp != act {
+		t.Errorf("expected %d planned jenkins instances, got %d", exp, act)
+	}
+
+	assertCost(t, "current load balancer cost", 14.6, rows["load balancers"].Current)
+	assertCost(t, "planned load balancer cost", 7.3, rows["load balancers"].Planned)
+	assertCost(t, "current NAT gateway cost", 36.5, rows["NAT gateways"].Current)
+	assertCost(t, "planned NAT gateway cost", 73, rows["NAT gateways"].Planned)
+
+	min, max := e.Planned()
+	assertCost(t, "current total", 275.9, e.Current())
+	assertCost(t, "planned total", 379.7, min)
+	assertCost(t, "planned max total", 603.5, max)
+
+	if exp, act := []string{"instance type x1.huge"}, e.Missing; !reflect.DeepEqual(exp, act) {
+		t.Errorf("expected missing prices %v, got %v", exp, act)
+	}
+}
+
+func TestEstimate_Parameters(t *testing.T) {
+	e := &Estimate{
+		Rows: []*Row{
+			{Name: "worker", CurrentType: "m4.large", PlannedType: "m5.large", CurrentCount: 2, PlannedMinCount: 3, PlannedMaxCount: 6, Current: 100, Planned: 150, PlannedMax: 300},
+			{Name: "NAT gateways"},
+		},
+	}
+
+	exp := []map[string]string{
+		{
+			"pool":      "worker",
+			"type":      "m4.large -> m5.large",
+			"instances": "2 -> 3-6",
+			"current":   "$100.00",
+			"planned":   "$150.00-$300.00",
+			"change":    "+$50.00",
+		},
+		{
+			"pool":    "total",
+			"current": "$100.00",
+			"planned": "$150.00-$300.00",
+			"change":  "+$50.00",
+		},
+	}
+	if act := e.Parameters(); !reflect.DeepEqual(exp, act) {
+		t.Errorf("expected %+v, got %+v", exp, act)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cost
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// HoursPerMonth is used to convert hourly into monthly prices
+const HoursPerMonth = 730
+
+// DefaultRegion is used for regions without prices
+const DefaultRegion = "us-east-1"
+
+// PriceTable holds the prices of a single region in US dollars
+type PriceTable struct {
+	Instances    map[string]float64 `json:"instances,omitempty"`    // hourly on-demand price per instance type
+	Volumes      map[string]float64 `json:"volumes,omitempty"`      // monthly price per GB per volume type
+	LoadBalancer float64            `json:"loadBalancer,omitempty"` // hourly price per classic load balancer
+	NATGateway   float64            `json:"natGateway,omitempty"`   // hourly price per NAT gateway
+}
+
+// Prices holds the price tables per region
+type Prices map[string]*PriceTable
+
+// LoadPrices returns the bundled prices, overridden by the prices in the
+// given file if it exists. The file uses the same format as the bundled
+// prices, entries missing in it keep their bundled price.
+func LoadPrices(path string) (Prices, error) {
+	prices := BundledPrices()
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return prices, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading prices '%s': %s", path, err)
+	}
+
+	override := Prices{}
+	if err := json.Unmarshal(data, &override); err != nil {
+		return nil, fmt.Errorf("error parsing prices '%s': %s", path, err)
+	}
+
+	prices.merge(override)
+	return prices, nil
+}
+
+// Region returns the price table of a region, falling back to the default
+// region if there are no prices for it
+func (p Prices) Region(region string) (table *PriceTable, fallback bool) {
+	if table, ok := p[region]; ok {
+		return table, false
+	}
+	return p[DefaultRegion], true
+}
+
+func (p Prices) merge(override Prices) {
+	for region, table := range override {
+		// regions set to null have no prices to override
+		if table == nil {
+			continue
+		}
+
+		existing, ok := p[region]
+		if !ok || existing == nil {
+			p[region] = table
+			continue
+		}
+
+		if existing.Instances == nil {
+			existing.Instances = map[string]float64{}
+		}
+		if existing.Volumes == nil {
+			existing.Volumes = map[string]float64{}
+		}
+		for instanceType, price := range table.Instances {
+			existing.Instances[instanceType] = price
+		}
+		for volumeType, price := range table.Volumes {
+			existing.Volumes[volumeType] = price
+		}
+		if table.LoadBalancer != 0 {
+			existing.LoadBalancer = table.LoadBalancer
+		}
+		if table.NATGateway != 0 {
+			existing.NATGateway = table.NATGateway
+		}
+	}
+}
+
+// BundledPrices returns the Linux on-demand prices of the instance types
+// tarmak uses by default and of common general purpose, compute and memory
+// optimised types. These are only updated with tarmak releases, so they are
+// meant for rough estimates.
+func BundledPrices() Prices {
+	return Prices{
+		"us-east-1": &PriceTable{
+			Instances: map[string]float64{
+				"t2.nano":     0.0058,
+				"t2.micro":    0.0116,
+				"t2.small":    0.023,
+				"t2.medium":   0.0464,
+				"t2.large":    0.0928,
+				"t2.xlarge":   0.1856,
+				"t2.2xlarge":  0.3712,
+				"m4.large":    0.10,
+				"m4.xlarge":   0.20,
+				"m4.2xlarge":  0.40,
+				"m4.4xlarge":  0.80,
+				"m4.10xlarge": 2.00,
+				"m4.16xlarge": 3.20,
+				"m5.large":    0.096,
+				"m5.xlarge":   0.192,
+				"m5.2xlarge":  0.384,
+				"m5.4xlarge":  0.768,
+				"m5.12xlarge": 2.304,
+				"m5.24xlarge": 4.608,
+				"c4.large":    0.10,
+				"c4.xlarge":   0.199,
+				"c4.2xlarge":  0.398,
+				"c4.4xlarge":  0.796,
+				"c4.8xlarge":  1.591,
+				"c5.large":    0.085,
+				"c5.xlarge":   0.17,
+				"c5.2xlarge":  0.34,
+				"c5.4xlarge":  0.68,
+				"c5.9xlarge":  1.53,
+				"c5.18xlarge": 3.06,
+				"r4.large":    0.133,
+				"r4.xlarge":   0.266,
+				"r4.2xlarge":  0.532,
+				"r4.4xlarge":  1.064,
+				"r4.8xlarge":  2.128,
+				"r4.16xlarge": 4.256,
+			},
+			Volumes: map[string]float64{
+				"gp2":      0.10,
+				"io1":      0.125,
+				"st1":      0.045,
+				"sc1":      0.025,
+				"standard": 0.05,
+			},
+			LoadBalancer: 0.025,
+			NATGateway:   0.045,
+		},
+		"eu-west-1": &PriceTable{
+			Instances: map[string]float64{
+				"t2.nano":     0.0063,
+				"t2.micro":    0.0126,
+				"t2.small":    0.025,
+				"t2.medium":   0.05,
+				"t2.large":    0.101,
+				"t2.xlarge":   0.202,
+				"t2.2xlarge":  0.404,
+				"m4.large":    0.111,
+				"m4.xlarge":   0.222,
+				"m4.2xlarge":  0.444,
+				"m4.4xlarge":  0.888,
+				"m4.10xlarge": 2.22,
+				"m4.16xlarge": 3.552,
+				"m5.large":    0.107,
+				"m5.xlarge":   0.214,
+				"m5.2xlarge":  0.428,
+				"m5.4xlarge":  0.856,
+				"m5.12xlarge": 2.568,
+				"m5.24xlarge": 5.136,
+				"c4.large":    0.113,
+				"c4.xlarge":   0.226,
+				"c4.2xlarge":  0.453,
+				"c4.4xlarge":  0.905,
+				"c4.8xlarge":  1.811,
+				"c5.large":    0.096,
+				"c5.xlarge":   0.192,
+				"c5.2xlarge":  0.384,
+				"c5.4xlarge":  0.768,
+				"c5.9xlarge":  1.728,
+				"c5.18xlarge": 3.456,
+				"r4.large":    0.148,
+				"r4.xlarge":   0.296,
+				"r4.2xlarge":  0.593,
+				"r4.4xlarge":  1.186,
+				"r4.8xlarge":  2.371,
+				"r4.16xlarge": 4.742,
+			},
+			Volumes: map[string]float64{
+				"gp2":      0.11,
+				"io1":      0.138,
+				"st1":      0.05,
+				"sc1":      0.028,
+				"standard": 0.055,
+			},
+			LoadBalancer: 0.028,
+			NATGateway:   0.048,
+		},
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package cost
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPrices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tarmak-prices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "prices.json")
+	if err := ioutil.WriteFile(path, []byte(`{
+  "us-east-1": {"instances": {"m5.large": 0.09}, "natGateway": 0.04},
+  "ap-south-1": {"instances": {"m5.large": 0.101}}
+}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	prices, err := LoadPrices(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	table, fallback := prices.Region("us-east-1")
+	if fallback {
+		t.Error("unexpected fallback for us-east-1")
+	}
+	if exp, act := 0.09, table.Instances["m5.large"]; exp != act {
+		t.Errorf("expected overridden price %v, got %v", exp, act)
+	}
+	if exp, act := BundledPrices()["us-east-1"].Instances["m4.large"], table.Instances["m4.large"]; exp != act {
+		t.Errorf("expected bundled price %v, got %v", exp, act)
+	}
+	if exp, act := 0.04, table.NATGateway; exp != act {
+		t.Errorf("expected overridden NAT gateway price %v, got %v", exp, act)
+	}
+
+	if _, fallback := prices.Region("ap-south-1"); fallback {
+		t.Error("unexpected fallback for ap-south-1")
+	}
+	if _, fallback := prices.Region("sa-east-1"); !fallback {
+		t.Error("expected fallback for sa-east-1")
+	}
+}
+
+func TestLoadPrices_Missing(t *testing.T) {
+	prices, err := LoadPrices(filepath.Join(os.TempDir(), "tarmak-prices-does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := prices[DefaultRegion]; !ok {
+		t.Errorf("expected bundled prices for %s", DefaultRegion)
+	}
+}
+
+func TestPrices_merge_Empty(t *testing.T) {
+	prices := Prices{
+		"us-east-1": &PriceTable{LoadBalancer: 0.025},
+		"eu-west-1": BundledPrices()["eu-west-1"],
+	}
+
+	prices.merge(Prices{
+		"us-east-1": &PriceTable{
+			Instances: map[string]float64{"m5.large": 0.09},
+			Volumes:   map[string]float64{"gp2": 0.1},
+		},
+		"eu-west-1":  nil,
+		"ap-south-1": nil,
+	})
+
+	if exp, act := 0.09, prices["us-east-1"].Instances["m5.large"]; exp != act {
+		t.Errorf("expected overridden price %v, got %v", exp, act)
+	}
+	if exp, act := 0.1, prices["us-east-1"].Volumes["gp2"]; exp != act {
+		t.Errorf("expected overridden volume price %v, got %v", exp, act)
+	}
+	if prices["eu-west-1"] == nil {
+		t.Error("expected bundled prices for eu-west-1 to be kept")
+	}
+	if _, ok := prices["ap-south-1"]; ok {
+		t.Error("unexpected prices for ap-south-1")
+	}
+}
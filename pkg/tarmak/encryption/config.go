@@ -0,0 +1,163 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	// name and socket of the aws-encryption-provider KMS plugin, these need
+	// to match the puppet kubernetes::kms_plugin class
+	KMSPluginName     = "aws-encryption-provider"
+	KMSPluginEndpoint = "unix:///var/run/kmsplugin/socket.sock"
+
+	keyNamePrefix = "key"
+	keySize       = 32
+)
+
+// EncryptionConfig as consumed by the API server's
+// --encryption-provider-config flag
+type Config struct {
+	Kind       string           `yaml:"kind"`
+	APIVersion string           `yaml:"apiVersion"`
+	Resources  []ResourceConfig `yaml:"resources"`
+}
+
+type ResourceConfig struct {
+	Resources []string         `yaml:"resources"`
+	Providers []ProviderConfig `yaml:"providers"`
+}
+
+type ProviderConfig struct {
+	AESCBC   *AESConfig      `yaml:"aescbc,omitempty"`
+	KMS      *KMSConfig      `yaml:"kms,omitempty"`
+	Identity *IdentityConfig `yaml:"identity,omitempty"`
+}
+
+type AESConfig struct {
+	Keys []Key `yaml:"keys"`
+}
+
+type Key struct {
+	Name   string `yaml:"name"`
+	Secret string `yaml:"secret"`
+}
+
+type KMSConfig struct {
+	Name      string `yaml:"name"`
+	Endpoint  string `yaml:"endpoint"`
+	CacheSize int    `yaml:"cachesize,omitempty"`
+	Timeout   string `yaml:"timeout,omitempty"`
+}
+
+type IdentityConfig struct{}
+
+func ParseConfig(content string) (*Config, error) {
+	c := &Config{}
+	if err := yaml.Unmarshal([]byte(content), c); err != nil {
+		return nil, fmt.Errorf("failed to parse encryption config: %s", err)
+	}
+	return c, nil
+}
+
+func (c *Config) Marshal() (string, error) {
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal encryption config: %s", err)
+	}
+	return string(out), nil
+}
+
+// return all aescbc keys in the config, ordered by first appearance
+func (c *Config) AESCBCKeys() []Key {
+	var keys []Key
+	seen := map[string]bool{}
+
+	for _, r := range c.Resources {
+		for _, p := range r.Providers {
+			if p.AESCBC == nil {
+				continue
+			}
+			for _, k := range p.AESCBC.Keys {
+				if seen[k.Name] {
+					continue
+				}
+				seen[k.Name] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	return keys
+}
+
+// build the config for the cluster's encryption spec, aescbc keys are kept so
+// existing data can still be decrypted
+func DesiredConfig(spec *clusterv1alpha1.ClusterKubernetesAPIServerEncryption, keys []Key) *Config {
+	if spec == nil {
+		spec = &clusterv1alpha1.ClusterKubernetesAPIServerEncryption{}
+		clusterv1alpha1.SetDefaults_ClusterKubernetesAPIServerEncryption(spec)
+	}
+
+	var providers []ProviderConfig
+
+	if spec.Provider == clusterv1alpha1.EncryptionProviderKMS {
+		kms := &KMSConfig{
+			Name:     KMSPluginName,
+			Endpoint: KMSPluginEndpoint,
+		}
+		if k := spec.KMS; k != nil {
+			if k.CacheSize != nil {
+				kms.CacheSize = *k.CacheSize
+			}
+			kms.Timeout = k.Timeout
+		}
+		providers = append(providers, ProviderConfig{KMS: kms})
+	}
+
+	if len(keys) > 0 {
+		providers = append(providers, ProviderConfig{AESCBC: &AESConfig{Keys: keys}})
+	}
+
+	providers = append(providers, ProviderConfig{Identity: &IdentityConfig{}})
+
+	return &Config{
+		Kind:       "EncryptionConfig",
+		APIVersion: "v1",
+		Resources: []ResourceConfig{
+			{
+				Resources: spec.Resources,
+				Providers: providers,
+			},
+		},
+	}
+}
+
+// generate a new random aescbc key, named after the highest existing key
+func NewKey(existing []Key) (Key, error) {
+	max := 0
+	for _, k := range existing {
+		n, err := strconv.Atoi(strings.TrimPrefix(k.Name, keyNamePrefix))
+		if err == nil && n > max {
+			max = n
+		}
+	}
+
+	secret := make([]byte, keySize)
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, fmt.Errorf("error generating encryption key: %s", err)
+	}
+
+	return Key{
+		Name:   fmt.Sprintf("%s%d", keyNamePrefix, max+1),
+		Secret: base64.StdEncoding.EncodeToString(secret),
+	}, nil
+}
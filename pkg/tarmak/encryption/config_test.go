@@ -0,0 +1,98 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package encryption
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+)
+
+// default config written by vault-helper
+const vaultHelperConfig = `kind: EncryptionConfig
+apiVersion: v1
+resources:
+  - resources:
+    - secrets
+    - configmaps
+    providers:
+    - aescbc:
+        keys:
+        - name: key1
+          secret: c2VjcmV0
+    - identity: {}
+`
+
+func TestConfig_DefaultUnchanged(t *testing.T) {
+	current, err := ParseConfig(vaultHelperConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	keys := current.AESCBCKeys()
+	if len(keys) != 1 || keys[0].Name != "key1" {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	currentContent, err := current.Marshal()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	desiredContent, err := DesiredConfig(nil, keys).Marshal()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if currentContent != desiredContent {
+		t.Errorf("expected default config to be unchanged, got:\n%s\nexpected:\n%s", desiredContent, currentContent)
+	}
+}
+
+func TestConfig_KMS(t *testing.T) {
+	cacheSize := 500
+	spec := &clusterv1alpha1.ClusterKubernetesAPIServerEncryption{
+		Provider:  clusterv1alpha1.EncryptionProviderKMS,
+		Resources: []string{"secrets"},
+		KMS: &clusterv1alpha1.ClusterKubernetesAPIServerEncryptionKMS{
+			KeyARN:    "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			CacheSize: &cacheSize,
+			Timeout:   "3s",
+		},
+	}
+
+	c := DesiredConfig(spec, []Key{{Name: "key1", Secret: "c2VjcmV0"}})
+	providers := c.Resources[0].Providers
+	if len(providers) != 3 {
+		t.Fatalf("expected kms, aescbc and identity providers, got %+v", providers)
+	}
+
+	if kms := providers[0].KMS; kms == nil || kms.Endpoint != KMSPluginEndpoint || kms.CacheSize != 500 {
+		t.Errorf("unexpected first provider: %+v", providers[0])
+	}
+	if providers[1].AESCBC == nil {
+		t.Errorf("expected existing aescbc keys to be kept for reading")
+	}
+	if providers[2].Identity == nil {
+		t.Errorf("expected identity provider last")
+	}
+
+	c = DesiredConfig(spec, nil)
+	if len(c.Resources[0].Providers) != 2 {
+		t.Errorf("expected no aescbc provider without keys, got %+v", c.Resources[0].Providers)
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	key, err := NewKey([]Key{{Name: "key1"}, {Name: "key3"}, {Name: "custom"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if key.Name != "key4" {
+		t.Errorf("unexpected key name: %s", key.Name)
+	}
+
+	if len(key.Secret) != 44 {
+		t.Errorf("expected base64 encoded 32 byte secret, got %s", key.Secret)
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package encryption
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/go-multierror"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+)
+
+const (
+	// systemd unit fetching the encryption config from vault on the masters
+	encryptionConfigService = "kube-encryption-config-file-secret.service"
+	apiServerService        = "kube-apiserver.service"
+
+	apiServerRetries = 60
+)
+
+type Encryption struct {
+	tarmak interfaces.Tarmak
+	log    *logrus.Entry
+}
+
+func New(tarmak interfaces.Tarmak) *Encryption {
+	return &Encryption{
+		tarmak: tarmak,
+		log:    tarmak.Log().WithField("module", "encryption"),
+	}
+}
+
+// path of the encryption config in vault
+func VaultPath(clusterName string) string {
+	return fmt.Sprintf("%s/secrets/encryption-config", clusterName)
+}
+
+func spec(cluster interfaces.Cluster) *clusterv1alpha1.ClusterKubernetesAPIServerEncryption {
+	if k := cluster.Config().Kubernetes; k != nil && k.APIServer != nil && k.APIServer.Encryption != nil {
+		return k.APIServer.Encryption
+	}
+
+	s := &clusterv1alpha1.ClusterKubernetesAPIServerEncryption{}
+	clusterv1alpha1.SetDefaults_ClusterKubernetesAPIServerEncryption(s)
+	return s
+}
+
+func readConfig(v *vault.Client, clusterName string) (*Config, error) {
+	path := VaultPath(clusterName)
+
+	secret, err := v.Logical().Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading encryption config %s: %s", path, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("encryption config %s does not exist", path)
+	}
+
+	content, ok := secret.Data["content"].(string)
+	if !ok {
+		return nil, fmt.Errorf("encryption config %s has no content", path)
+	}
+
+	return ParseConfig(content)
+}
+
+func writeConfig(v *vault.Client, clusterName string, c *Config) error {
+	content, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+
+	path := VaultPath(clusterName)
+	if _, err := v.Logical().Write(path, map[string]interface{}{
+		"content": content,
+	}); err != nil {
+		return fmt.Errorf("error writing encryption config %s: %s", path, err)
+	}
+
+	return nil
+}
+
+// Ensure makes sure the encryption config in vault matches the provider and
+// resources of the cluster, while keeping all existing aescbc keys
+func Ensure(v *vault.Client, cluster interfaces.Cluster) (changed bool, err error) {
+	current, err := readConfig(v, cluster.ClusterName())
+	if err != nil {
+		return false, err
+	}
+
+	s := spec(cluster)
+	keys := current.AESCBCKeys()
+	if len(keys) == 0 && s.Provider == clusterv1alpha1.EncryptionProviderAESCBC {
+		key, err := NewKey(nil)
+		if err != nil {
+			return false, err
+		}
+		keys = []Key{key}
+	}
+
+	currentContent, err := current.Marshal()
+	if err != nil {
+		return false, err
+	}
+
+	desired := DesiredConfig(s, keys)
+	desiredContent, err := desired.Marshal()
+	if err != nil {
+		return false, err
+	}
+
+	if currentContent == desiredContent {
+		return false, nil
+	}
+
+	return true, writeConfig(v, cluster.ClusterName(), desired)
+}
+
+// RotateKey introduces a new encryption key on all masters, re-encrypts all
+// configured resources and finally removes the old keys
+func (e *Encryption) RotateKey(kubeconfigPath string) error {
+	cluster := e.tarmak.Cluster()
+	clusterName := cluster.ClusterName()
+	s := spec(cluster)
+
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig %s: %s", kubeconfigPath, err)
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	masters, err := e.masterAliases()
+	if err != nil {
+		return err
+	}
+
+	vaultTunnel, err := e.vaultTunnel()
+	if err != nil {
+		return err
+	}
+	defer vaultTunnel.Stop()
+	v := vaultTunnel.VaultClient()
+
+	current, err := readConfig(v, clusterName)
+	if err != nil {
+		return err
+	}
+	keys := current.AESCBCKeys()
+
+	var stages []*Config
+	var final *Config
+
+	switch s.Provider {
+	case clusterv1alpha1.EncryptionProviderAESCBC:
+		key, err := NewKey(keys)
+		if err != nil {
+			return err
+		}
+		e.log.Infof("rotating to new encryption key %s", key.Name)
+
+		// all API servers need to be able to read with the new key, before
+		// any of them writes with it
+		known := append([]Key{}, keys...)
+		if len(known) == 0 {
+			known = []Key{key}
+		} else {
+			known = append(known[:1], append([]Key{key}, known[1:]...)...)
+		}
+		stages = append(stages, DesiredConfig(s, known))
+		stages = append(stages, DesiredConfig(s, append([]Key{key}, keys...)))
+		final = DesiredConfig(s, []Key{key})
+
+	case clusterv1alpha1.EncryptionProviderKMS:
+		e.log.Infof("rotating to new data encryption keys from KMS key %s", s.KMS.KeyARN)
+		stages = append(stages, DesiredConfig(s, keys))
+		final = DesiredConfig(s, nil)
+
+	default:
+		return fmt.Errorf("unsupported encryption provider: %s", s.Provider)
+	}
+
+	for _, c := range stages {
+		if err := e.applyConfig(v, clientset, masters, c); err != nil {
+			return err
+		}
+	}
+
+	if err := e.reencrypt(clientset, s.Resources); err != nil {
+		return fmt.Errorf("failed to re-encrypt resources, old encryption keys have been kept: %s", err)
+	}
+
+	e.log.Info("removing old encryption keys")
+	if err := e.applyConfig(v, clientset, masters, final); err != nil {
+		return err
+	}
+
+	e.log.Info("encryption key rotation completed")
+
+	return nil
+}
+
+// write config to vault and reload it on every master, one at a time
+func (e *Encryption) applyConfig(v *vault.Client, clientset kubernetes.Interface, masters []string, c *Config) error {
+	if err := writeConfig(v, e.tarmak.Cluster().ClusterName(), c); err != nil {
+		return err
+	}
+
+	for _, master := range masters {
+		e.log.Infof("reloading encryption config on %s", master)
+
+		for _, cmd := range [][]string{
+			{"sudo", "systemctl", "restart", encryptionConfigService},
+			{"sudo", "systemctl", "restart", apiServerService},
+		} {
+			ret, err := e.tarmak.SSH().Execute(master, cmd, nil, nil, nil)
+			if err != nil {
+				return fmt.Errorf("failed to run %v on %s: %s", cmd, master, err)
+			}
+			if ret != 0 {
+				return fmt.Errorf("command %v on %s returned non-zero: %d", cmd, master, ret)
+			}
+		}
+
+		if err := e.waitForAPIServer(clientset); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Encryption) waitForAPIServer(clientset kubernetes.Interface) error {
+	apiServerReady := func() error {
+		_, err := clientset.Discovery().ServerVersion()
+		if err != nil {
+			e.log.Debugf("waiting for API server: %s", err)
+		}
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	ctx := e.tarmak.CancellationContext().TryOrCancel(done)
+	b := backoff.WithContext(
+		backoff.WithMaxTries(backoff.NewConstantBackOff(time.Second*5), apiServerRetries),
+		ctx)
+
+	if err := backoff.Retry(apiServerReady, b); err != nil {
+		return fmt.Errorf("API server did not become ready: %s", err)
+	}
+
+	return nil
+}
+
+// rewrite all objects of the resources, so they get stored using the
+// current write key
+func (e *Encryption) reencrypt(clientset kubernetes.Interface, resources []string) error {
+	var result *multierror.Error
+
+	for _, resource := range resources {
+		e.log.Infof("re-encrypting all %s", resource)
+
+		switch resource {
+		case "secrets":
+			list, err := clientset.CoreV1().Secrets(metav1.NamespaceAll).List(metav1.ListOptions{})
+			if err != nil {
+				result = multierror.Append(result, err)
+				continue
+			}
+			for pos := range list.Items {
+				item := &list.Items[pos]
+				if _, err := clientset.CoreV1().Secrets(item.Namespace).Update(item); err != nil {
+					result = multierror.Append(result, fmt.Errorf("failed to update secret %s/%s: %s", item.Namespace, item.Name, err))
+				}
+			}
+
+		case "configmaps":
+			list, err := clientset.CoreV1().ConfigMaps(metav1.NamespaceAll).List(metav1.ListOptions{})
+			if err != nil {
+				result = multierror.Append(result, err)
+				continue
+			}
+			for pos := range list.Items {
+				item := &list.Items[pos]
+				if _, err := clientset.CoreV1().ConfigMaps(item.Namespace).Update(item); err != nil {
+					result = multierror.Append(result, fmt.Errorf("failed to update configmap %s/%s: %s", item.Namespace, item.Name, err))
+				}
+			}
+
+		default:
+			result = multierror.Append(result, fmt.Errorf("re-encrypting resource %s is not supported", resource))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// return the ssh aliases of all kubernetes masters of the current cluster
+func (e *Encryption) masterAliases() ([]string, error) {
+	hosts, err := e.tarmak.Cluster().ListHosts()
+	if err != nil {
+		return nil, err
+	}
+
+	var aliases []string
+	for _, h := range hosts {
+		if !utils.SliceContains(h.Roles(), "master") && !utils.SliceContains(h.Roles(), "etcd-master") {
+			continue
+		}
+		if len(h.Aliases()) == 0 {
+			return nil, fmt.Errorf("master host found without alias: %s", h.ID())
+		}
+		aliases = append(aliases, h.Aliases()[0])
+	}
+
+	if len(aliases) == 0 {
+		return nil, errors.New("no masters found in the current cluster")
+	}
+
+	return aliases, nil
+}
+
+func (e *Encryption) vaultTunnel() (interfaces.VaultTunnel, error) {
+	vault := e.tarmak.Environment().Vault()
+
+	vaultRootToken, err := vault.RootToken()
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := e.tarmak.Environment().Hub().TerraformOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	interfaceInstanceFQDNs, ok := outputs["instance_fqdns"].([]interface{})
+	if !ok {
+		return nil, errors.New("hub has no vault instance_fqdns output")
+	}
+	instanceFQDNs := make([]string, len(interfaceInstanceFQDNs))
+	for i := range interfaceInstanceFQDNs {
+		instanceFQDNs[i] = interfaceInstanceFQDNs[i].(string)
+	}
+
+	vaultCA, ok := outputs["vault_ca"].(string)
+	if !ok {
+		return nil, errors.New("hub has no vault_ca output")
+	}
+
+	vaultTunnel, err := vault.TunnelFromFQDNs(instanceFQDNs, vaultCA)
+	if err != nil {
+		return nil, err
+	}
+	vaultTunnel.VaultClient().SetToken(vaultRootToken)
+
+	return vaultTunnel, nil
+}
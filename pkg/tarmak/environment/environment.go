@@ -139,6 +139,11 @@ func (e *Environment) Variables() map[string]interface{} {
 	output["state_cluster_name"] = e.HubCluster.Name()
 	output["tools_cluster_name"] = e.HubCluster.Name()
 	output["vault_cluster_name"] = e.HubCluster.Name()
+
+	// clusters in another region peer their network with the hub's
+	if network := e.HubCluster.Config().Network; network != nil {
+		output["hub_network"] = network.CIDR
+	}
 	return output
 }
 
@@ -0,0 +1,346 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/kardianos/osext"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
+)
+
+// EnvironmentApply applies the hub and then all other clusters of the
+// environment. The planned changes of all clusters are shown first and
+// approved once, they need to be approved again if applying the hub has
+// changed the plans of the other clusters.
+func (c *CmdTarmak) EnvironmentApply() (err error) {
+	flags := c.flags.Environment.Apply
+	if flags.Concurrency < 1 {
+		return fmt.Errorf("concurrency has to be at least 1, got %d", flags.Concurrency)
+	}
+
+	if len(c.args) > 0 {
+		c.Tarmak.environment, err = c.Tarmak.EnvironmentByName(c.args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	hub := c.Environment().Hub()
+	if hub == nil {
+		return fmt.Errorf("environment '%s' has no hub cluster", c.Environment().Name())
+	}
+	var members []interfaces.Cluster
+	for _, cluster := range c.Environment().Clusters() {
+		if cluster.Name() != hub.Name() {
+			members = append(members, cluster)
+		}
+	}
+
+	// plan all clusters for a single approval
+	hubReport := c.planClusterChanges(hub)
+	if hubReport.Error != "" {
+		return fmt.Errorf("failed to plan hub %s: %s", hub.ClusterName(), hubReport.Error)
+	}
+	hubChanged := len(hubReport.Changes) > 0
+
+	// clusters can depend on hub changes, like when the hub has never been
+	// applied, so they are only required to plan if the hub is unchanged
+	memberReports, changedMembers, err := c.planMembers(members, !hubChanged)
+	if err != nil {
+		return err
+	}
+
+	count := len(changedMembers)
+	if hubChanged {
+		count++
+	}
+	if err := c.approveEnvironmentChanges(append([]*clusterDrift{hubReport}, memberReports...), count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+
+	// clusters are applied like 'tarmak cluster apply', replanning them as
+	// the hub might have changed in between
+	applyFlags := &c.flags.Cluster.Apply
+	applyFlags.AutoApprove = true
+	applyFlags.AutoApproveDeletingData = flags.AutoApproveDeletingData
+	applyFlags.WaitForConvergence = flags.WaitForConvergence
+	applyFlags.PlanFileLocation = consts.DefaultPlanLocationPlaceholder
+	c.flags.Cluster.Plan.PlanFileStore = consts.DefaultPlanLocationPlaceholder
+
+	if hubChanged {
+		c.log.Infof("applying hub %s", hub.ClusterName())
+		if err := c.applyCluster(hub); err != nil {
+			return fmt.Errorf("failed to apply hub %s, not applying other clusters: %s", hub.ClusterName(), err)
+		}
+
+		// the hub's changes can change the plans of the other clusters, or
+		// make clusters plannable for the first time. These need to be
+		// approved again.
+		replanned, replannedMembers, err := c.planMembers(members, true)
+		if err != nil {
+			return err
+		}
+		if !sameDrift(memberReports, replanned) {
+			c.log.Infof("plans of the clusters have changed after applying hub %s", hub.ClusterName())
+			if err := c.approveEnvironmentChanges(replanned, len(replannedMembers)); err != nil {
+				return err
+			}
+		}
+		changedMembers = replannedMembers
+	}
+
+	apply := c.applyCluster
+	if flags.Concurrency > 1 {
+		apply = c.applyClusterProcess
+	}
+	failed, skipped := c.applyClusters(changedMembers, flags.Concurrency, flags.ContinueOnFailure, apply)
+
+	var result []string
+	for _, name := range sortedKeys(failed) {
+		c.log.Errorf("failed to apply cluster %s: %s", name, failed[name])
+		result = append(result, fmt.Sprintf("%s failed", name))
+	}
+	for _, name := range skipped {
+		result = append(result, fmt.Sprintf("%s skipped", name))
+	}
+	if len(result) > 0 {
+		return fmt.Errorf("not all clusters of environment %s have been applied: %s", c.Environment().Name(), strings.Join(result, ", "))
+	}
+
+	return nil
+}
+
+// plan the given clusters and return their reports together with the clusters
+// that have changes. A cluster that fails to plan is an error if required,
+// otherwise it is reported as changed, to be planned again later.
+func (c *CmdTarmak) planMembers(clusters []interfaces.Cluster, required bool) ([]*clusterDrift, []interfaces.Cluster, error) {
+	var reports []*clusterDrift
+	var changed []interfaces.Cluster
+	for _, cluster := range clusters {
+		report := c.planClusterChanges(cluster)
+		reports = append(reports, report)
+
+		if report.Error != "" {
+			if required {
+				return nil, nil, fmt.Errorf("failed to plan cluster %s: %s", cluster.ClusterName(), report.Error)
+			}
+			report.Error = fmt.Sprintf("will be planned after the hub has been applied: %s", report.Error)
+		} else if len(report.Changes) == 0 {
+			continue
+		}
+		changed = append(changed, cluster)
+
+		select {
+		case <-c.ctx.Done():
+			return nil, nil, c.ctx.Err()
+		default:
+		}
+	}
+
+	return reports, changed, nil
+}
+
+// show the planned changes and ask to apply count clusters with changes,
+// unless auto approved
+func (c *CmdTarmak) approveEnvironmentChanges(reports []*clusterDrift, count int) error {
+	if err := outputDrift(os.Stdout, "text", reports); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		c.log.Infof("no changes to apply in environment %s", c.Environment().Name())
+		return nil
+	}
+
+	if c.flags.Environment.Apply.AutoApprove {
+		return nil
+	}
+
+	approved, err := input.New(os.Stdin, os.Stdout).AskYesNo(&input.AskYesNo{
+		Default: false,
+		Query:   fmt.Sprintf("Apply the changes of %d clusters of environment %s?", count, c.Environment().Name()),
+	})
+	if err != nil {
+		return err
+	}
+	if !approved {
+		return fmt.Errorf("not applying environment %s", c.Environment().Name())
+	}
+
+	return nil
+}
+
+// sameDrift is true if both reports contain the same changes for the same
+// clusters, a cluster that failed to plan never matches
+func sameDrift(a, b []*clusterDrift) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Cluster != b[i].Cluster || a[i].Error != "" || b[i].Error != "" {
+			return false
+		}
+		if !reflect.DeepEqual(a[i].Changes, b[i].Changes) {
+			return false
+		}
+	}
+	return true
+}
+
+// plan a cluster and classify its changes
+func (c *CmdTarmak) planClusterChanges(cluster interfaces.Cluster) *clusterDrift {
+	c.cluster = cluster
+	defer c.terraform.ResetTerraformWrapper()
+
+	c.log.Infof("planning cluster %s", cluster.ClusterName())
+	report := &clusterDrift{Cluster: cluster.ClusterName()}
+
+	err := c.setupTerraform()
+	if err == nil {
+		report.Changes, err = c.terraform.Drift(cluster)
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	return report
+}
+
+// apply a cluster within this process
+func (c *CmdTarmak) applyCluster(cluster interfaces.Cluster) error {
+	c.cluster = cluster
+	defer c.terraform.ResetTerraformWrapper()
+
+	return c.Apply()
+}
+
+// apply a cluster in a separate tarmak process, this allows to apply
+// clusters concurrently
+func (c *CmdTarmak) applyClusterProcess(cluster interfaces.Cluster) error {
+	binaryPath, err := osext.Executable()
+	if err != nil {
+		return fmt.Errorf("error finding tarmak executable: %s", err)
+	}
+
+	args := []string{
+		"--config-directory", c.ConfigPath(),
+		"--current-cluster", cluster.ClusterName(),
+		fmt.Sprintf("--verbose=%t", c.flags.Verbose),
+		fmt.Sprintf("--ignore-missing-public-key-tags=%t", c.flags.IgnoreMissingPublicKeyTags),
+		"cluster", "apply",
+		fmt.Sprintf("--auto-approve-deleting-data=%t", c.flags.Cluster.Apply.AutoApproveDeletingData),
+		fmt.Sprintf("--wait-for-convergence=%t", c.flags.Cluster.Apply.WaitForConvergence),
+	}
+
+	cmd := exec.Command(binaryPath, args...)
+	// signals are forwarded explicitly, see terraform.command
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+
+	outputReader, outputWriter := io.Pipe()
+	cmd.Stdout = outputWriter
+	cmd.Stderr = outputWriter
+
+	outputDone := make(chan struct{})
+	go func() {
+		defer close(outputDone)
+		scanner := bufio.NewScanner(outputReader)
+		for scanner.Scan() {
+			fmt.Fprintf(os.Stderr, "%s | %s\n", cluster.ClusterName(), scanner.Text())
+		}
+	}()
+
+	c.log.Infof("applying cluster %s", cluster.ClusterName())
+	if err := cmd.Start(); err != nil {
+		outputWriter.Close()
+		return err
+	}
+
+	complete := make(chan struct{})
+	go func() {
+		err = cmd.Wait()
+		close(complete)
+	}()
+
+	select {
+	case <-c.ctx.Done():
+		if cmd.Process != nil {
+			cmd.Process.Signal(c.ctx.Signal())
+		}
+		<-complete
+	case <-complete:
+	}
+
+	outputWriter.Close()
+	<-outputDone
+
+	return err
+}
+
+// apply clusters with the given concurrency, after a failure no further
+// clusters are started unless continueOnFailure is set
+func (c *CmdTarmak) applyClusters(clusters []interfaces.Cluster, concurrency int, continueOnFailure bool, apply func(interfaces.Cluster) error) (failed map[string]error, skipped []string) {
+	failed = make(map[string]error)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, cluster := range clusters {
+		sem <- struct{}{}
+
+		mu.Lock()
+		stop := len(failed) > 0 && !continueOnFailure
+		mu.Unlock()
+		select {
+		case <-c.ctx.Done():
+			stop = true
+		default:
+		}
+		if stop {
+			<-sem
+			skipped = append(skipped, cluster.ClusterName())
+			continue
+		}
+
+		wg.Add(1)
+		go func(cluster interfaces.Cluster) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := apply(cluster); err != nil {
+				mu.Lock()
+				failed[cluster.ClusterName()] = err
+				mu.Unlock()
+			}
+		}(cluster)
+	}
+
+	wg.Wait()
+	return failed, skipped
+}
+
+func sortedKeys(m map[string]error) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,124 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package tarmak
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
+	"github.com/jetstack/tarmak/pkg/terraform/plan"
+)
+
+func testApplyClusters(t *testing.T, continueOnFailure bool) (applied []string, failed map[string]error, skipped []string) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := mocks.NewMockCancellationContext(ctrl)
+	ctx.EXPECT().Done().Return(make(chan struct{})).AnyTimes()
+	c := &CmdTarmak{ctx: ctx}
+
+	var clusters []interfaces.Cluster
+	for _, name := range []string{"env-a", "env-b", "env-c"} {
+		cluster := mocks.NewMockCluster(ctrl)
+		cluster.EXPECT().ClusterName().Return(name).AnyTimes()
+		clusters = append(clusters, cluster)
+	}
+
+	failed, skipped = c.applyClusters(clusters, 1, continueOnFailure, func(cluster interfaces.Cluster) error {
+		applied = append(applied, cluster.ClusterName())
+		if cluster.ClusterName() == "env-b" {
+			return errors.New("apply failed")
+		}
+		return nil
+	})
+
+	return applied, failed, skipped
+}
+
+func TestCmdTarmak_applyClusters_StopOnFailure(t *testing.T) {
+	applied, failed, skipped := testApplyClusters(t, false)
+
+	if exp := []string{"env-a", "env-b"}; !reflect.DeepEqual(exp, applied) {
+		t.Errorf("expected applied clusters %v, got %v", exp, applied)
+	}
+	if _, ok := failed["env-b"]; !ok || len(failed) != 1 {
+		t.Errorf("expected env-b to fail, got %v", failed)
+	}
+	if exp := []string{"env-c"}; !reflect.DeepEqual(exp, skipped) {
+		t.Errorf("expected skipped clusters %v, got %v", exp, skipped)
+	}
+}
+
+func TestCmdTarmak_applyClusters_ContinueOnFailure(t *testing.T) {
+	applied, failed, skipped := testApplyClusters(t, true)
+
+	if exp := []string{"env-a", "env-b", "env-c"}; !reflect.DeepEqual(exp, applied) {
+		t.Errorf("expected applied clusters %v, got %v", exp, applied)
+	}
+	if _, ok := failed["env-b"]; !ok || len(failed) != 1 {
+		t.Errorf("expected env-b to fail, got %v", failed)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("expected no skipped clusters, got %v", skipped)
+	}
+}
+
+func TestSameDrift(t *testing.T) {
+	change := func(resource string) *plan.Change {
+		return &plan.Change{Resource: resource, Action: "update", Kind: plan.ChangeKindConfig}
+	}
+	approved := []*clusterDrift{
+		{Cluster: "env-a", Changes: []*plan.Change{change("aws_instance.a")}},
+		{Cluster: "env-b"},
+	}
+
+	for _, c := range []struct {
+		name      string
+		replanned []*clusterDrift
+		exp       bool
+	}{
+		{
+			name: "unchanged",
+			replanned: []*clusterDrift{
+				{Cluster: "env-a", Changes: []*plan.Change{change("aws_instance.a")}},
+				{Cluster: "env-b"},
+			},
+			exp: true,
+		},
+		{
+			name: "additional change",
+			replanned: []*clusterDrift{
+				{Cluster: "env-a", Changes: []*plan.Change{change("aws_instance.a")}},
+				{Cluster: "env-b", Changes: []*plan.Change{change("aws_instance.b")}},
+			},
+			exp: false,
+		},
+		{
+			name: "failed to plan",
+			replanned: []*clusterDrift{
+				{Cluster: "env-a", Changes: []*plan.Change{change("aws_instance.a")}},
+				{Cluster: "env-b", Error: "no hub state"},
+			},
+			exp: false,
+		},
+	} {
+		if act := sameDrift(approved, c.replanned); act != c.exp {
+			t.Errorf("%s: expected %t, got %t", c.name, c.exp, act)
+		}
+	}
+
+	// clusters never planned before have to be approved
+	unplanned := []*clusterDrift{
+		{Cluster: "env-a", Error: "will be planned after the hub has been applied"},
+	}
+	replanned := []*clusterDrift{
+		{Cluster: "env-a"},
+	}
+	if sameDrift(unplanned, replanned) {
+		t.Error("expected cluster that failed to plan to need approval")
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package initialize
+
+import (
+	"fmt"
+	"strings"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/cluster"
+	"github.com/jetstack/tarmak/pkg/tarmak/config"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+)
+
+// InitFromSpec creates the provider, environment and cluster of the spec
+// without asking any questions. Existing objects with matching answers are
+// reused, so running it again does not change the configuration.
+func (i *Initialize) InitFromSpec(spec *Spec) (clusterObj interfaces.Cluster, err error) {
+	if err := spec.Validate(i.Config()); err != nil {
+		return nil, fmt.Errorf("invalid init spec: %s", err)
+	}
+
+	i.currentProvider, err = i.providerFromSpec(&spec.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	i.currentEnvironment, err = i.environmentFromSpec(&spec.Environment)
+	if err != nil {
+		return nil, err
+	}
+	i.currentEnvironment.Provider().Reset()
+
+	return i.clusterFromSpec(&spec.Cluster)
+}
+
+func (i *Initialize) providerFromSpec(spec *SpecProvider) (interfaces.Provider, error) {
+	for _, providerConf := range i.Config().Providers() {
+		if providerConf.Name != spec.Name {
+			continue
+		}
+		if diffs := spec.diff(providerConf); len(diffs) > 0 {
+			return nil, fmt.Errorf("provider '%s' already exists with a different configuration: %s", spec.Name, strings.Join(diffs, ", "))
+		}
+		i.log.Infof("using existing provider '%s'", spec.Name)
+		return i.newProvider(providerConf)
+	}
+
+	if spec.PublicZone == "" {
+		return nil, fmt.Errorf("public zone of new provider '%s' is missing", spec.Name)
+	}
+	bucketPrefix := spec.BucketPrefix
+	if bucketPrefix == "" {
+		bucketPrefix = fmt.Sprintf("%s-tarmak-", spec.Name)
+	}
+
+	providerConf := &tarmakv1alpha1.Provider{
+		Amazon: &tarmakv1alpha1.ProviderAmazon{
+			Profile:      spec.Profile,
+			VaultPath:    spec.VaultPath,
+			BucketPrefix: bucketPrefix,
+			PublicZone:   spec.PublicZone,
+		},
+	}
+	providerConf.Name = spec.Name
+
+	providerObj, err := i.newProvider(providerConf)
+	if err != nil {
+		return nil, fmt.Errorf("error creating provider: %s", err)
+	}
+	if err := providerObj.Validate(); err != nil {
+		return nil, fmt.Errorf("validation of provider '%s' failed: %s", spec.Name, err)
+	}
+	// the public zone might not exist yet, verification is repeated on apply
+	if err := providerObj.Verify(); err != nil {
+		i.log.Warnf("verification of provider '%s' failed: %s", spec.Name, err)
+	}
+
+	if err := i.Config().AppendProvider(providerConf); err != nil {
+		return nil, err
+	}
+	i.log.Infof("created provider '%s'", spec.Name)
+
+	return providerObj, nil
+}
+
+func (i *Initialize) environmentFromSpec(spec *SpecEnvironment) (interfaces.Environment, error) {
+	providerName := i.CurrentProvider().Name()
+
+	for _, environmentConf := range i.Config().Environments() {
+		if environmentConf.Name != spec.Name {
+			continue
+		}
+		if diffs := spec.diff(providerName, environmentConf); len(diffs) > 0 {
+			return nil, fmt.Errorf("environment '%s' already exists with a different configuration: %s", spec.Name, strings.Join(diffs, ", "))
+		}
+		i.log.Infof("using existing environment '%s'", spec.Name)
+		return i.newEnvironment(environmentConf)
+	}
+
+	if spec.Region == "" {
+		return nil, fmt.Errorf("region of new environment '%s' is missing", spec.Name)
+	}
+	project := spec.Project
+	if project == "" {
+		project = defaultProjectName
+	}
+
+	environmentConf := &tarmakv1alpha1.Environment{
+		Provider: providerName,
+		Project:  project,
+		Contact:  spec.Contact,
+		Location: spec.Region,
+	}
+	environmentConf.Name = spec.Name
+
+	environmentObj, err := i.newEnvironment(environmentConf)
+	if err != nil {
+		return nil, fmt.Errorf("error creating environment: %s", err)
+	}
+	if err := environmentObj.Validate(); err != nil {
+		return nil, fmt.Errorf("validation of environment '%s' failed: %s", spec.Name, err)
+	}
+
+	if err := i.Config().AppendEnvironment(environmentConf); err != nil {
+		return nil, err
+	}
+	i.log.Infof("created environment '%s'", spec.Name)
+
+	return environmentObj, nil
+}
+
+func (i *Initialize) clusterFromSpec(spec *SpecCluster) (interfaces.Cluster, error) {
+	environmentObj := i.CurrentEnvironment()
+
+	switch environmentObj.Type() {
+	case tarmakv1alpha1.EnvironmentTypeSingle:
+		if spec.Type == SpecClusterTypeMulti {
+			return nil, fmt.Errorf("can't add a cluster to the single cluster environment '%s'", environmentObj.Name())
+		}
+	case tarmakv1alpha1.EnvironmentTypeMulti:
+		if spec.Type != SpecClusterTypeMulti {
+			return nil, fmt.Errorf("environment '%s' is a multi cluster environment, cluster type '%s' is required", environmentObj.Name(), SpecClusterTypeMulti)
+		}
+	}
+
+	clusterName := spec.clusterName()
+	for _, clusterConf := range i.Config().Clusters(environmentObj.Name()) {
+		if clusterConf.Name != clusterName {
+			continue
+		}
+		if diffs := spec.diff(clusterConf); len(diffs) > 0 {
+			return nil, fmt.Errorf("cluster '%s' already exists with a different configuration: %s", clusterName, strings.Join(diffs, ", "))
+		}
+		i.log.Infof("cluster '%s' is already initialized", clusterName)
+		return i.newCluster(clusterConf)
+	}
+
+	if len(spec.Zones) == 0 {
+		return nil, fmt.Errorf("zones of new cluster '%s' are missing", clusterName)
+	}
+
+	var clusterConf, clusterHub *clusterv1alpha1.Cluster
+	if spec.Type == SpecClusterTypeMulti {
+		clusterConf = config.NewClusterMulti(environmentObj.Name(), clusterName)
+
+		// adds hub if neccessary
+		if err := i.Config().UniqueClusterName(environmentObj.Name(), clusterv1alpha1.ClusterTypeHub); err == nil {
+			clusterHub = config.NewHub(environmentObj.Name())
+			cluster.AddAvailabilityZones(clusterHub, spec.Zones)
+		}
+	} else {
+		clusterConf = config.NewClusterSingle(environmentObj.Name(), clusterName)
+	}
+
+	disableMonitoring(clusterConf)
+	cluster.AddAvailabilityZones(clusterConf, spec.Zones)
+	if err := spec.applyInstancePools(clusterConf); err != nil {
+		return nil, err
+	}
+
+	clusterObj, err := i.newCluster(clusterConf)
+	if err != nil {
+		return nil, err
+	}
+	if err := clusterObj.Validate(); err != nil {
+		return nil, fmt.Errorf("validation of cluster '%s' failed: %s", clusterName, err)
+	}
+
+	if clusterHub != nil {
+		if err := i.Config().AppendCluster(clusterHub); err != nil {
+			return nil, err
+		}
+	}
+	if err := i.Config().AppendCluster(clusterConf); err != nil {
+		return nil, err
+	}
+	i.log.Infof("created cluster '%s'", clusterName)
+
+	return clusterObj, nil
+}
@@ -286,21 +286,7 @@ creationLoop:
 			return nil, err
 		}
 
-		if clusterConf.Kubernetes == nil {
-			clusterConf.Kubernetes = new(clusterv1alpha1.ClusterKubernetes)
-		}
-
-		clusterConf.Kubernetes.Heapster = &clusterv1alpha1.ClusterKubernetesHeapster{
-			Enabled: false,
-		}
-
-		clusterConf.Kubernetes.Grafana = &clusterv1alpha1.ClusterKubernetesGrafana{
-			Enabled: false,
-		}
-
-		clusterConf.Kubernetes.InfluxDB = &clusterv1alpha1.ClusterKubernetesInfluxDB{
-			Enabled: false,
-		}
+		disableMonitoring(clusterConf)
 
 		clusterObj, err = i.newCluster(clusterConf)
 		if err != nil {
@@ -366,6 +352,25 @@ creationLoop:
 	return clusterObj, nil
 }
 
+// new clusters are created without the legacy monitoring addons
+func disableMonitoring(clusterConf *clusterv1alpha1.Cluster) {
+	if clusterConf.Kubernetes == nil {
+		clusterConf.Kubernetes = new(clusterv1alpha1.ClusterKubernetes)
+	}
+
+	clusterConf.Kubernetes.Heapster = &clusterv1alpha1.ClusterKubernetesHeapster{
+		Enabled: false,
+	}
+
+	clusterConf.Kubernetes.Grafana = &clusterv1alpha1.ClusterKubernetesGrafana{
+		Enabled: false,
+	}
+
+	clusterConf.Kubernetes.InfluxDB = &clusterv1alpha1.ClusterKubernetesInfluxDB{
+		Enabled: false,
+	}
+}
+
 func (i *Initialize) GetProvider() (providerObj interfaces.Provider, err error) {
 	if len(i.tarmak.Config().Providers()) == 0 {
 		i.input.Warn("no providers found in configuration...\n")
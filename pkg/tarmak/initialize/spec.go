@@ -0,0 +1,321 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package initialize
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v2"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
+)
+
+const (
+	SpecClusterTypeSingle = tarmakv1alpha1.EnvironmentTypeSingle
+	SpecClusterTypeMulti  = tarmakv1alpha1.EnvironmentTypeMulti
+
+	defaultProjectName = "tarmak-playground"
+)
+
+// Spec contains the answers to all questions of the init wizard
+type Spec struct {
+	Provider    SpecProvider    `yaml:"provider"`
+	Environment SpecEnvironment `yaml:"environment"`
+	Cluster     SpecCluster     `yaml:"cluster"`
+}
+
+type SpecProvider struct {
+	Name         string `yaml:"name"`
+	Profile      string `yaml:"profile,omitempty"`
+	VaultPath    string `yaml:"vaultPath,omitempty"`
+	BucketPrefix string `yaml:"bucketPrefix,omitempty"`
+	PublicZone   string `yaml:"publicZone,omitempty"`
+}
+
+type SpecEnvironment struct {
+	Name    string `yaml:"name"`
+	Project string `yaml:"project,omitempty"`
+	Contact string `yaml:"contact,omitempty"`
+	Region  string `yaml:"region,omitempty"`
+}
+
+type SpecCluster struct {
+	Type          string             `yaml:"type,omitempty"`
+	Name          string             `yaml:"name,omitempty"`
+	Zones         []string           `yaml:"zones,omitempty"`
+	InstancePools []SpecInstancePool `yaml:"instancePools,omitempty"`
+}
+
+type SpecInstancePool struct {
+	Name     string `yaml:"name"`
+	MinCount int    `yaml:"minCount,omitempty"`
+	MaxCount int    `yaml:"maxCount,omitempty"`
+	Size     string `yaml:"size,omitempty"`
+}
+
+// SpecRequested returns true if init should run without prompts
+func SpecRequested(flags *tarmakv1alpha1.InitFlags) bool {
+	if len(flags.Zones) > 0 || len(flags.InstancePoolCounts) > 0 || len(flags.InstancePoolSizes) > 0 {
+		return true
+	}
+
+	answers := *flags
+	answers.Zones = nil
+	answers.InstancePoolCounts = nil
+	answers.InstancePoolSizes = nil
+	return !reflect.DeepEqual(answers, tarmakv1alpha1.InitFlags{})
+}
+
+// NewSpec reads the spec file given in the flags and overrides its answers
+// with the ones given as flags
+func NewSpec(flags *tarmakv1alpha1.InitFlags) (*Spec, error) {
+	spec := &Spec{}
+
+	if flags.FromFile != "" {
+		data, err := ioutil.ReadFile(flags.FromFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading init spec: %s", err)
+		}
+		if err := yaml.UnmarshalStrict(data, spec); err != nil {
+			return nil, fmt.Errorf("error parsing init spec '%s': %s", flags.FromFile, err)
+		}
+	}
+
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	override(&spec.Provider.Name, flags.Provider)
+	override(&spec.Provider.Profile, flags.Profile)
+	override(&spec.Provider.VaultPath, flags.VaultPath)
+	override(&spec.Provider.BucketPrefix, flags.BucketPrefix)
+	override(&spec.Provider.PublicZone, flags.PublicZone)
+	override(&spec.Environment.Name, flags.Environment)
+	override(&spec.Environment.Project, flags.Project)
+	override(&spec.Environment.Contact, flags.Contact)
+	override(&spec.Environment.Region, flags.Region)
+	override(&spec.Cluster.Type, flags.ClusterType)
+	override(&spec.Cluster.Name, flags.ClusterName)
+	if len(flags.Zones) > 0 {
+		spec.Cluster.Zones = flags.Zones
+	}
+
+	for _, value := range flags.InstancePoolCounts {
+		name, count, err := splitPoolValue(value)
+		if err != nil {
+			return nil, err
+		}
+		c, err := strconv.Atoi(count)
+		if err != nil {
+			return nil, fmt.Errorf("invalid count of instance pool '%s': %s", name, err)
+		}
+		pool := spec.Cluster.instancePool(name)
+		pool.MinCount = c
+		pool.MaxCount = c
+	}
+
+	for _, value := range flags.InstancePoolSizes {
+		name, size, err := splitPoolValue(value)
+		if err != nil {
+			return nil, err
+		}
+		spec.Cluster.instancePool(name).Size = size
+	}
+
+	return spec, nil
+}
+
+func splitPoolValue(value string) (name, poolValue string, err error) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid instance pool value '%s', expected <pool>=<value>", value)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (s *SpecCluster) instancePool(name string) *SpecInstancePool {
+	for pos := range s.InstancePools {
+		if s.InstancePools[pos].Name == name {
+			return &s.InstancePools[pos]
+		}
+	}
+	s.InstancePools = append(s.InstancePools, SpecInstancePool{Name: name})
+	return &s.InstancePools[len(s.InstancePools)-1]
+}
+
+// Validate checks the answers with the same rules the init wizard applies
+func (s *Spec) Validate(conf interfaces.Config) error {
+	var result *multierror.Error
+
+	validName := func(kind, name, regex string) {
+		if name == "" {
+			result = multierror.Append(result, fmt.Errorf("%s name is missing", kind))
+		} else if err := conf.ValidName(name, regex); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s name is not valid: %s", kind, err))
+		}
+	}
+
+	validName("provider", s.Provider.Name, input.RegexpProviderName.String())
+	if s.Provider.Profile != "" && s.Provider.VaultPath != "" {
+		result = multierror.Append(result, fmt.Errorf("provider profile and vault path are mutually exclusive"))
+	}
+	if s.Provider.BucketPrefix != "" {
+		if err := conf.ValidName(s.Provider.BucketPrefix, input.RegexpProviderName.String()); err != nil {
+			result = multierror.Append(result, fmt.Errorf("bucket prefix is not valid: %s", err))
+		}
+	}
+	if s.Provider.PublicZone != "" {
+		if err := conf.ValidName(s.Provider.PublicZone, input.RegexpDNS.String()); err != nil {
+			result = multierror.Append(result, fmt.Errorf("public zone is not valid: %s", err))
+		}
+	}
+
+	validName("environment", s.Environment.Name, input.RegexpEnvironmentName.String())
+	if s.Environment.Contact != "" {
+		if err := validation.Validate(s.Environment.Contact, validation.Required, is.Email); err != nil {
+			result = multierror.Append(result, fmt.Errorf("invalid contact mail address: %s", err))
+		}
+	}
+	for _, zone := range s.Cluster.Zones {
+		if s.Environment.Region != "" && !strings.HasPrefix(zone, s.Environment.Region) {
+			result = multierror.Append(result, fmt.Errorf("zone '%s' is not in region '%s'", zone, s.Environment.Region))
+		}
+	}
+
+	switch s.Cluster.Type {
+	case "", SpecClusterTypeSingle:
+	case SpecClusterTypeMulti:
+		validName("cluster", s.Cluster.Name, input.RegexpClusterName.String())
+		if s.Cluster.Name == clusterv1alpha1.ClusterTypeHub {
+			result = multierror.Append(result, fmt.Errorf("cluster name '%s' is reserved", s.Cluster.Name))
+		}
+	default:
+		result = multierror.Append(result, fmt.Errorf("cluster type '%s' is not valid, use '%s' or '%s'", s.Cluster.Type, SpecClusterTypeSingle, SpecClusterTypeMulti))
+	}
+
+	for _, pool := range s.Cluster.InstancePools {
+		if pool.MinCount < 0 || pool.MaxCount < pool.MinCount {
+			result = multierror.Append(result, fmt.Errorf("instance pool '%s' has invalid counts min=%d max=%d", pool.Name, pool.MinCount, pool.MaxCount))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// the type of the cluster within its environment
+func (s *SpecCluster) clusterType() string {
+	if s.Type == SpecClusterTypeMulti {
+		return clusterv1alpha1.ClusterTypeClusterMulti
+	}
+	return clusterv1alpha1.ClusterTypeClusterSingle
+}
+
+func (s *SpecCluster) clusterName() string {
+	if s.Type == SpecClusterTypeMulti {
+		return s.Name
+	}
+	return "cluster"
+}
+
+// compare the answers with an existing provider
+func (s *SpecProvider) diff(conf *tarmakv1alpha1.Provider) []string {
+	var diffs []string
+	if conf.Amazon == nil {
+		return []string{"provider is not an amazon provider"}
+	}
+	diffs = appendDiff(diffs, "profile", s.Profile, conf.Amazon.Profile)
+	diffs = appendDiff(diffs, "vaultPath", s.VaultPath, conf.Amazon.VaultPath)
+	diffs = appendDiff(diffs, "bucketPrefix", s.BucketPrefix, conf.Amazon.BucketPrefix)
+	diffs = appendDiff(diffs, "publicZone", s.PublicZone, conf.Amazon.PublicZone)
+	return diffs
+}
+
+// compare the answers with an existing environment
+func (s *SpecEnvironment) diff(provider string, conf *tarmakv1alpha1.Environment) []string {
+	var diffs []string
+	diffs = appendDiff(diffs, "provider", provider, conf.Provider)
+	diffs = appendDiff(diffs, "project", s.Project, conf.Project)
+	diffs = appendDiff(diffs, "contact", s.Contact, conf.Contact)
+	diffs = appendDiff(diffs, "region", s.Region, conf.Location)
+	return diffs
+}
+
+// compare the answers with an existing cluster
+func (s *SpecCluster) diff(conf *clusterv1alpha1.Cluster) []string {
+	var diffs []string
+	diffs = appendDiff(diffs, "type", s.clusterType(), conf.Type)
+
+	for _, pool := range conf.InstancePools {
+		if len(s.Zones) == 0 {
+			break
+		}
+		var zones []string
+		for _, subnet := range pool.Subnets {
+			zones = append(zones, subnet.Zone)
+		}
+		diffs = appendDiff(diffs, fmt.Sprintf("instancePools[%s].zones", pool.Name), strings.Join(s.Zones, ","), strings.Join(zones, ","))
+	}
+
+	for _, specPool := range s.InstancePools {
+		var pool *clusterv1alpha1.InstancePool
+		for pos := range conf.InstancePools {
+			if conf.InstancePools[pos].Name == specPool.Name {
+				pool = &conf.InstancePools[pos]
+			}
+		}
+		if pool == nil {
+			diffs = append(diffs, fmt.Sprintf("instance pool '%s' does not exist", specPool.Name))
+			continue
+		}
+		if specPool.MaxCount > 0 {
+			diffs = appendDiff(diffs, fmt.Sprintf("instancePools[%s].minCount", pool.Name), strconv.Itoa(specPool.MinCount), strconv.Itoa(pool.MinCount))
+			diffs = appendDiff(diffs, fmt.Sprintf("instancePools[%s].maxCount", pool.Name), strconv.Itoa(specPool.MaxCount), strconv.Itoa(pool.MaxCount))
+		}
+		diffs = appendDiff(diffs, fmt.Sprintf("instancePools[%s].size", pool.Name), specPool.Size, pool.Size)
+	}
+
+	return diffs
+}
+
+// answers left empty accept any existing value
+func appendDiff(diffs []string, field, spec, existing string) []string {
+	if spec == "" || spec == existing {
+		return diffs
+	}
+	return append(diffs, fmt.Sprintf("%s is '%s' instead of '%s'", field, existing, spec))
+}
+
+// apply the instance pool answers to a new cluster
+func (s *SpecCluster) applyInstancePools(conf *clusterv1alpha1.Cluster) error {
+	for _, specPool := range s.InstancePools {
+		found := false
+		for pos := range conf.InstancePools {
+			pool := &conf.InstancePools[pos]
+			if pool.Name != specPool.Name {
+				continue
+			}
+			found = true
+			if specPool.MaxCount > 0 {
+				pool.MinCount = specPool.MinCount
+				pool.MaxCount = specPool.MaxCount
+			}
+			if specPool.Size != "" {
+				pool.Size = specPool.Size
+			}
+		}
+		if !found {
+			return fmt.Errorf("cluster '%s' has no instance pool '%s'", conf.Name, specPool.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package initialize
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/config"
+)
+
+const testSpec = `provider:
+  name: aws
+  profile: ci
+  publicZone: example.com
+environment:
+  name: dev
+  contact: admin@example.com
+  region: eu-west-1
+cluster:
+  type: multi
+  name: apps
+  zones:
+  - eu-west-1a
+  instancePools:
+  - name: worker
+    minCount: 3
+    maxCount: 5
+`
+
+func TestNewSpec(t *testing.T) {
+	file, err := ioutil.TempFile("", "tarmak-init-spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(testSpec); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	spec, err := NewSpec(&tarmakv1alpha1.InitFlags{
+		FromFile:          file.Name(),
+		Region:            "eu-west-2",
+		Zones:             []string{"eu-west-2a", "eu-west-2b"},
+		InstancePoolSizes: []string{"worker=large", "master=small"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	exp := &Spec{
+		Provider: SpecProvider{
+			Name:       "aws",
+			Profile:    "ci",
+			PublicZone: "example.com",
+		},
+		Environment: SpecEnvironment{
+			Name:    "dev",
+			Contact: "admin@example.com",
+			Region:  "eu-west-2",
+		},
+		Cluster: SpecCluster{
+			Type:  SpecClusterTypeMulti,
+			Name:  "apps",
+			Zones: []string{"eu-west-2a", "eu-west-2b"},
+			InstancePools: []SpecInstancePool{
+				{Name: "worker", MinCount: 3, MaxCount: 5, Size: "large"},
+				{Name: "master", Size: "small"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(exp, spec) {
+		t.Errorf("unexpected spec:\nexp: %+v\nact: %+v", exp, spec)
+	}
+
+	if err := spec.Validate(&config.Config{}); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+}
+
+func TestSpec_Validate(t *testing.T) {
+	spec := &Spec{
+		Provider:    SpecProvider{Name: "AWS", Profile: "ci", VaultPath: "secret/aws"},
+		Environment: SpecEnvironment{Name: "dev", Contact: "nobody", Region: "eu-west-1"},
+		Cluster: SpecCluster{
+			Type:          SpecClusterTypeMulti,
+			Name:          clusterv1alpha1.ClusterTypeHub,
+			Zones:         []string{"us-east-1a"},
+			InstancePools: []SpecInstancePool{{Name: "worker", MinCount: 3, MaxCount: 1}},
+		},
+	}
+
+	err := spec.Validate(&config.Config{})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	for _, msg := range []string{
+		"provider name is not valid",
+		"mutually exclusive",
+		"invalid contact mail address",
+		"zone 'us-east-1a' is not in region 'eu-west-1'",
+		"cluster name 'hub' is reserved",
+		"invalid counts",
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected error to contain '%s', got: %s", msg, err)
+		}
+	}
+}
+
+func TestSpecRequested(t *testing.T) {
+	if SpecRequested(&tarmakv1alpha1.InitFlags{Zones: []string{}}) {
+		t.Error("expected no spec without answers")
+	}
+	if !SpecRequested(&tarmakv1alpha1.InitFlags{Environment: "dev"}) {
+		t.Error("expected spec with answers given as flags")
+	}
+}
+
+func TestSpecCluster_diff(t *testing.T) {
+	conf := config.NewClusterSingle("dev", "cluster")
+	spec := &SpecCluster{}
+	if diffs := spec.diff(conf); len(diffs) > 0 {
+		t.Errorf("expected no differences, got %v", diffs)
+	}
+
+	spec = &SpecCluster{
+		Type:          SpecClusterTypeMulti,
+		InstancePools: []SpecInstancePool{{Name: "worker", Size: clusterv1alpha1.InstancePoolSizeLarge}},
+	}
+	exp := []string{
+		"type is 'cluster-single' instead of 'cluster-multi'",
+		"instancePools[worker].size is 'm5.large' instead of 'large'",
+	}
+	if diffs := spec.diff(conf); !reflect.DeepEqual(exp, diffs) {
+		t.Errorf("unexpected differences:\nexp: %v\nact: %v", exp, diffs)
+	}
+}
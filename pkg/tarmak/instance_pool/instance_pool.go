@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
@@ -19,6 +20,7 @@ import (
 	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
 	"github.com/jetstack/tarmak/pkg/tarmak/role"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+	"github.com/jetstack/tarmak/pkg/wing/schedule"
 )
 
 var _ interfaces.InstancePool = &InstancePool{}
@@ -203,12 +205,138 @@ func (n *InstancePool) AmazonAdditionalIAMPoliciesString() string {
 	return fmt.Sprintf("[%s]", strings.Join(policies, ","))
 }
 
+// returns the KMS key used to encrypt resources at rest, if this instance
+// pool runs the Kubernetes API server
+func (n *InstancePool) AmazonEncryptionKMSKeyARN() string {
+	if !n.Role().HasMaster() {
+		return ""
+	}
+
+	k := n.cluster.Config().Kubernetes
+	if k == nil || k.APIServer == nil || k.APIServer.Encryption == nil {
+		return ""
+	}
+
+	e := k.APIServer.Encryption
+	if e.Provider != clusterv1alpha1.EncryptionProviderKMS || e.KMS == nil {
+		return ""
+	}
+
+	return e.KMS.KeyARN
+}
+
+// returns true if the pods of this instance pool get their IP addresses from
+// the VPC using the AWS VPC CNI plugin
+func (n *InstancePool) AmazonVPCCNI() bool {
+	if !n.Role().HasMaster() && !n.Role().HasWorker() {
+		return false
+	}
+
+	k := n.cluster.Config().Kubernetes
+	return k != nil && k.Networking != nil && k.Networking.Provider == clusterv1alpha1.NetworkingProviderAmazonVPC
+}
+
+// returns true if the instances of this instance pool are attached to the
+// ingress ELB of their role
+func (n *InstancePool) ELBIngress() bool {
+	if !n.Role().AWS.ELBIngress {
+		return false
+	}
+
+	if !n.Role().HasWorker() {
+		return true
+	}
+
+	// only attach the worker instance pool hosting the ingress controller
+	k := n.cluster.Config().Kubernetes
+	if k == nil || k.Ingress == nil {
+		return false
+	}
+	return k.Ingress.InstancePool == "" || k.Ingress.InstancePool == n.Name()
+}
+
+// returns true if the instances of this instance pool run the agent of
+// the pod IAM provider and need to assume the roles of pods
+func (n *InstancePool) PodIAMAssumeRole() bool {
+	k := n.cluster.Config().Kubernetes
+	if k == nil || k.PodIAM == nil {
+		return false
+	}
+
+	// kiam's server runs on the masters, kube2iam on the workers
+	if k.PodIAM.Provider == clusterv1alpha1.PodIAMProviderKiam {
+		return n.Role().HasMaster()
+	}
+	return n.Role().HasWorker()
+}
+
 func (n *InstancePool) AmazonEBSEncrypted() bool {
 	return n.cluster.AmazonEBSEncrypted()
 }
 
 func (n *InstancePool) Validate() (result error) {
-	return n.ValidateAllowCIDRs()
+	if err := n.ValidateAllowCIDRs(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if err := n.ValidateReconverge(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	return result
+}
+
+func (n *InstancePool) ValidateReconverge() (result error) {
+	reconverge := n.Config().Reconverge
+	if reconverge == nil {
+		return nil
+	}
+
+	if reconverge.Interval == "" {
+		result = multierror.Append(result, fmt.Errorf("reconverge interval of instance pool %s is required", n.conf.Name))
+	} else if interval, err := time.ParseDuration(reconverge.Interval); err != nil {
+		result = multierror.Append(result, fmt.Errorf("invalid reconverge interval of instance pool %s: %s", n.conf.Name, err))
+	} else if interval < time.Hour {
+		result = multierror.Append(result, fmt.Errorf("reconverge interval of instance pool %s needs to be at least 1h", n.conf.Name))
+	}
+
+	if reconverge.Jitter != "" {
+		if jitter, err := time.ParseDuration(reconverge.Jitter); err != nil {
+			result = multierror.Append(result, fmt.Errorf("invalid reconverge jitter of instance pool %s: %s", n.conf.Name, err))
+		} else if jitter < 0 {
+			result = multierror.Append(result, fmt.Errorf("reconverge jitter of instance pool %s can not be negative", n.conf.Name))
+		}
+	}
+
+	for _, window := range reconverge.MaintenanceWindows {
+		if strings.Contains(window, schedule.WindowSeparator) {
+			result = multierror.Append(result, fmt.Errorf("maintenance window '%s' of instance pool %s can not contain '%s'", window, n.conf.Name, schedule.WindowSeparator))
+		} else if _, err := schedule.ParseWindow(window); err != nil {
+			result = multierror.Append(result, fmt.Errorf("invalid maintenance window of instance pool %s: %s", n.conf.Name, err))
+		}
+	}
+
+	return result
+}
+
+// settings for the periodic reconverges of wing, empty if disabled
+func (n *InstancePool) ReconvergeInterval() string {
+	if n.conf.Reconverge == nil {
+		return ""
+	}
+	return n.conf.Reconverge.Interval
+}
+
+func (n *InstancePool) ReconvergeJitter() string {
+	if n.conf.Reconverge == nil {
+		return ""
+	}
+	return n.conf.Reconverge.Jitter
+}
+
+func (n *InstancePool) MaintenanceWindows() string {
+	if n.conf.Reconverge == nil {
+		return ""
+	}
+	return strings.Join(n.conf.Reconverge.MaintenanceWindows, schedule.WindowSeparator)
 }
 
 func (n *InstancePool) ValidateAllowCIDRs() (result error) {
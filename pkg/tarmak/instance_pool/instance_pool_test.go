@@ -133,6 +133,56 @@ func TestInstancePool_Taints(t *testing.T) {
 	}
 }
 
+func TestInstancePool_Reconverge(t *testing.T) {
+	validReconverges := []clusterv1alpha1.InstancePoolReconverge{
+		clusterv1alpha1.InstancePoolReconverge{Interval: "6h"},
+		clusterv1alpha1.InstancePoolReconverge{Interval: "24h", Jitter: "2h"},
+		clusterv1alpha1.InstancePoolReconverge{Interval: "6h", MaintenanceWindows: []string{"Mon-Fri 01:00-05:00 Europe/London", "Sat,Sun 00:00-24:00"}},
+	}
+
+	for _, reconverge := range validReconverges {
+		i := InstancePool{
+			conf: &clusterv1alpha1.InstancePool{
+				Reconverge: &reconverge,
+			},
+		}
+
+		if err := i.ValidateReconverge(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	invalidReconverges := []clusterv1alpha1.InstancePoolReconverge{
+		clusterv1alpha1.InstancePoolReconverge{},
+		clusterv1alpha1.InstancePoolReconverge{Interval: "often"},
+		clusterv1alpha1.InstancePoolReconverge{Interval: "5m"},
+		clusterv1alpha1.InstancePoolReconverge{Interval: "6h", Jitter: "-1h"},
+		clusterv1alpha1.InstancePoolReconverge{Interval: "6h", MaintenanceWindows: []string{"weekends"}},
+		clusterv1alpha1.InstancePoolReconverge{Interval: "6h", MaintenanceWindows: []string{"Mon 01:00-02:00; Tue 01:00-02:00"}},
+	}
+
+	for _, reconverge := range invalidReconverges {
+		i := InstancePool{
+			conf: &clusterv1alpha1.InstancePool{
+				Reconverge: &reconverge,
+			},
+		}
+
+		if err := i.ValidateReconverge(); err == nil {
+			t.Errorf("expected %+v to cause a validation error", reconverge)
+		}
+	}
+
+	i := InstancePool{
+		conf: &clusterv1alpha1.InstancePool{
+			Reconverge: &validReconverges[2],
+		},
+	}
+	if exp, act := "Mon-Fri 01:00-05:00 Europe/London;Sat,Sun 00:00-24:00", i.MaintenanceWindows(); exp != act {
+		t.Errorf("unexpected maintenance windows: exp=%s act=%s", exp, act)
+	}
+}
+
 func TestInstancePool_MinMaxCount(t *testing.T) {
 	i := newFakeInstancePool(t)
 	defer i.ctrl.Finish()
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"os"
+	"time"
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/jetstack/vault-unsealer/pkg/kv"
@@ -14,6 +15,7 @@ import (
 
 	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
 	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
 	"github.com/jetstack/tarmak/pkg/tarmak/role"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
 	wingclient "github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned"
@@ -37,6 +39,7 @@ type Cluster interface {
 	Log() *logrus.Entry
 	APITunnel() Tunnel
 	Region() string
+	CrossRegion() bool                 // Return true if the cluster is placed in another region than its hub
 	Subnets() []clusterv1alpha1.Subnet // Return subnets per AZ
 	Role(string) *role.Role
 	Roles() []*role.Role
@@ -52,6 +55,16 @@ type Cluster interface {
 	WaitForConvergance() error
 	// This upload the puppet.tar.gz to the cluster, warning there is some duplication as terraform is also uploading this puppet.tar.gz
 	UploadConfiguration() error
+	// This records the current puppet.tar.gz in the cluster's manifest history
+	RecordConfiguration() error
+	// This returns the history of manifests uploaded to the cluster
+	ConfigurationHistory() ([]*tarmakv1alpha1.ManifestHistoryEntry, error)
+	// This pins instances to a manifest from the history, optionally limited to an instance pool
+	RollbackConfiguration(hash, instancePool string) error
+	// This returns the instances known to wing including their reported facts
+	Inventory() ([]*wingv1alpha1.Instance, error)
+	// This runs a command through wing on the instances of an instance pool and returns their results
+	RunCommand(instancePool string, command []string, timeout time.Duration) (map[string]*wingv1alpha1.CommandResult, error)
 	// Verify the cluster (these contain more expensive calls like AWS calls
 	Verify() error
 	// Validate the cluster (these contain less expensive local calls)
@@ -120,6 +133,16 @@ type Provider interface {
 	RemoteStateBucketName() string
 	RemoteStateBucketAvailable() (bool, error)
 	RemoteState(namespace, clusterName, stackName string) string
+	RemoteStateObjectKey(namespace, clusterName string) string
+	// read a terraform state from any bucket of the provider, used to migrate state
+	ReadRemoteState(bucket, key string) ([]byte, error)
+	// back up the terraform state of a cluster to the backups of its environment
+	BackupState(cluster Cluster, state []byte) (*tarmakv1alpha1.StateBackup, error)
+	// list the state backups of a cluster, oldest first
+	StateBackups(Cluster) ([]*tarmakv1alpha1.StateBackup, error)
+	ReadStateBackup(cluster Cluster, name string) ([]byte, error)
+	// return the lock held on the terraform state of a cluster, nil if unlocked
+	StateLock(Cluster) (*tarmakv1alpha1.StateLock, error)
 	PublicZone() string
 	Environment() ([]string, error)
 	Variables() map[string]interface{}
@@ -133,9 +156,15 @@ type Provider interface {
 	String() string
 	AskEnvironmentLocation(Initialize) (string, error)
 	AskInstancePoolZones(Initialize) (zones []string, err error)
-	UploadConfiguration(Cluster, io.ReadSeeker, string) error
+	UploadConfiguration(cluster Cluster, stateFile io.ReadSeeker, md5Hash string, signature []byte) error
+	SignManifest(cluster Cluster, manifest []byte) ([]byte, error)
+	ManifestSigningPublicKey(Cluster) ([]byte, error)
+	RecordConfiguration(cluster Cluster, md5Hash, hash string) error
+	ConfigurationHistory(Cluster) ([]*tarmakv1alpha1.ManifestHistoryEntry, error)
 	EnsureRemoteResources() error
 	LegacyPuppetTFName() string
+	// discover existing resources of the cluster, that can be imported into its terraform state
+	ImportableResources(Cluster) ([]*tarmakv1alpha1.ImportResource, error)
 	// Remove provider
 	Remove() error
 }
@@ -196,6 +225,10 @@ type Config interface {
 	CurrentEnvironmentName() (string, error)
 	// remove environment
 	RemoveEnvironment(environment string) error
+	// split the configuration into the directory layout
+	Migrate() error
+	// convert the configuration to the latest schema versions, returns the changes
+	Upgrade(dryRun bool) (diff string, err error)
 	Contact() string
 	Project() string
 	WingDevMode() bool
@@ -280,6 +313,7 @@ type InstancePool interface {
 	MinCount() int
 	MaxCount() int
 	InstanceType() string
+	SpotPrice() string
 	Labels() (string, error)
 	Taints() (string, error)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -27,6 +28,8 @@ import (
 	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
 	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
 	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/provider/amazon/kmssign"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
 )
@@ -41,13 +44,21 @@ type Amazon struct {
 	availabilityZones *[]string
 	remoteStateKMS    string
 
-	session  *session.Session
-	ec2      EC2
-	s3       S3
-	kms      KMS
-	dynamodb DynamoDB
-	route53  Route53
-	log      *logrus.Entry
+	manifestSigningPublicKeys map[string][]byte
+
+	session       *session.Session
+	sessionRegion string
+	ec2           EC2
+	ec2Hub        EC2
+	s3            S3
+	s3Hub         S3
+	kms           KMS
+	kmsHub        KMS
+	dynamodb      DynamoDB
+	dynamodbHub   DynamoDB
+	route53       Route53
+	elb           ELB
+	log           *logrus.Entry
 }
 
 type S3 interface {
@@ -75,6 +86,14 @@ type EC2 interface {
 	DescribeRegions(input *ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error)
 	DescribeReservedInstancesOfferings(input *ec2.DescribeReservedInstancesOfferingsInput) (*ec2.DescribeReservedInstancesOfferingsOutput, error)
 	DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error)
+	DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
+	DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	DescribeInternetGateways(input *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error)
+	DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error)
+}
+
+type ELB interface {
+	DescribeLoadBalancers(input *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error)
 }
 
 type DynamoDB interface {
@@ -83,6 +102,7 @@ type DynamoDB interface {
 	CreateTable(input *dynamodb.CreateTableInput) (*dynamodb.CreateTableOutput, error)
 	DeleteTable(input *dynamodb.DeleteTableInput) (*dynamodb.DeleteTableOutput, error)
 	DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error)
+	PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error)
 	Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error)
 }
 
@@ -96,6 +116,9 @@ type KMS interface {
 	CreateAlias(input *kms.CreateAliasInput) (*kms.CreateAliasOutput, error)
 	CreateKey(input *kms.CreateKeyInput) (*kms.CreateKeyOutput, error)
 	DescribeKey(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error)
+	CreateSigningKey(input *kmssign.CreateKeyInput) (*kms.CreateKeyOutput, error)
+	Sign(input *kmssign.SignInput) (*kmssign.SignOutput, error)
+	GetPublicKey(input *kmssign.GetPublicKeyInput) (*kmssign.GetPublicKeyOutput, error)
 }
 
 var _ interfaces.Provider = &Amazon{}
@@ -124,8 +147,13 @@ func (a *Amazon) Reset() {
 	a.dynamodb = nil
 	a.session = nil
 	a.s3 = nil
+	a.s3Hub = nil
 	a.ec2 = nil
+	a.ec2Hub = nil
+	a.dynamodbHub = nil
+	a.kmsHub = nil
 	a.route53 = nil
+	a.elb = nil
 	a.availabilityZones = nil
 }
 
@@ -218,6 +246,16 @@ func (a *Amazon) AskInstancePoolZones(init interfaces.Initialize) (zones []strin
 }
 
 func (a *Amazon) Region() string {
+	// clusters can be placed in another region than their environment
+	if cluster := a.tarmak.Cluster(); cluster != nil {
+		return cluster.Region()
+	}
+	return a.hubRegion()
+}
+
+// hubRegion returns the region of the environment, which is where its hub is
+// placed
+func (a *Amazon) hubRegion() string {
 	// without environment selected, fall back to default region
 	if a.tarmak.Environment() == nil {
 		return "us-east-1"
@@ -225,8 +263,38 @@ func (a *Amazon) Region() string {
 	return a.tarmak.Environment().Location()
 }
 
+// environmentRegions returns the regions of the environment's hub and clusters
+func (a *Amazon) environmentRegions() []string {
+	regions := []string{a.hubRegion()}
+	if a.tarmak.Environment() == nil {
+		return regions
+	}
+
+	for _, cluster := range a.tarmak.Environment().Clusters() {
+		if !utils.SliceContains(regions, cluster.Region()) {
+			regions = append(regions, cluster.Region())
+		}
+	}
+	return regions
+}
+
+// resetRegion drops the session and clients if they have been created for
+// another region. This happens when clusters of an environment placed in
+// different regions are handled by the same process.
+func (a *Amazon) resetRegion() {
+	if a.session == nil || a.sessionRegion == a.Region() {
+		return
+	}
+	a.log.Debugf("switching from region %s to %s", a.sessionRegion, a.Region())
+
+	a.Reset()
+	a.kms = nil
+	a.remoteStateKMS = ""
+}
+
 // This return the availabililty zones that are used for a cluster
 func (a *Amazon) AvailabilityZones() (availabiltyZones []string) {
+	a.resetRegion()
 	if a.availabilityZones != nil {
 		return *a.availabilityZones
 	}
@@ -250,6 +318,7 @@ func (a *Amazon) AvailabilityZones() (availabiltyZones []string) {
 }
 
 func (a *Amazon) EC2() (EC2, error) {
+	a.resetRegion()
 	if a.ec2 == nil {
 		sess, err := a.Session()
 		if err != nil {
@@ -260,7 +329,21 @@ func (a *Amazon) EC2() (EC2, error) {
 	return a.ec2, nil
 }
 
+// hubEC2 returns an EC2 client for the region of the environment's hub
+func (a *Amazon) hubEC2() (EC2, error) {
+	a.resetRegion()
+	if a.ec2Hub == nil {
+		sess, err := a.Session()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Amazon session: %s", err)
+		}
+		a.ec2Hub = ec2.New(sess, aws.NewConfig().WithRegion(a.hubRegion()))
+	}
+	return a.ec2Hub, nil
+}
+
 func (a *Amazon) S3() (S3, error) {
+	a.resetRegion()
 	if a.s3 == nil {
 		sess, err := a.Session()
 		if err != nil {
@@ -271,18 +354,47 @@ func (a *Amazon) S3() (S3, error) {
 	return a.s3, nil
 }
 
+// hubS3 returns an S3 client for the region of the environment's hub, which
+// holds the environment's secrets and backups buckets
+func (a *Amazon) hubS3() (S3, error) {
+	a.resetRegion()
+	if a.s3Hub == nil {
+		sess, err := a.Session()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Amazon session: %s", err)
+		}
+		a.s3Hub = s3.New(sess, aws.NewConfig().WithRegion(a.hubRegion()))
+	}
+	return a.s3Hub, nil
+}
+
 func (a *Amazon) KMS() (KMS, error) {
+	a.resetRegion()
 	if a.kms == nil {
 		sess, err := a.Session()
 		if err != nil {
 			return nil, fmt.Errorf("error getting Amazon session: %s", err)
 		}
-		a.kms = kms.New(sess)
+		a.kms = kmssign.New(kms.New(sess))
 	}
 	return a.kms, nil
 }
 
+// hubKMS returns a KMS client for the region of the environment's hub
+func (a *Amazon) hubKMS() (KMS, error) {
+	a.resetRegion()
+	if a.kmsHub == nil {
+		sess, err := a.Session()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Amazon session: %s", err)
+		}
+		a.kmsHub = kmssign.New(kms.New(sess, aws.NewConfig().WithRegion(a.hubRegion())))
+	}
+	return a.kmsHub, nil
+}
+
 func (a *Amazon) DynamoDB() (DynamoDB, error) {
+	a.resetRegion()
 	if a.dynamodb == nil {
 		sess, err := a.Session()
 		if err != nil {
@@ -293,7 +405,22 @@ func (a *Amazon) DynamoDB() (DynamoDB, error) {
 	return a.dynamodb, nil
 }
 
+// hubDynamoDB returns a DynamoDB client for the region of the environment's
+// hub
+func (a *Amazon) hubDynamoDB() (DynamoDB, error) {
+	a.resetRegion()
+	if a.dynamodbHub == nil {
+		sess, err := a.Session()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Amazon session: %s", err)
+		}
+		a.dynamodbHub = dynamodb.New(sess, aws.NewConfig().WithRegion(a.hubRegion()))
+	}
+	return a.dynamodbHub, nil
+}
+
 func (a *Amazon) Route53() (Route53, error) {
+	a.resetRegion()
 	if a.route53 == nil {
 		sess, err := a.Session()
 		if err != nil {
@@ -304,6 +431,18 @@ func (a *Amazon) Route53() (Route53, error) {
 	return a.route53, nil
 }
 
+func (a *Amazon) ELB() (ELB, error) {
+	a.resetRegion()
+	if a.elb == nil {
+		sess, err := a.Session()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Amazon session: %s", err)
+		}
+		a.elb = elb.New(sess)
+	}
+	return a.elb, nil
+}
+
 func (a *Amazon) Variables() map[string]interface{} {
 	output := map[string]interface{}{}
 	output["key_name"] = a.KeyName()
@@ -312,6 +451,9 @@ func (a *Amazon) Variables() map[string]interface{} {
 	}
 	output["availability_zones"] = a.AvailabilityZones()
 	output["region"] = a.Region()
+	output["hub_region"] = a.hubRegion()
+	output["hub_state_bucket"] = a.remoteStateName(a.hubRegion())
+	output["environment_regions"] = a.environmentRegions()
 
 	output["public_zone"] = a.conf.Amazon.PublicZone
 	output["public_zone_id"] = a.conf.Amazon.PublicHostedZoneID
@@ -549,11 +691,13 @@ func (a *Amazon) verifyAvailabilityZones() error {
 }
 
 func (a *Amazon) Session() (*session.Session, error) {
+	a.resetRegion()
 
 	// return cached session
 	if a.session != nil {
 		return a.session, nil
 	}
+	a.sessionRegion = a.Region()
 
 	// use default config, if vault disabled
 	if a.conf.Amazon.VaultPath != "" {
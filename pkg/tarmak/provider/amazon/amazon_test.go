@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
@@ -262,3 +263,27 @@ func TestAmazon_verifyInstanceTypeZonesOne(t *testing.T) {
 		t.Errorf("unexpected err:%v", err)
 	}
 }
+
+func TestAmazon_resetRegion(t *testing.T) {
+	a := newFakeAmazon(t)
+	defer a.ctrl.Finish()
+
+	a.fakeCluster.EXPECT().Region().AnyTimes().Return("eu-west-1")
+	a.Amazon.session = &session.Session{}
+	a.Amazon.sessionRegion = "eu-west-1"
+
+	a.resetRegion()
+	if a.Amazon.session == nil || a.Amazon.ec2 == nil {
+		t.Error("expected session and clients of the same region to be kept")
+	}
+
+	a.Amazon.sessionRegion = "us-west-2"
+	a.Amazon.remoteStateKMS = "arn:aws:kms:us-west-2:1234:key/abcd"
+	a.resetRegion()
+	if a.Amazon.session != nil || a.Amazon.ec2 != nil {
+		t.Error("expected session and clients of another region to be dropped")
+	}
+	if a.Amazon.remoteStateKMS != "" {
+		t.Errorf("expected KMS key of another region to be dropped, got %s", a.Amazon.remoteStateKMS)
+	}
+}
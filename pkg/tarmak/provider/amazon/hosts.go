@@ -190,10 +190,26 @@ func (a *Amazon) ListHosts(c interfaces.Cluster) ([]interfaces.Host, error) {
 	if err != nil {
 		return []interfaces.Host{}, err
 	}
+	reservations := instances.Reservations
+
+	// the bastion and vault instances of clusters in another region are
+	// placed in the region of the hub
+	if c.CrossRegion() {
+		svc, err := a.hubEC2()
+		if err != nil {
+			return []interfaces.Host{}, err
+		}
+
+		instances, err := svc.DescribeInstances(&ec2.DescribeInstancesInput{Filters: filters})
+		if err != nil {
+			return []interfaces.Host{}, err
+		}
+		reservations = append(reservations, instances.Reservations...)
+	}
 
 	hosts := []*host{}
 
-	for _, reservation := range instances.Reservations {
+	for _, reservation := range reservations {
 	instancesLoop:
 		for _, instance := range reservation.Instances {
 			if instance.PrivateIpAddress == nil || instance.InstanceId == nil {
@@ -0,0 +1,267 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/hashicorp/go-multierror"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+)
+
+// ImportableResources discovers existing resources of a cluster by the names
+// and tags the generated terraform code would give them. Resources that could
+// not be found are returned without an ID.
+func (a *Amazon) ImportableResources(c interfaces.Cluster) ([]*tarmakv1alpha1.ImportResource, error) {
+	svc, err := a.EC2()
+	if err != nil {
+		return nil, err
+	}
+
+	// the terraform code names resources after the stack name
+	stackName := c.ClusterName()
+
+	var resources []*tarmakv1alpha1.ImportResource
+	var result *multierror.Error
+	add := func(address, match string, find func() (string, error)) {
+		id, err := find()
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("error discovering %s: %s", address, err))
+			return
+		}
+		resources = append(resources, &tarmakv1alpha1.ImportResource{
+			Address: address,
+			ID:      id,
+			Match:   match,
+		})
+	}
+
+	if c.Type() != clusterv1alpha1.ClusterTypeClusterMulti {
+		if _, ok := c.Config().Network.ObjectMeta.Annotations[clusterv1alpha1.ExistingVPCAnnotationKey]; !ok {
+			name := fmt.Sprintf("vpc.%s", stackName)
+			add("module.network.aws_vpc.main", tagMatch("Name", name), func() (string, error) {
+				return findVPC(svc, tagFilter("Name", name))
+			})
+			add("module.network.aws_internet_gateway.main", tagMatch("Name", name), func() (string, error) {
+				return findInternetGateway(svc, tagFilter("Name", name))
+			})
+
+			for pos, zone := range a.AvailabilityZones() {
+				for _, subnet := range []string{"public", "private"} {
+					name := fmt.Sprintf("%s_%s_%s", stackName, subnet, zone)
+					add(fmt.Sprintf("module.network.aws_subnet.%s[%d]", subnet, pos), tagMatch("Name", name), func() (string, error) {
+						return findSubnet(svc, tagFilter("Name", name))
+					})
+				}
+			}
+
+			if zone := c.Environment().Config().PrivateZone; zone != "" {
+				add("module.network.aws_route53_zone.private", fmt.Sprintf("private zone %s", zone), func() (string, error) {
+					return a.findPrivateZone(zone)
+				})
+			}
+		}
+
+		for _, role := range []string{"bastion", "vault"} {
+			name := fmt.Sprintf("%s-%s", stackName, role)
+			add(fmt.Sprintf("module.%s.aws_security_group.%s", role, role), fmt.Sprintf("group name %s", name), func() (string, error) {
+				return findSecurityGroup(svc, &ec2.Filter{
+					Name:   aws.String("group-name"),
+					Values: []*string{aws.String(name)},
+				})
+			})
+		}
+
+		name := fmt.Sprintf("%s-bastion", stackName)
+		add("module.bastion.aws_instance.bastion", tagMatch("Name", name), func() (string, error) {
+			return findInstance(svc, tagFilter("Name", name))
+		})
+
+		if vault := c.InstancePool(clusterv1alpha1.InstancePoolTypeVault); vault != nil {
+			for pos := 0; pos < vault.MinCount(); pos++ {
+				name := fmt.Sprintf("%s-vault-%d", stackName, pos+1)
+				add(fmt.Sprintf("module.vault.aws_instance.vault[%d]", pos), tagMatch("Name", name), func() (string, error) {
+					return findInstance(svc, tagFilter("Name", name))
+				})
+			}
+		}
+	}
+
+	if c.Type() != clusterv1alpha1.ClusterTypeHub {
+		for _, instancePool := range c.InstancePools() {
+			role := instancePool.Role()
+			if !role.AWS.ELBAPI {
+				continue
+			}
+
+			// the names are truncated like in the terraform code
+			name := fmt.Sprintf("%.23s-api", stackName)
+			add(fmt.Sprintf("module.kubernetes.aws_elb.%s", role.TFName()), fmt.Sprintf("load balancer %s", name), func() (string, error) {
+				return a.findLoadBalancer(name)
+			})
+			if role.AWS.ELBAPIPublic {
+				name := fmt.Sprintf("%.19s-api-pub", stackName)
+				add(fmt.Sprintf("module.kubernetes.aws_elb.%s_public", role.TFName()), fmt.Sprintf("load balancer %s", name), func() (string, error) {
+					return a.findLoadBalancer(name)
+				})
+			}
+		}
+	}
+
+	return resources, result.ErrorOrNil()
+}
+
+func tagFilter(key, value string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(fmt.Sprintf("tag:%s", key)),
+		Values: []*string{aws.String(value)},
+	}
+}
+
+func tagMatch(key, value string) string {
+	return fmt.Sprintf("tag %s=%s", key, value)
+}
+
+// returns the single ID found, more than one match is an error as terraform
+// would only manage one of them
+func singleID(ids []string) (string, error) {
+	switch len(ids) {
+	case 0:
+		return "", nil
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found multiple matching resources: %s", strings.Join(ids, ", "))
+	}
+}
+
+func findVPC(svc EC2, filter *ec2.Filter) (string, error) {
+	output, err := svc.DescribeVpcs(&ec2.DescribeVpcsInput{Filters: []*ec2.Filter{filter}})
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, vpc := range output.Vpcs {
+		ids = append(ids, aws.StringValue(vpc.VpcId))
+	}
+	return singleID(ids)
+}
+
+func findInternetGateway(svc EC2, filter *ec2.Filter) (string, error) {
+	output, err := svc.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{Filters: []*ec2.Filter{filter}})
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, gateway := range output.InternetGateways {
+		ids = append(ids, aws.StringValue(gateway.InternetGatewayId))
+	}
+	return singleID(ids)
+}
+
+func findSubnet(svc EC2, filter *ec2.Filter) (string, error) {
+	output, err := svc.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: []*ec2.Filter{filter}})
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, subnet := range output.Subnets {
+		ids = append(ids, aws.StringValue(subnet.SubnetId))
+	}
+	return singleID(ids)
+}
+
+func findSecurityGroup(svc EC2, filter *ec2.Filter) (string, error) {
+	output, err := svc.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: []*ec2.Filter{filter}})
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, group := range output.SecurityGroups {
+		ids = append(ids, aws.StringValue(group.GroupId))
+	}
+	return singleID(ids)
+}
+
+// terminated instances are ignored
+func findInstance(svc EC2, filter *ec2.Filter) (string, error) {
+	output, err := svc.DescribeInstances(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter,
+			&ec2.Filter{
+				Name:   aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{"pending", "running", "stopping", "stopped"}),
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			ids = append(ids, aws.StringValue(instance.InstanceId))
+		}
+	}
+	return singleID(ids)
+}
+
+func (a *Amazon) findLoadBalancer(name string) (string, error) {
+	svc, err := a.ELB()
+	if err != nil {
+		return "", err
+	}
+
+	output, err := svc.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(name)},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elb.ErrCodeAccessPointNotFoundException {
+			return "", nil
+		}
+		return "", err
+	}
+
+	// load balancers are imported by name
+	var ids []string
+	for _, loadBalancer := range output.LoadBalancerDescriptions {
+		ids = append(ids, aws.StringValue(loadBalancer.LoadBalancerName))
+	}
+	return singleID(ids)
+}
+
+func (a *Amazon) findPrivateZone(name string) (string, error) {
+	svc, err := a.Route53()
+	if err != nil {
+		return "", err
+	}
+
+	dnsName := strings.TrimSuffix(name, ".") + "."
+	output, err := svc.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(dnsName),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// zones are sorted by name, so only the first ones can match
+	var ids []string
+	for _, zone := range output.HostedZones {
+		if aws.StringValue(zone.Name) != dnsName {
+			break
+		}
+		if zone.Config == nil || !aws.BoolValue(zone.Config.PrivateZone) {
+			continue
+		}
+		ids = append(ids, strings.TrimPrefix(aws.StringValue(zone.Id), "/hostedzone/"))
+	}
+	return singleID(ids)
+}
@@ -0,0 +1,91 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/golang/mock/gomock"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
+	"github.com/jetstack/tarmak/pkg/tarmak/role"
+)
+
+func TestAmazon_ImportableResources_ClusterMulti(t *testing.T) {
+	a := newFakeAmazon(t)
+	defer a.ctrl.Finish()
+
+	fakeELB := mocks.NewMockELB(a.ctrl)
+	a.Amazon.elb = fakeELB
+
+	master := (&role.Role{AWS: &role.RoleAWS{ELBAPI: true, ELBAPIPublic: true}}).WithName("master").WithPrefix("kubernetes")
+	worker := (&role.Role{AWS: &role.RoleAWS{}}).WithName("worker").WithPrefix("kubernetes")
+	var instancePools []interfaces.InstancePool
+	for _, r := range []*role.Role{master, worker} {
+		instancePool := mocks.NewMockInstancePool(a.ctrl)
+		instancePool.EXPECT().Role().AnyTimes().Return(r)
+		instancePools = append(instancePools, instancePool)
+	}
+
+	a.fakeCluster.EXPECT().Type().AnyTimes().Return(clusterv1alpha1.ClusterTypeClusterMulti)
+	a.fakeCluster.EXPECT().ClusterName().AnyTimes().Return("production-applications")
+	a.fakeCluster.EXPECT().InstancePools().AnyTimes().Return(instancePools)
+
+	fakeELB.EXPECT().DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: aws.StringSlice([]string{"production-applications-api"}),
+	}).Return(&elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+			{LoadBalancerName: aws.String("production-applications-api")},
+		},
+	}, nil)
+	fakeELB.EXPECT().DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: aws.StringSlice([]string{"production-applicat-api-pub"}),
+	}).Return(nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "not found", nil))
+
+	resources, err := a.ImportableResources(a.fakeCluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	exp := []*tarmakv1alpha1.ImportResource{
+		{
+			Address: "module.kubernetes.aws_elb.kubernetes_master",
+			ID:      "production-applications-api",
+			Match:   "load balancer production-applications-api",
+		},
+		{
+			Address: "module.kubernetes.aws_elb.kubernetes_master_public",
+			Match:   "load balancer production-applicat-api-pub",
+		},
+	}
+	if !reflect.DeepEqual(exp, resources) {
+		t.Errorf("unexpected resources:\nexp: %+v\nact: %+v", exp, resources)
+	}
+}
+
+func TestFindInstance_Multiple(t *testing.T) {
+	a := newFakeAmazon(t)
+	defer a.ctrl.Finish()
+
+	a.fakeEC2.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{
+			{Instances: []*ec2.Instance{
+				{InstanceId: aws.String("i-1")},
+				{InstanceId: aws.String("i-2")},
+			}},
+		},
+	}, nil)
+
+	_, err := findInstance(a.fakeEC2, tagFilter("Name", "dev-hub-bastion"))
+	if err == nil || !strings.Contains(err.Error(), "i-1, i-2") {
+		t.Errorf("expected error about multiple instances, got: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+
+// Package kmssign implements the KMS operations for asymmetric signing keys.
+// The vendored aws-sdk-go predates asymmetric KMS keys, so they are built on
+// top of its KMS client.
+package kmssign
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+const (
+	KeyUsageSignVerify          = "SIGN_VERIFY"
+	KeySpecECCNISTP256          = "ECC_NIST_P256"
+	MessageTypeDigest           = "DIGEST"
+	SigningAlgorithmECDSASHA256 = "ECDSA_SHA_256"
+)
+
+type CreateKeyInput struct {
+	_ struct{} `type:"structure"`
+
+	Description           *string    `type:"string"`
+	KeyUsage              *string    `type:"string"`
+	CustomerMasterKeySpec *string    `type:"string"`
+	Tags                  []*kms.Tag `type:"list"`
+}
+
+type SignInput struct {
+	_ struct{} `type:"structure"`
+
+	KeyId            *string `type:"string" required:"true"`
+	Message          []byte  `type:"blob" required:"true" sensitive:"true"`
+	MessageType      *string `type:"string"`
+	SigningAlgorithm *string `type:"string" required:"true"`
+}
+
+type SignOutput struct {
+	_ struct{} `type:"structure"`
+
+	KeyId            *string `type:"string"`
+	Signature        []byte  `type:"blob"`
+	SigningAlgorithm *string `type:"string"`
+}
+
+type GetPublicKeyInput struct {
+	_ struct{} `type:"structure"`
+
+	KeyId *string `type:"string" required:"true"`
+}
+
+type GetPublicKeyOutput struct {
+	_ struct{} `type:"structure"`
+
+	KeyId                 *string `type:"string"`
+	CustomerMasterKeySpec *string `type:"string"`
+	KeyUsage              *string `type:"string"`
+	PublicKey             []byte  `type:"blob"`
+}
+
+// Client extends the KMS client by the signing operations
+type Client struct {
+	*kms.KMS
+}
+
+func New(svc *kms.KMS) *Client {
+	return &Client{svc}
+}
+
+func (c *Client) send(name string, input, output interface{}) error {
+	req := c.NewRequest(&request.Operation{
+		Name:       name,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, input, output)
+	return req.Send()
+}
+
+// CreateSigningKey creates an asymmetric key, whose private key never leaves
+// KMS
+func (c *Client) CreateSigningKey(input *CreateKeyInput) (*kms.CreateKeyOutput, error) {
+	output := &kms.CreateKeyOutput{}
+	return output, c.send("CreateKey", input, output)
+}
+
+func (c *Client) Sign(input *SignInput) (*SignOutput, error) {
+	output := &SignOutput{}
+	return output, c.send("Sign", input, output)
+}
+
+func (c *Client) GetPublicKey(input *GetPublicKeyInput) (*GetPublicKeyOutput, error) {
+	output := &GetPublicKeyOutput{}
+	return output, c.send("GetPublicKey", input, output)
+}
@@ -0,0 +1,134 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kms"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/provider/amazon/kmssign"
+	"github.com/jetstack/tarmak/pkg/wing/signature"
+)
+
+func (a *Amazon) manifestSigningKMSName(cluster interfaces.Cluster) string {
+	return fmt.Sprintf("alias/%s-%s-manifest-signing", a.RemoteStateName(), cluster.Environment().Name())
+}
+
+// This signs a puppet manifest of the cluster with the environment's
+// asymmetric KMS key. The private key never leaves KMS.
+func (a *Amazon) SignManifest(cluster interfaces.Cluster, manifest []byte) ([]byte, error) {
+	svc, err := a.KMS()
+	if err != nil {
+		return nil, err
+	}
+
+	keyName := a.manifestSigningKMSName(cluster)
+	out, err := svc.Sign(&kmssign.SignInput{
+		KeyId:            aws.String(keyName),
+		Message:          signature.Digest(manifest),
+		MessageType:      aws.String(kmssign.MessageTypeDigest),
+		SigningAlgorithm: aws.String(kmssign.SigningAlgorithmECDSASHA256),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error signing manifest with kms key '%s': %s", keyName, err)
+	}
+
+	return signature.Encode(out.Signature), nil
+}
+
+// This returns the PEM encoded public key instances verify puppet manifests
+// against. The environment's KMS signing key is created if it doesn't exist
+// yet.
+func (a *Amazon) ManifestSigningPublicKey(cluster interfaces.Cluster) ([]byte, error) {
+	keyName := a.manifestSigningKMSName(cluster)
+	if pubPEM, ok := a.manifestSigningPublicKeys[keyName]; ok {
+		return pubPEM, nil
+	}
+
+	svc, err := a.KMS()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := svc.GetPublicKey(&kmssign.GetPublicKeyInput{
+		KeyId: aws.String(keyName),
+	})
+	if err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if !ok || !strings.Contains(awsErr.Code(), "NotFound") {
+			return nil, fmt.Errorf("error getting public key of kms key '%s': %s", keyName, err)
+		}
+
+		if err := a.initManifestSigningKMS(cluster); err != nil {
+			return nil, fmt.Errorf("error creating kms key '%s': %s", keyName, err)
+		}
+
+		out, err = svc.GetPublicKey(&kmssign.GetPublicKeyInput{
+			KeyId: aws.String(keyName),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting public key of kms key '%s': %s", keyName, err)
+		}
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(out.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key of kms key '%s': %s", keyName, err)
+	}
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T of kms key '%s', expected ECDSA", pub, keyName)
+	}
+
+	pubPEM, err := signature.EncodePublicKey(ecdsaPub)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.manifestSigningPublicKeys == nil {
+		a.manifestSigningPublicKeys = make(map[string][]byte)
+	}
+	a.manifestSigningPublicKeys[keyName] = pubPEM
+
+	return pubPEM, nil
+}
+
+func (a *Amazon) initManifestSigningKMS(cluster interfaces.Cluster) error {
+	svc, err := a.KMS()
+	if err != nil {
+		return err
+	}
+
+	a.log.Infof("creating manifest signing kms key '%s'", a.manifestSigningKMSName(cluster))
+
+	k, err := svc.CreateSigningKey(&kmssign.CreateKeyInput{
+		Description:           aws.String(fmt.Sprintf("KMS key signing puppet manifests of Tarmak environment '%s'", cluster.Environment().Name())),
+		KeyUsage:              aws.String(kmssign.KeyUsageSignVerify),
+		CustomerMasterKeySpec: aws.String(kmssign.KeySpecECCNISTP256),
+		Tags: []*kms.Tag{
+			&kms.Tag{
+				TagKey:   aws.String("provider"),
+				TagValue: aws.String(a.Name()),
+			},
+			&kms.Tag{
+				TagKey:   aws.String("environment"),
+				TagValue: aws.String(cluster.Environment().Name()),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.CreateAlias(&kms.CreateAliasInput{
+		TargetKeyId: aws.String(*k.KeyMetadata.KeyId),
+		AliasName:   aws.String(a.manifestSigningKMSName(cluster)),
+	})
+	return err
+}
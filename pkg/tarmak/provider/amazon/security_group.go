@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"net"
 
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
 	"github.com/jetstack/tarmak/pkg/tarmak/cluster/firewall"
 	"github.com/jetstack/tarmak/pkg/tarmak/role"
 )
 
-const masterELB = "master_elb"
+const (
+	masterELB = "master_elb"
+	workerELB = "worker_elb"
+)
 
 type AWSSGRule struct {
 	Comment     string
@@ -39,11 +43,13 @@ func awsGroupID(role string) string {
 	}
 }
 
-func GenerateAWSRules(role *role.Role) (awsRules []*AWSSGRule, err error) {
+func GenerateAWSRules(role *role.Role, kubernetes *clusterv1alpha1.ClusterKubernetes) (awsRules []*AWSSGRule, err error) {
 	// Get all firewall rules where the role is mentioned in the destination
-	for _, rule := range firewall.Rules() {
+	for _, rule := range firewall.Rules(kubernetes) {
 		for _, destination := range rule.Destinations {
-			if destination.Role == role.Name() || (role.Name() == "master" && destination.Role == masterELB) {
+			if destination.Role == role.Name() ||
+				(role.Name() == "master" && destination.Role == masterELB) ||
+				(role.Name() == "worker" && destination.Role == workerELB) {
 				awsRules = append(awsRules, generateFromRule(rule, role, &destination)...)
 			}
 		}
@@ -92,7 +98,7 @@ func generateFromRule(rule *firewall.Rule, role *role.Role, destination *firewal
 				}
 
 				// if the role is elb then add elb to destination name
-				if destination.Role == masterELB {
+				if destination.Role == masterELB || destination.Role == workerELB {
 					awsRule.Destination = fmt.Sprintf("%s_elb", role.TFName())
 				} else {
 					awsRule.Destination = role.TFName()
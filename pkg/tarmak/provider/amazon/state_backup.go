@@ -0,0 +1,209 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+)
+
+const (
+	stateBackupDir        = "terraform-state"
+	stateBackupSuffix     = ".tfstate"
+	stateBackupNameFormat = "20060102T150405Z"
+)
+
+// the backups bucket and key are created by the state module of the hub, so
+// they only exist in the hub's region
+func (a *Amazon) backupsBucketName(environment string) string {
+	return fmt.Sprintf("%s%s-%s-backups", a.conf.Amazon.BucketPrefix, environment, a.hubRegion())
+}
+
+func (a *Amazon) backupsKMSName(environment string) string {
+	return fmt.Sprintf("alias/tarmak/%s/backups", environment)
+}
+
+func stateBackupPrefix(cluster interfaces.Cluster) string {
+	return path.Join(stateBackupDir, cluster.Name()) + "/"
+}
+
+// BackupState stores a copy of the terraform state of the cluster in the
+// backups bucket of its environment. Backups are named after the time they
+// have been taken and expire with the lifecycle of the bucket.
+func (a *Amazon) BackupState(cluster interfaces.Cluster, state []byte) (*tarmakv1alpha1.StateBackup, error) {
+	svc, err := a.hubS3()
+	if err != nil {
+		return nil, err
+	}
+
+	environment := cluster.Environment().Name()
+	bucketName := a.backupsBucketName(environment)
+	if err := a.ensureBackupsBucket(svc, bucketName); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	name := now.Format(stateBackupNameFormat)
+	key := stateBackupPrefix(cluster) + name + stateBackupSuffix
+
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String(bucketName),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(state),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+		SSEKMSKeyId:          aws.String(a.backupsKMSName(environment)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error writing state backup 's3://%s/%s': %s", bucketName, key, err)
+	}
+
+	return &tarmakv1alpha1.StateBackup{
+		Name:      name,
+		Path:      fmt.Sprintf("s3://%s/%s", bucketName, key),
+		Size:      int64(len(state)),
+		Timestamp: metav1.NewTime(now),
+	}, nil
+}
+
+// StateBackups lists the terraform state backups of the cluster, oldest first
+func (a *Amazon) StateBackups(cluster interfaces.Cluster) ([]*tarmakv1alpha1.StateBackup, error) {
+	svc, err := a.hubS3()
+	if err != nil {
+		return nil, err
+	}
+
+	bucketName := a.backupsBucketName(cluster.Environment().Name())
+	if err := a.ensureBackupsBucket(svc, bucketName); err != nil {
+		return nil, err
+	}
+
+	prefix := stateBackupPrefix(cluster)
+	input := &s3.ListObjectsInput{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	}
+
+	var backups []*tarmakv1alpha1.StateBackup
+	for {
+		output, err := svc.ListObjects(input)
+		if err != nil {
+			return nil, fmt.Errorf("error listing state backups in 's3://%s/%s': %s", bucketName, prefix, err)
+		}
+
+		for _, object := range output.Contents {
+			key := aws.StringValue(object.Key)
+			if !strings.HasSuffix(key, stateBackupSuffix) {
+				continue
+			}
+			backups = append(backups, &tarmakv1alpha1.StateBackup{
+				Name:      strings.TrimSuffix(strings.TrimPrefix(key, prefix), stateBackupSuffix),
+				Path:      fmt.Sprintf("s3://%s/%s", bucketName, key),
+				Size:      aws.Int64Value(object.Size),
+				Timestamp: metav1.NewTime(aws.TimeValue(object.LastModified)),
+			})
+		}
+
+		if !aws.BoolValue(output.IsTruncated) || len(output.Contents) == 0 {
+			break
+		}
+		input.Marker = output.Contents[len(output.Contents)-1].Key
+	}
+
+	// names sort by the time the backups have been taken
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name < backups[j].Name
+	})
+
+	return backups, nil
+}
+
+// ReadStateBackup returns the terraform state stored in the named backup of
+// the cluster
+func (a *Amazon) ReadStateBackup(cluster interfaces.Cluster, name string) ([]byte, error) {
+	svc, err := a.hubS3()
+	if err != nil {
+		return nil, err
+	}
+
+	bucketName := a.backupsBucketName(cluster.Environment().Name())
+	key := stateBackupPrefix(cluster) + name + stateBackupSuffix
+
+	state, err := readObject(svc, bucketName, key)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, fmt.Errorf("state backup '%s' of cluster %s not found", name, cluster.ClusterName())
+		}
+		return nil, fmt.Errorf("error reading state backup 's3://%s/%s': %s", bucketName, key, err)
+	}
+
+	return state, nil
+}
+
+// ReadRemoteState reads a terraform state from any bucket accessible with the
+// credentials of the provider, regardless of the bucket's region
+func (a *Amazon) ReadRemoteState(bucket, key string) ([]byte, error) {
+	svc, err := a.S3()
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := svc.GetBucketLocation(&s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error finding region of bucket '%s': %s", bucket, err)
+	}
+
+	if region := s3.NormalizeBucketLocation(aws.StringValue(location.LocationConstraint)); region != a.Region() {
+		sess, err := a.Session()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Amazon session: %s", err)
+		}
+		svc = s3.New(sess, aws.NewConfig().WithRegion(region))
+	}
+
+	state, err := readObject(svc, bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("error reading state 's3://%s/%s': %s", bucket, key, err)
+	}
+
+	return state, nil
+}
+
+func (a *Amazon) ensureBackupsBucket(svc S3, bucketName string) error {
+	_, err := svc.HeadBucket(&s3.HeadBucketInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err == nil {
+		return nil
+	} else if strings.HasPrefix(err.Error(), "NotFound:") {
+		return fmt.Errorf("backups bucket '%s' doesn't exist, it is created when applying the hub", bucketName)
+	}
+
+	return fmt.Errorf("error while checking backups bucket '%s': %s", bucketName, err)
+}
+
+func readObject(svc S3, bucket, key string) ([]byte, error) {
+	obj, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+
+	return ioutil.ReadAll(obj.Body)
+}
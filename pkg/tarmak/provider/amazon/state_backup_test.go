@@ -0,0 +1,96 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
+)
+
+func TestAmazon_StateBackups(t *testing.T) {
+	a := newFakeAmazon(t)
+	defer a.ctrl.Finish()
+
+	fakeS3 := mocks.NewMockS3(a.ctrl)
+	a.Amazon.s3Hub = fakeS3
+	a.Amazon.conf.Amazon.BucketPrefix = "tarmak-"
+
+	a.fakeEnvironment.EXPECT().Name().AnyTimes().Return("production")
+	a.fakeEnvironment.EXPECT().Location().AnyTimes().Return("eu-west-1")
+	// the backups bucket is in the hub's region, also for clusters in others
+	a.fakeCluster.EXPECT().Region().AnyTimes().Return("us-west-2")
+	a.fakeCluster.EXPECT().Name().AnyTimes().Return("applications")
+
+	bucket := "tarmak-production-eu-west-1-backups"
+	fakeS3.EXPECT().HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(bucket)}).Return(&s3.HeadBucketOutput{}, nil)
+
+	modified := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	gomock.InOrder(
+		fakeS3.EXPECT().ListObjects(&s3.ListObjectsInput{
+			Bucket: aws.String(bucket),
+			Prefix: aws.String("terraform-state/applications/"),
+		}).Return(&s3.ListObjectsOutput{
+			IsTruncated: aws.Bool(true),
+			Contents: []*s3.Object{
+				{Key: aws.String("terraform-state/applications/20180601T120000Z.tfstate"), Size: aws.Int64(1024), LastModified: aws.Time(modified)},
+				{Key: aws.String("terraform-state/applications/README"), Size: aws.Int64(12), LastModified: aws.Time(modified)},
+			},
+		}, nil),
+		fakeS3.EXPECT().ListObjects(&s3.ListObjectsInput{
+			Bucket: aws.String(bucket),
+			Prefix: aws.String("terraform-state/applications/"),
+			Marker: aws.String("terraform-state/applications/README"),
+		}).Return(&s3.ListObjectsOutput{
+			IsTruncated: aws.Bool(false),
+			Contents: []*s3.Object{
+				{Key: aws.String("terraform-state/applications/20180101T080000Z.tfstate"), Size: aws.Int64(512), LastModified: aws.Time(modified)},
+			},
+		}, nil),
+	)
+
+	backups, err := a.StateBackups(a.fakeCluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backups))
+	}
+	if exp, act := "20180101T080000Z", backups[0].Name; exp != act {
+		t.Errorf("expected oldest backup %s, got %s", exp, act)
+	}
+	if exp, act := "s3://tarmak-production-eu-west-1-backups/terraform-state/applications/20180601T120000Z.tfstate", backups[1].Path; exp != act {
+		t.Errorf("expected path %s, got %s", exp, act)
+	}
+	if exp, act := int64(1024), backups[1].Size; exp != act {
+		t.Errorf("expected size %d, got %d", exp, act)
+	}
+}
+
+func TestAmazon_StateBackups_NoBucket(t *testing.T) {
+	a := newFakeAmazon(t)
+	defer a.ctrl.Finish()
+
+	fakeS3 := mocks.NewMockS3(a.ctrl)
+	a.Amazon.s3Hub = fakeS3
+
+	a.fakeEnvironment.EXPECT().Name().AnyTimes().Return("production")
+	a.fakeEnvironment.EXPECT().Location().AnyTimes().Return("eu-west-1")
+
+	fakeS3.EXPECT().HeadBucket(gomock.Any()).Return(nil, s3NotFoundError{})
+
+	if _, err := a.StateBackups(a.fakeCluster); err == nil {
+		t.Error("expected an error for a missing backups bucket")
+	}
+}
+
+type s3NotFoundError struct{}
+
+func (s3NotFoundError) Error() string {
+	return "NotFound: Not Found"
+}
@@ -0,0 +1,74 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
+)
+
+// lock metadata as written by terraform's s3 backend
+type stateLockInfo struct {
+	ID        string
+	Operation string
+	Info      string
+	Who       string
+	Version   string
+	Created   time.Time
+	Path      string
+}
+
+// StateLock reads the lock held on the terraform state of the cluster from the
+// DynamoDB lock table, it returns nil if the state is not locked
+func (a *Amazon) StateLock(cluster interfaces.Cluster) (*tarmakv1alpha1.StateLock, error) {
+	svc, err := a.DynamoDB()
+	if err != nil {
+		return nil, err
+	}
+
+	lockID := fmt.Sprintf("%s/%s", a.RemoteStateName(), a.RemoteStateObjectKey(cluster.Environment().Name(), cluster.Name()))
+	output, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(a.RemoteStateName()),
+		Key: map[string]*dynamodb.AttributeValue{
+			DynamoDBKey: {S: aws.String(lockID)},
+		},
+		ProjectionExpression: aws.String("LockID, Info"),
+		ConsistentRead:       aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading lock '%s': %s", lockID, err)
+	}
+
+	value, ok := output.Item["Info"]
+	if !ok || value.S == nil {
+		return nil, nil
+	}
+
+	info := &stateLockInfo{}
+	if err := json.Unmarshal([]byte(aws.StringValue(value.S)), info); err != nil {
+		return nil, fmt.Errorf("error parsing lock '%s': %s", lockID, err)
+	}
+
+	lock := &tarmakv1alpha1.StateLock{
+		ID:               info.ID,
+		Operation:        info.Operation,
+		Who:              info.Who,
+		TerraformVersion: info.Version,
+		Created:          metav1.NewTime(info.Created),
+		Path:             info.Path,
+	}
+	if strings.HasPrefix(info.Info, consts.TerraformLockInfoPrefix) {
+		lock.TarmakVersion = strings.TrimPrefix(info.Info, consts.TerraformLockInfoPrefix)
+	}
+
+	return lock, nil
+}
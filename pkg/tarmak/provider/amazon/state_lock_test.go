@@ -0,0 +1,83 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang/mock/gomock"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
+)
+
+func newFakeAmazonStateLock(t *testing.T) (*fakeAmazon, *mocks.MockDynamoDB) {
+	a := newFakeAmazon(t)
+
+	fakeDynamoDB := mocks.NewMockDynamoDB(a.ctrl)
+	a.Amazon.dynamodb = fakeDynamoDB
+	a.Amazon.conf.Amazon.BucketPrefix = "tarmak-"
+
+	a.fakeEnvironment.EXPECT().Name().AnyTimes().Return("production")
+	a.fakeCluster.EXPECT().Region().AnyTimes().Return("eu-west-1")
+	a.fakeCluster.EXPECT().Name().AnyTimes().Return("applications")
+
+	return a, fakeDynamoDB
+}
+
+func TestAmazon_StateLock(t *testing.T) {
+	a, fakeDynamoDB := newFakeAmazonStateLock(t)
+	defer a.ctrl.Finish()
+
+	fakeDynamoDB.EXPECT().GetItem(gomock.Any()).DoAndReturn(func(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+		if exp, act := "tarmak-eu-west-1-terraform-state", aws.StringValue(input.TableName); exp != act {
+			t.Errorf("expected table %s, got %s", exp, act)
+		}
+		if exp, act := "tarmak-eu-west-1-terraform-state/production/applications/main.tfstate", aws.StringValue(input.Key[DynamoDBKey].S); exp != act {
+			t.Errorf("expected lock ID %s, got %s", exp, act)
+		}
+		return &dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				DynamoDBKey: {S: input.Key[DynamoDBKey].S},
+				"Info":      {S: aws.String(`{"ID":"b3c1ad0e-5f8c-2a0e-7e0b-0d1c5c1a6d0e","Operation":"OperationTypeApply","Info":"tarmak 0.5.0","Who":"jane@laptop","Version":"0.11.7","Created":"2018-06-01T12:00:00Z","Path":"tarmak-eu-west-1-terraform-state/production/applications/main.tfstate"}`)},
+			},
+		}, nil
+	})
+
+	lock, err := a.StateLock(a.fakeCluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lock == nil {
+		t.Fatal("expected a lock")
+	}
+
+	if exp, act := "b3c1ad0e-5f8c-2a0e-7e0b-0d1c5c1a6d0e", lock.ID; exp != act {
+		t.Errorf("expected ID %s, got %s", exp, act)
+	}
+	if exp, act := "0.5.0", lock.TarmakVersion; exp != act {
+		t.Errorf("expected tarmak version %s, got %s", exp, act)
+	}
+	if exp, act := "0.11.7", lock.TerraformVersion; exp != act {
+		t.Errorf("expected terraform version %s, got %s", exp, act)
+	}
+	if exp, act := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC), lock.Created.Time; !exp.Equal(act) {
+		t.Errorf("expected created %s, got %s", exp, act)
+	}
+}
+
+func TestAmazon_StateLock_Unlocked(t *testing.T) {
+	a, fakeDynamoDB := newFakeAmazonStateLock(t)
+	defer a.ctrl.Finish()
+
+	fakeDynamoDB.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+
+	lock, err := a.StateLock(a.fakeCluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lock != nil {
+		t.Errorf("expected no lock, got %+v", lock)
+	}
+}
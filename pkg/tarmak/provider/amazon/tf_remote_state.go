@@ -13,11 +13,17 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
+// RemoteStateName returns the name of the bucket and lock table holding the
+// terraform state in the region of the current cluster
 func (a *Amazon) RemoteStateName() string {
+	return a.remoteStateName(a.Region())
+}
+
+func (a *Amazon) remoteStateName(region string) string {
 	return fmt.Sprintf(
 		"%s%s-terraform-state",
 		a.conf.Amazon.BucketPrefix,
-		a.Region(),
+		region,
 	)
 }
 
@@ -3,20 +3,49 @@ package amazon
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/s3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	tarmakv1alpha1 "github.com/jetstack/tarmak/pkg/apis/tarmak/v1alpha1"
 	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/wing/signature"
 )
 
+const (
+	manifestDir           = "puppet-manifests"
+	manifestHistoryObject = "manifest-history.json"
+
+	// locks older than this are left behind by a crashed tarmak and are
+	// taken over
+	manifestHistoryLockTimeout = 5 * time.Minute
+	manifestHistoryLockRetry   = time.Second
+)
+
+// the secrets bucket is only created in the region of the hub, clusters in
+// other regions use it as well
+func (a *Amazon) secretsBucketName(cluster interfaces.Cluster) string {
+	return fmt.Sprintf(
+		"%s%s-%s-secrets",
+		a.conf.Amazon.BucketPrefix,
+		cluster.Environment().Name(),
+		a.hubRegion(),
+	)
+}
+
 // This uploads the main configuration to the S3 bucket
-func (a *Amazon) UploadConfiguration(cluster interfaces.Cluster, stateFile io.ReadSeeker, md5Hash string) error {
-	svcKMS, err := a.KMS()
+func (a *Amazon) UploadConfiguration(cluster interfaces.Cluster, stateFile io.ReadSeeker, md5Hash string, manifestSignature []byte) error {
+	svcKMS, err := a.hubKMS()
 	if err != nil {
 		return err
 	}
@@ -28,14 +57,9 @@ func (a *Amazon) UploadConfiguration(cluster interfaces.Cluster, stateFile io.Re
 		return fmt.Errorf("error looking for tarmak secrets kms alias '%s': %s", a.SecretsKMSName(), err)
 	}
 
-	bucketName := fmt.Sprintf(
-		"%s%s-%s-secrets",
-		a.conf.Amazon.BucketPrefix,
-		cluster.Environment().Name(),
-		a.Region(),
-	)
+	bucketName := a.secretsBucketName(cluster)
 
-	svc, err := a.S3()
+	svc, err := a.hubS3()
 	if err != nil {
 		return err
 	}
@@ -56,7 +80,7 @@ func (a *Amazon) UploadConfiguration(cluster interfaces.Cluster, stateFile io.Re
 		return fmt.Errorf("failed to rewind puppet state file: %s", err)
 	}
 
-	dirPath := filepath.Join(cluster.ClusterName(), "puppet-manifests")
+	dirPath := filepath.Join(cluster.ClusterName(), manifestDir)
 	hashPointerKey := filepath.Join(dirPath, "latest-puppet-hash")
 	manifestKey = filepath.Join(dirPath, fmt.Sprintf("%s-puppet.tar.gz", md5Hash))
 	_, err = svc.PutObject(&s3.PutObjectInput{
@@ -70,6 +94,16 @@ func (a *Amazon) UploadConfiguration(cluster interfaces.Cluster, stateFile io.Re
 		return err
 	}
 
+	// the signature has to be in place before the hash pointer is updated
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(manifestKey + signature.Suffix),
+		Body:   bytes.NewReader(manifestSignature),
+	})
+	if err != nil {
+		return err
+	}
+
 	_, err = svc.PutObject(&s3.PutObjectInput{
 		Bucket: aws.String(bucketName),
 		Key:    aws.String(hashPointerKey),
@@ -81,3 +115,142 @@ func (a *Amazon) UploadConfiguration(cluster interfaces.Cluster, stateFile io.Re
 
 	return nil
 }
+
+// This records an uploaded manifest in the manifest history of the cluster
+func (a *Amazon) RecordConfiguration(cluster interfaces.Cluster, md5Hash, hash string) error {
+	unlock, err := a.lockConfigurationHistory(cluster)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	history, err := a.ConfigurationHistory(cluster)
+	if err != nil {
+		return err
+	}
+
+	// skip if the manifest is already the latest entry
+	if len(history) > 0 && history[len(history)-1].Hash == hash {
+		return nil
+	}
+
+	bucketName := a.secretsBucketName(cluster)
+	history = append(history, &tarmakv1alpha1.ManifestHistoryEntry{
+		Hash: hash,
+		MD5:  md5Hash,
+		Path: fmt.Sprintf(
+			"s3://%s/%s",
+			bucketName,
+			filepath.Join(cluster.ClusterName(), manifestDir, fmt.Sprintf("%s-puppet.tar.gz", md5Hash)),
+		),
+		Timestamp: metav1.NewTime(time.Now()),
+	})
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("error marshalling manifest history: %s", err)
+	}
+
+	svc, err := a.hubS3()
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(filepath.Join(cluster.ClusterName(), manifestDir, manifestHistoryObject)),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing manifest history: %s", err)
+	}
+
+	return nil
+}
+
+// This returns the history of uploaded manifests of the cluster, oldest first
+func (a *Amazon) ConfigurationHistory(cluster interfaces.Cluster) ([]*tarmakv1alpha1.ManifestHistoryEntry, error) {
+	var history []*tarmakv1alpha1.ManifestHistoryEntry
+
+	svc, err := a.hubS3()
+	if err != nil {
+		return nil, err
+	}
+
+	key := filepath.Join(cluster.ClusterName(), manifestDir, manifestHistoryObject)
+	obj, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(a.secretsBucketName(cluster)),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return history, nil
+		}
+		return nil, fmt.Errorf("error reading manifest history '%s': %s", key, err)
+	}
+	defer obj.Body.Close()
+
+	data, err := ioutil.ReadAll(obj.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest history '%s': %s", key, err)
+	}
+
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("error parsing manifest history '%s': %s", key, err)
+	}
+
+	return history, nil
+}
+
+// lockConfigurationHistory takes a lock on the manifest history of the
+// cluster in the DynamoDB table of the remote state in the hub's region, so
+// concurrent applies don't drop each other's entries
+func (a *Amazon) lockConfigurationHistory(cluster interfaces.Cluster) (unlock func(), err error) {
+	svc, err := a.hubDynamoDB()
+	if err != nil {
+		return nil, err
+	}
+
+	lockID := fmt.Sprintf("%s/%s", a.secretsBucketName(cluster), filepath.Join(cluster.ClusterName(), manifestDir, manifestHistoryObject))
+	key := map[string]*dynamodb.AttributeValue{
+		DynamoDBKey: {S: aws.String(lockID)},
+	}
+
+	deadline := time.Now().Add(manifestHistoryLockTimeout)
+	for {
+		now := time.Now()
+		_, err = svc.PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String(a.remoteStateName(a.hubRegion())),
+			Item: map[string]*dynamodb.AttributeValue{
+				DynamoDBKey: {S: aws.String(lockID)},
+				"Created":   {N: aws.String(fmt.Sprintf("%d", now.Unix()))},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(LockID) OR Created < :stale"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":stale": {N: aws.String(fmt.Sprintf("%d", now.Add(-manifestHistoryLockTimeout).Unix()))},
+			},
+		})
+		if err == nil {
+			break
+		}
+
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil, fmt.Errorf("error locking manifest history '%s': %s", lockID, err)
+		}
+		if now.After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock on manifest history '%s'", lockID)
+		}
+
+		a.log.Debugf("waiting for lock on manifest history '%s'", lockID)
+		time.Sleep(manifestHistoryLockRetry)
+	}
+
+	return func() {
+		if _, err := svc.DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String(a.remoteStateName(a.hubRegion())),
+			Key:       key,
+		}); err != nil {
+			a.log.Warnf("error unlocking manifest history '%s': %s", lockID, err)
+		}
+	}, nil
+}
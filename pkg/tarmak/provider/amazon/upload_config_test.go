@@ -0,0 +1,55 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package amazon
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang/mock/gomock"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
+)
+
+func TestAmazon_lockConfigurationHistory(t *testing.T) {
+	a := newFakeAmazon(t)
+	defer a.ctrl.Finish()
+
+	fakeDynamoDB := mocks.NewMockDynamoDB(a.ctrl)
+	a.Amazon.dynamodbHub = fakeDynamoDB
+
+	a.fakeEnvironment.EXPECT().Name().Return("env").AnyTimes()
+	a.fakeEnvironment.EXPECT().Location().Return("eu-west-1").AnyTimes()
+	a.fakeCluster.EXPECT().ClusterName().Return("env-cluster").AnyTimes()
+
+	lockID := "env-eu-west-1-secrets/env-cluster/puppet-manifests/manifest-history.json"
+	put := func(input *dynamodb.PutItemInput) {
+		if exp, act := "eu-west-1-terraform-state", aws.StringValue(input.TableName); exp != act {
+			t.Errorf("unexpected table, exp=%s act=%s", exp, act)
+		}
+		if exp, act := lockID, aws.StringValue(input.Item[DynamoDBKey].S); exp != act {
+			t.Errorf("unexpected lock id, exp=%s act=%s", exp, act)
+		}
+		if input.ConditionExpression == nil {
+			t.Error("expected a conditional put")
+		}
+	}
+
+	// the lock is held by another apply first
+	gomock.InOrder(
+		fakeDynamoDB.EXPECT().PutItem(gomock.Any()).Do(put).Return(nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "held", nil)),
+		fakeDynamoDB.EXPECT().PutItem(gomock.Any()).Do(put).Return(&dynamodb.PutItemOutput{}, nil),
+		fakeDynamoDB.EXPECT().DeleteItem(gomock.Any()).Do(func(input *dynamodb.DeleteItemInput) {
+			if exp, act := lockID, aws.StringValue(input.Key[DynamoDBKey].S); exp != act {
+				t.Errorf("unexpected lock id, exp=%s act=%s", exp, act)
+			}
+		}).Return(&dynamodb.DeleteItemOutput{}, nil),
+	)
+
+	unlock, err := a.lockConfigurationHistory(a.fakeCluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	unlock()
+}
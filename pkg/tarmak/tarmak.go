@@ -20,12 +20,14 @@ import (
 	"github.com/jetstack/tarmak/pkg/puppet"
 	"github.com/jetstack/tarmak/pkg/tarmak/assets"
 	"github.com/jetstack/tarmak/pkg/tarmak/config"
+	"github.com/jetstack/tarmak/pkg/tarmak/encryption"
 	"github.com/jetstack/tarmak/pkg/tarmak/initialize"
 	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
 	"github.com/jetstack/tarmak/pkg/tarmak/kubectl"
 	"github.com/jetstack/tarmak/pkg/tarmak/logs"
 	"github.com/jetstack/tarmak/pkg/tarmak/ssh"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/input"
 	"github.com/jetstack/tarmak/pkg/terraform"
 	pkgversion "github.com/jetstack/tarmak/pkg/version"
 )
@@ -38,14 +40,15 @@ type Tarmak struct {
 	configDirectory string
 	ctx             interfaces.CancellationContext
 
-	config    interfaces.Config
-	terraform *terraform.Terraform
-	puppet    *puppet.Puppet
-	packer    *packer.Packer
-	ssh       interfaces.SSH
-	init      *initialize.Initialize
-	kubectl   *kubectl.Kubectl
-	logs      *logs.Logs
+	config     interfaces.Config
+	terraform  *terraform.Terraform
+	puppet     *puppet.Puppet
+	packer     *packer.Packer
+	ssh        interfaces.SSH
+	init       *initialize.Initialize
+	kubectl    *kubectl.Kubectl
+	logs       *logs.Logs
+	encryption *encryption.Encryption
 
 	environment interfaces.Environment
 	cluster     interfaces.Cluster
@@ -143,6 +146,7 @@ func (t *Tarmak) initializeModules() {
 	t.puppet = puppet.New(t)
 	t.kubectl = kubectl.New(t)
 	t.logs = logs.New(t)
+	t.encryption = encryption.New(t)
 }
 
 // Initialize default cluster, its environment and provider
@@ -189,7 +193,19 @@ func (t *Tarmak) writeSSHConfigForClusterHosts() error {
 func (t *Tarmak) CmdClusterInit() error {
 	i := initialize.New(t, os.Stdin, os.Stdout)
 	t.init = i
-	cluster, err := i.InitCluster()
+
+	var cluster interfaces.Cluster
+	var err error
+	if initialize.SpecRequested(&t.flags.Init) {
+		var spec *initialize.Spec
+		spec, err = initialize.NewSpec(&t.flags.Init)
+		if err != nil {
+			return err
+		}
+		cluster, err = i.InitFromSpec(spec)
+	} else {
+		cluster, err = i.InitCluster()
+	}
 	if err != nil {
 		return err
 	}
@@ -242,6 +258,93 @@ func (t *Tarmak) CmdProviderInit() error {
 	return nil
 }
 
+// CmdConfigMigrate splits tarmak.yaml into the directory layout
+func (t *Tarmak) CmdConfigMigrate() error {
+	return t.config.Migrate()
+}
+
+// CmdConfigValidate runs the validation of all providers, environments and
+// clusters, without contacting the cloud provider
+func (t *Tarmak) CmdConfigValidate() error {
+	if len(t.config.Providers()) == 0 && len(t.config.Environments()) == 0 {
+		return errors.New("no configuration found, run 'tarmak init'")
+	}
+
+	var result *multierror.Error
+
+	for _, providerConf := range t.config.Providers() {
+		provider, err := t.ProviderByName(providerConf.Name)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		if err := provider.Validate(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("provider '%s': %s", provider.Name(), err))
+		}
+	}
+
+	for _, environmentConf := range t.config.Environments() {
+		environment, err := t.EnvironmentByName(environmentConf.Name)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		// validations refer to the current environment
+		t.environment = environment
+		if err := environment.Validate(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("environment '%s': %s", environment.Name(), err))
+		}
+
+		for _, cluster := range environment.Clusters() {
+			if err := cluster.Validate(); err != nil {
+				result = multierror.Append(result, fmt.Errorf("cluster '%s': %s", cluster.ClusterName(), err))
+			}
+		}
+	}
+
+	if err := result.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	t.log.Infof("configuration is valid")
+	return nil
+}
+
+// CmdConfigUpgrade converts the configuration to the latest schema versions,
+// after showing the changes
+func (t *Tarmak) CmdConfigUpgrade() error {
+	diff, err := t.config.Upgrade(true)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		t.log.Infof("configuration is up to date")
+		return nil
+	}
+	fmt.Print(diff)
+
+	if !t.flags.Config.Upgrade.AutoApprove {
+		approved, err := input.New(os.Stdin, os.Stdout).AskYesNo(&input.AskYesNo{
+			Default: false,
+			Query:   "Write the upgraded configuration?",
+		})
+		if err != nil {
+			return err
+		}
+		if !approved {
+			return errors.New("not upgrading configuration")
+		}
+	}
+
+	if _, err := t.config.Upgrade(false); err != nil {
+		return err
+	}
+
+	t.log.Infof("upgraded configuration to the latest schema")
+	return nil
+}
+
 func (t *Tarmak) Puppet() interfaces.Puppet {
 	return t.puppet
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/jetstack/tarmak/pkg/tarmak/mocks"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils"
 	"github.com/jetstack/tarmak/pkg/terraform"
+	"github.com/jetstack/tarmak/pkg/wing/signature"
 )
 
 var (
@@ -52,6 +53,15 @@ func (tt *testTarmak) fakeAWSProvider(name string) {
 	baseImage := &tarmakv1alpha1.Image{}
 	baseImage.Name = "ami-6e28b517"
 
+	manifestSigningKey, err := signature.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	manifestSigningPublicKey, err := signature.EncodePublicKey(&manifestSigningKey.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+
 	tt.fakeProvider.EXPECT().Name().AnyTimes().Return(name)
 	tt.fakeProvider.EXPECT().Cloud().AnyTimes().Return("amazon")
 	tt.fakeProvider.EXPECT().InstanceType(gomock.Any()).AnyTimes().Return("t2.large", nil)
@@ -64,6 +74,12 @@ func (tt *testTarmak) fakeAWSProvider(name string) {
 		"test": "ffs",
 	})
 	tt.fakeProvider.EXPECT().Environment().AnyTimes().Return([]string{"COOL_ENVIRONMENT=true"}, nil)
+	tt.fakeProvider.EXPECT().SignManifest(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ interfaces.Cluster, manifest []byte) ([]byte, error) {
+			return signature.Sign(manifestSigningKey, manifest)
+		},
+	)
+	tt.fakeProvider.EXPECT().ManifestSigningPublicKey(gomock.Any()).AnyTimes().Return(manifestSigningPublicKey, nil)
 
 	// override provider creation method
 	tt.tarmak.providerByName = func(providerName string) (interfaces.Provider, error) {
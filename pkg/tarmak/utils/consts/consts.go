@@ -7,6 +7,9 @@ const (
 	DefaultPlanLocationPlaceholder = "${TARMAK_CONFIG}/${CURRENT_CLUSTER}/terraform/tarmak.plan"
 	DefaultLogsPathPlaceholder     = "${TARMAK_CONFIG}/${CURRENT_CLUSTER}/${INSTANCE_POOL}.tar.gz"
 	TerraformPlanFile              = "tarmak.plan"
+	TerraformDriftPlanFile         = "tarmak-drift.plan"
+	TerraformLockInfoPrefix        = "tarmak "
+	CostPricesFile                 = "prices.json"
 
 	DefaultKubeconfigPath = "${TARMAK_CONFIG}/${CURRENT_CLUSTER}/kubeconfig"
 	KubeconfigFlagName    = "public-api-endpoint"
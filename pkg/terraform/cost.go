@@ -0,0 +1,45 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	clusterv1alpha1 "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/cost"
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
+	"github.com/jetstack/tarmak/pkg/terraform/plan"
+)
+
+// printCostEstimate compares the monthly cost of the state the plan has been
+// made against with the cost of the cluster's configuration. The estimate is
+// only informational, so failures are logged as warnings.
+func (t *Terraform) printCostEstimate(cluster interfaces.Cluster, tfPlan *plan.Plan) {
+	provider := cluster.Environment().Provider()
+	if provider.Cloud() != clusterv1alpha1.CloudAmazon {
+		return
+	}
+
+	prices, err := cost.LoadPrices(filepath.Join(t.tarmak.ConfigPath(), consts.CostPricesFile))
+	if err != nil {
+		t.log.Warnf("unable to estimate cost: %s", err)
+		return
+	}
+
+	table, fallback := prices.Region(provider.Region())
+	if fallback {
+		t.log.Warnf("no prices for region %s, estimating cost with the prices of %s", provider.Region(), cost.DefaultRegion)
+	}
+
+	estimate := cost.New(cluster, tfPlan.State, table)
+
+	t.log.Info("estimated monthly cost in US dollars")
+	utils.ListParameters(os.Stdout, []string{"pool", "type", "instances", "current", "planned", "change"}, estimate.Parameters())
+
+	if len(estimate.Missing) > 0 {
+		t.log.Warnf("no prices for %s, add them to %s to include them in the estimate", strings.Join(estimate.Missing, ", "), consts.CostPricesFile)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package terraform
+
+import (
+	"path/filepath"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
+	"github.com/jetstack/tarmak/pkg/terraform/plan"
+)
+
+// Drift plans the cluster and classifies the planned changes into changes
+// made outside of terraform and changes of the configuration. The plan is
+// stored separately so it never replaces a plan that is meant to be applied.
+func (t *Terraform) Drift(cluster interfaces.Cluster) ([]*plan.Change, error) {
+	planPath := filepath.Join(t.codePath(cluster), consts.TerraformDriftPlanFile)
+
+	_, tfPlan, err := t.planWrapper(cluster, planPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// planning doesn't persist the refreshed state, so this is the state
+	// before the refresh
+	state, err := t.StatePull(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	prior, err := plan.ReadState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return tfPlan.Changes(prior), nil
+}
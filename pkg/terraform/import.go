@@ -0,0 +1,16 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package terraform
+
+import (
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+)
+
+// Import adds an existing resource to the terraform state of the cluster at
+// the given address of the generated code
+func (t *Terraform) Import(cluster interfaces.Cluster, address, id string) error {
+	return t.terraformWrapper(
+		cluster,
+		"import",
+		[]string{"-input=false", address, id},
+	)
+}
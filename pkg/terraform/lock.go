@@ -0,0 +1,52 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package terraform
+
+import (
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/state"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
+	"github.com/jetstack/tarmak/pkg/version"
+)
+
+// lockInfoBackend wraps a backend, so that the locks it takes on states
+// record the version of tarmak that took them
+func lockInfoBackend(initFn backend.InitFn) backend.InitFn {
+	return func() backend.Backend {
+		return &lockInfoBackendWrapper{Backend: initFn()}
+	}
+}
+
+type lockInfoBackendWrapper struct {
+	backend.Backend
+}
+
+func (b *lockInfoBackendWrapper) State(name string) (state.State, error) {
+	s, err := b.Backend.State(name)
+	if err != nil {
+		return nil, err
+	}
+	return &lockInfoState{
+		StateReader:    s,
+		StateWriter:    s,
+		StateRefresher: s,
+		StatePersister: s,
+		Locker:         s,
+	}, nil
+}
+
+// the embedded interfaces are all the same state
+type lockInfoState struct {
+	state.StateReader
+	state.StateWriter
+	state.StateRefresher
+	state.StatePersister
+	state.Locker
+}
+
+func (s *lockInfoState) Lock(info *state.LockInfo) (string, error) {
+	if info.Info == "" {
+		info.Info = consts.TerraformLockInfoPrefix + version.Get().GitVersion
+	}
+	return s.Locker.Lock(info)
+}
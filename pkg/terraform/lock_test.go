@@ -0,0 +1,36 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+	backendInmem "github.com/hashicorp/terraform/backend/remote-state/inmem"
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/utils/consts"
+)
+
+func TestLockInfoBackend(t *testing.T) {
+	b := lockInfoBackend(func() backend.Backend { return backendInmem.New() })()
+	if err := b.Configure(terraform.NewResourceConfig(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	s, err := b.State(backend.DefaultStateName)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	info := state.NewLockInfo()
+	info.Operation = "test"
+	if _, err := s.Lock(info); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.HasPrefix(info.Info, consts.TerraformLockInfoPrefix) {
+		t.Errorf("expected lock info to record the tarmak version, got '%s'", info.Info)
+	}
+}
@@ -34,6 +34,9 @@ var InternalProviders = map[string]plugin.ProviderFunc{
 func passthroughPrepare() {
 	// initialise backends
 	backendInit.Init(nil)
+
+	// record tarmak's version in the locks of the remote state
+	backendInit.Set("s3", lockInfoBackend(backendInit.Backend("s3")))
 }
 
 func passthroughCleanup() {
@@ -207,3 +210,70 @@ func Taint(args []string, stopCh <-chan struct{}) int {
 	}
 	return c.Run(args)
 }
+
+func Import(args []string, stopCh <-chan struct{}) int {
+	passthroughPrepare()
+	defer passthroughCleanup()
+	c := &command.ImportCommand{
+		Meta: newMeta(newUI(os.Stdout, os.Stderr), stopCh),
+	}
+	return c.Run(args)
+}
+
+func StateList(args []string, stopCh <-chan struct{}) int {
+	passthroughPrepare()
+	defer passthroughCleanup()
+	c := &command.StateListCommand{
+		Meta: newMeta(newUI(os.Stdout, os.Stderr), stopCh),
+	}
+	return c.Run(args)
+}
+
+func StatePull(args []string, stopCh <-chan struct{}) int {
+	passthroughPrepare()
+	defer passthroughCleanup()
+	c := &command.StatePullCommand{
+		Meta: newMeta(newUI(os.Stdout, os.Stderr), stopCh),
+	}
+	return c.Run(args)
+}
+
+func StatePush(args []string, stopCh <-chan struct{}) int {
+	passthroughPrepare()
+	defer passthroughCleanup()
+	meta := newMeta(newUI(os.Stdout, os.Stderr), stopCh)
+	c := &command.StatePushCommand{
+		Meta:      meta,
+		StateMeta: command.StateMeta{Meta: meta},
+	}
+	return c.Run(args)
+}
+
+func StateShow(args []string, stopCh <-chan struct{}) int {
+	passthroughPrepare()
+	defer passthroughCleanup()
+	meta := newMeta(newUI(os.Stdout, os.Stderr), stopCh)
+	c := &command.StateShowCommand{
+		Meta:      meta,
+		StateMeta: command.StateMeta{Meta: meta},
+	}
+	return c.Run(args)
+}
+
+func StateMv(args []string, stopCh <-chan struct{}) int {
+	passthroughPrepare()
+	defer passthroughCleanup()
+	c := &command.StateMvCommand{
+		StateMeta: command.StateMeta{Meta: newMeta(newUI(os.Stdout, os.Stderr), stopCh)},
+	}
+	return c.Run(args)
+}
+
+func StateRm(args []string, stopCh <-chan struct{}) int {
+	passthroughPrepare()
+	defer passthroughCleanup()
+	c := &command.StateRmCommand{
+		StateMeta: command.StateMeta{Meta: newMeta(newUI(os.Stdout, os.Stderr), stopCh)},
+	}
+	return c.Run(args)
+}
@@ -0,0 +1,146 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+type ChangeKind string
+
+const (
+	// the resource has been changed outside of terraform
+	ChangeKindDrift = ChangeKind("drift")
+	// the resource changes because the configuration has changed
+	ChangeKindConfig = ChangeKind("config")
+)
+
+// Change is a single resource change of a plan
+type Change struct {
+	Resource string     `json:"resource"`
+	Action   string     `json:"action"`
+	Kind     ChangeKind `json:"kind"`
+	// attributes changed outside of terraform
+	Attributes []string `json:"attributes,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+}
+
+func (c *Change) String() string {
+	output := fmt.Sprintf("%s %s (%s)", c.Action, c.Resource, c.Kind)
+	if c.Reason != "" {
+		output = fmt.Sprintf("%s: %s", output, c.Reason)
+	}
+	return output
+}
+
+// Changes classifies the plan's resource changes as drift or configuration
+// changes. The prior state is the state before terraform refreshed it while
+// planning, a resource differing between the prior and the refreshed state
+// has been changed outside of terraform.
+func (p *Plan) Changes(prior *terraform.State) []*Change {
+	var changes []*Change
+
+	for _, module := range p.Diff.Modules {
+		priorModule := prior.ModuleByPath(module.Path)
+		refreshedModule := p.State.ModuleByPath(module.Path)
+
+		for key, resource := range module.Resources {
+			// data sources are read on every plan
+			if strings.HasPrefix(key, "data.") {
+				continue
+			}
+
+			action := changeAction(resource.ChangeType())
+			if action == "" {
+				continue
+			}
+
+			change := &Change{
+				Resource: resourceName(module, key),
+				Action:   action,
+				Kind:     ChangeKindConfig,
+			}
+
+			priorResource := moduleResource(priorModule, key)
+			refreshedResource := moduleResource(refreshedModule, key)
+
+			switch {
+			case priorResource == nil:
+				// new resource
+			case refreshedResource == nil:
+				change.Kind = ChangeKindDrift
+				change.Reason = "deleted outside of terraform"
+			default:
+				for name := range resource.Attributes {
+					if priorResource.Attributes[name] != refreshedResource.Attributes[name] {
+						change.Attributes = append(change.Attributes, name)
+					}
+				}
+				if len(change.Attributes) > 0 {
+					sort.Strings(change.Attributes)
+					change.Kind = ChangeKindDrift
+					change.Reason = fmt.Sprintf("changed outside of terraform: %s", strings.Join(change.Attributes, ", "))
+				}
+			}
+
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Resource < changes[j].Resource
+	})
+
+	return changes
+}
+
+// ReadState parses the output of 'terraform state pull', clusters without a
+// state yet return an empty state
+func ReadState(data []byte) (*terraform.State, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return terraform.NewState(), nil
+	}
+
+	state, err := terraform.ReadState(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error reading state: %s", err)
+	}
+
+	return state, nil
+}
+
+func changeAction(changeType terraform.DiffChangeType) string {
+	switch changeType {
+	case terraform.DiffCreate:
+		return "create"
+	case terraform.DiffUpdate:
+		return "update"
+	case terraform.DiffDestroy:
+		return "destroy"
+	case terraform.DiffDestroyCreate:
+		return "replace"
+	}
+	return ""
+}
+
+func moduleResource(module *terraform.ModuleState, key string) *terraform.InstanceState {
+	if module == nil {
+		return nil
+	}
+	resource, ok := module.Resources[key]
+	if !ok || resource == nil || resource.Primary == nil {
+		return nil
+	}
+	return resource.Primary
+}
+
+func resourceName(module *terraform.ModuleDiff, key string) string {
+	if module.Path == nil || len(module.Path) == 1 {
+		return key
+	}
+	return fmt.Sprintf("module.%s.%s", strings.Join(module.Path[1:], "."), key)
+}
@@ -0,0 +1,122 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testState(resources map[string]map[string]string) *terraform.State {
+	module := &terraform.ModuleState{
+		Path:      []string{"root", "kubernetes"},
+		Resources: make(map[string]*terraform.ResourceState),
+	}
+	for key, attributes := range resources {
+		module.Resources[key] = &terraform.ResourceState{
+			Primary: &terraform.InstanceState{
+				ID:         key,
+				Attributes: attributes,
+			},
+		}
+	}
+	return &terraform.State{
+		Modules: []*terraform.ModuleState{module},
+	}
+}
+
+func TestPlan_Changes(t *testing.T) {
+	prior := testState(map[string]map[string]string{
+		"aws_security_group.master":    {"ingress.#": "2"},
+		"aws_autoscaling_group.worker": {"desired_capacity": "3", "max_size": "3"},
+		"aws_route53_record.api":       {"name": "api"},
+		"aws_instance.removed":         {"instance_type": "t2.large"},
+	})
+
+	refreshed := testState(map[string]map[string]string{
+		"aws_security_group.master":    {"ingress.#": "3"},
+		"aws_autoscaling_group.worker": {"desired_capacity": "3", "max_size": "3"},
+		"aws_instance.removed":         {"instance_type": "t2.large"},
+	})
+
+	p := &Plan{&terraform.Plan{
+		State: refreshed,
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				{
+					Path: []string{"root", "kubernetes"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_security_group.master": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ingress.#": {Old: "3", New: "2"},
+							},
+						},
+						"aws_autoscaling_group.worker": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"max_size": {Old: "3", New: "5"},
+							},
+						},
+						"aws_route53_record.api": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"name": {Old: "", New: "api", RequiresNew: true},
+							},
+						},
+						"aws_instance.new": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"instance_type": {Old: "", New: "t2.large", RequiresNew: true},
+							},
+						},
+						"aws_instance.removed": {
+							Destroy: true,
+						},
+						"data.template_file.user_data": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"rendered": {Old: "", NewComputed: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	exp := []*Change{
+		{
+			Resource: "module.kubernetes.aws_autoscaling_group.worker",
+			Action:   "update",
+			Kind:     ChangeKindConfig,
+		},
+		{
+			Resource: "module.kubernetes.aws_instance.new",
+			Action:   "create",
+			Kind:     ChangeKindConfig,
+		},
+		{
+			Resource: "module.kubernetes.aws_instance.removed",
+			Action:   "destroy",
+			Kind:     ChangeKindConfig,
+		},
+		{
+			Resource: "module.kubernetes.aws_route53_record.api",
+			Action:   "create",
+			Kind:     ChangeKindDrift,
+			Reason:   "deleted outside of terraform",
+		},
+		{
+			Resource:   "module.kubernetes.aws_security_group.master",
+			Action:     "update",
+			Kind:       ChangeKindDrift,
+			Attributes: []string{"ingress.#"},
+			Reason:     "changed outside of terraform: ingress.#",
+		},
+	}
+
+	act := p.Changes(prior)
+	if !reflect.DeepEqual(exp, act) {
+		for _, c := range act {
+			t.Logf("act: %+v", c)
+		}
+		t.Errorf("unexpected changes")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jetstack/vault-helper/pkg/kubernetes"
 
 	cluster "github.com/jetstack/tarmak/pkg/apis/cluster/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/tarmak/encryption"
 )
 
 var (
@@ -80,6 +81,18 @@ func (r *tarmakRPC) VaultInstanceRole(args *VaultInstanceRoleArgs, result *Vault
 		}
 	}
 
+	if args.Create && r.tarmak.Cluster().Type() != cluster.ClusterTypeHub {
+		changed, err := encryption.Ensure(vaultClient, r.tarmak.Cluster())
+		if err != nil {
+			err = fmt.Errorf("failed to ensure encryption config: %s", err)
+			r.tarmak.Log().Error(err)
+			return err
+		}
+		if changed {
+			r.tarmak.Log().Warn("encryption config has changed, run 'tarmak cluster encryption rotate-key' to reload it on existing masters and re-encrypt resources")
+		}
+	}
+
 	initTokens := k.InitTokens()
 	initToken, ok := initTokens[roleName]
 	if !ok {
@@ -0,0 +1,111 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package terraform
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jetstack/tarmak/pkg/tarmak/interfaces"
+)
+
+// StateList returns the addresses of all resources in the terraform state of
+// the cluster
+func (t *Terraform) StateList(cluster interfaces.Cluster) ([]string, error) {
+	output, err := t.stateCommand(cluster, nil, "list")
+	if err != nil {
+		return nil, fmt.Errorf("error listing terraform state: %s", err)
+	}
+
+	var addresses []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			addresses = append(addresses, line)
+		}
+	}
+
+	return addresses, nil
+}
+
+// StateShow returns the attributes of a single resource in the terraform
+// state of the cluster
+func (t *Terraform) StateShow(cluster interfaces.Cluster, address string) (string, error) {
+	output, err := t.stateCommand(cluster, nil, "show", address)
+	if err != nil {
+		return "", fmt.Errorf("error showing %s: %s", address, err)
+	}
+
+	return string(output), nil
+}
+
+// StatePull returns the raw terraform state of the cluster
+func (t *Terraform) StatePull(cluster interfaces.Cluster) ([]byte, error) {
+	output, err := t.stateCommand(cluster, nil, "pull")
+	if err != nil {
+		return nil, fmt.Errorf("error pulling terraform state: %s", err)
+	}
+
+	return output, nil
+}
+
+// StatePush replaces the terraform state of the cluster. Unless forced,
+// terraform refuses states of a different lineage or with a lower serial.
+func (t *Terraform) StatePush(cluster interfaces.Cluster, state []byte, force bool) error {
+	args := []string{"push"}
+	if force {
+		args = append(args, "-force")
+	}
+	args = append(args, "-")
+
+	if _, err := t.stateCommand(cluster, bytes.NewReader(state), args...); err != nil {
+		return fmt.Errorf("error pushing terraform state: %s", err)
+	}
+
+	return nil
+}
+
+// StateMove moves a resource to a different address within the terraform
+// state of the cluster
+func (t *Terraform) StateMove(cluster interfaces.Cluster, source, destination string) error {
+	if _, err := t.stateCommand(cluster, nil, "mv", source, destination); err != nil {
+		return fmt.Errorf("error moving %s to %s: %s", source, destination, err)
+	}
+
+	return nil
+}
+
+// StateRemove removes resources from the terraform state of the cluster,
+// without destroying them
+func (t *Terraform) StateRemove(cluster interfaces.Cluster, addresses []string) error {
+	if _, err := t.stateCommand(cluster, nil, append([]string{"rm"}, addresses...)...); err != nil {
+		return fmt.Errorf("error removing %s: %s", strings.Join(addresses, ", "), err)
+	}
+
+	return nil
+}
+
+// runs a terraform state subcommand and returns its output
+func (t *Terraform) stateCommand(cluster interfaces.Cluster, stdin io.Reader, args ...string) ([]byte, error) {
+	if !t.prepared {
+		if err := t.Prepare(cluster); err != nil {
+			return nil, fmt.Errorf("failed to prepare terraform: %s", err)
+		}
+		t.prepared = true
+	}
+
+	stdOutBuf := new(bytes.Buffer)
+	stdErrBuf := new(bytes.Buffer)
+	if err := t.command(
+		cluster,
+		append([]string{"terraform", "state"}, args...),
+		stdin,
+		stdOutBuf,
+		stdErrBuf,
+	); err != nil {
+		t.log.Error(stdErrBuf.String())
+		return nil, err
+	}
+
+	return stdOutBuf.Bytes(), nil
+}
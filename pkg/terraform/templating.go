@@ -2,9 +2,13 @@
 package terraform
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
@@ -20,6 +24,7 @@ import (
 	"github.com/jetstack/tarmak/pkg/tarmak/provider/amazon"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils"
 	"github.com/jetstack/tarmak/pkg/tarmak/utils/zip"
+	"github.com/jetstack/tarmak/pkg/wing/signature"
 )
 
 func (t *Terraform) GenerateCode(c interfaces.Cluster) (err error) {
@@ -129,10 +134,24 @@ func (t *Terraform) GenerateCode(c interfaces.Cluster) (err error) {
 	if err != nil {
 		return fmt.Errorf("error creating %s: %s", puppetTarGzFilename, err)
 	}
-	if err = t.tarmak.Cluster().Environment().Tarmak().Puppet().TarGz(file); err != nil {
+	puppetTarGz := new(bytes.Buffer)
+	if err = t.tarmak.Cluster().Environment().Tarmak().Puppet().TarGz(io.MultiWriter(file, puppetTarGz)); err != nil {
 		return fmt.Errorf("error writing to %s: %s", puppetTarGzFilename, err)
 	}
 
+	// sign puppet.tar.gz, instances verify manifests against the public key
+	puppetTarGzSignature, err := c.Environment().Provider().SignManifest(c, puppetTarGz.Bytes())
+	if err != nil {
+		return fmt.Errorf("error signing %s: %s", puppetTarGzFilename, err)
+	}
+	if err := ioutil.WriteFile(puppetTarGzFilename+signature.Suffix, puppetTarGzSignature, 0600); err != nil {
+		return fmt.Errorf("error writing signature of %s: %s", puppetTarGzFilename, err)
+	}
+	signingPublicKey, err := c.Environment().Provider().ManifestSigningPublicKey(c)
+	if err != nil {
+		return fmt.Errorf("error getting manifest signing public key: %s", err)
+	}
+
 	// generate templates
 	templ := &terraformTemplate{
 		terraform:   t,
@@ -141,6 +160,8 @@ func (t *Terraform) GenerateCode(c interfaces.Cluster) (err error) {
 		rootPath:    rootPath,
 		wingHash:    wingHash,
 		wingDevMode: t.tarmak.Config().WingDevMode(),
+
+		manifestSigningPublicKey: base64.StdEncoding.EncodeToString(signingPublicKey),
 	}
 	if err := templ.Generate(); err != nil {
 		return err
@@ -157,6 +178,8 @@ type terraformTemplate struct {
 	rootPath    string
 	wingHash    string
 	wingDevMode bool
+
+	manifestSigningPublicKey string // base64 encoded PEM
 }
 
 func (t *terraformTemplate) Generate() error {
@@ -258,6 +281,7 @@ func (t *terraformTemplate) data(module string) map[string]interface{} {
 		"ClusterType":              t.cluster.Type(),
 		"InstancePools":            t.cluster.InstancePools(),
 		"ExistingVPC":              existingVPC,
+		"CrossRegion":              t.cluster.CrossRegion(),
 		// cluster.Roles() returns a list of roles based off of the types of instancePools in tarmak.yaml
 		"Roles":                 t.cluster.Roles(),
 		"SocketPath":            t.terraform.socketPath,
@@ -269,6 +293,8 @@ func (t *terraformTemplate) data(module string) map[string]interface{} {
 		"VaultInstancePool":     t.cluster.InstancePool("vault"),
 		"BastionInstancePool":   t.cluster.InstancePool("bastion"),
 		"AmazonEBSEncrypted":    t.cluster.AmazonEBSEncrypted(),
+
+		"ManifestSigningPublicKey": t.manifestSigningPublicKey,
 	}
 }
 
@@ -388,6 +414,20 @@ func (t *terraformTemplate) generateModuleInstanceTemplates(module string) error
 
 // TODO: move this to the cloud provider
 func (t *terraformTemplate) generateAWSSecurityGroup() (rules map[string][]*amazon.AWSSGRule, err error) {
+	// security groups can't be referenced across regions, so clusters in
+	// another region allow the bastion and vault by the hub's network
+	var hubNetwork *net.IPNet
+	if t.cluster.CrossRegion() {
+		hub := t.cluster.Environment().Hub()
+		if hub == nil || hub.Config().Network == nil {
+			return nil, fmt.Errorf("unable to find the network of the hub of cluster %s", t.cluster.ClusterName())
+		}
+		_, hubNetwork, err = net.ParseCIDR(hub.Config().Network.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing network of the hub: %s", err)
+		}
+	}
+
 	rules = make(map[string][]*amazon.AWSSGRule)
 	for _, role := range t.cluster.Roles() {
 
@@ -395,10 +435,18 @@ func (t *terraformTemplate) generateAWSSecurityGroup() (rules map[string][]*amaz
 			continue
 		}
 
-		roleRules, err := amazon.GenerateAWSRules(role)
+		roleRules, err := amazon.GenerateAWSRules(role, t.cluster.Config().Kubernetes)
 		if err != nil {
 			return nil, err
 		}
+
+		if hubNetwork != nil {
+			for _, rule := range roleRules {
+				if rule.Source == "bastion" || rule.Source == "vault" {
+					rule.CIDRBlock = hubNetwork
+				}
+			}
+		}
 		rules[role.Name()] = roleRules
 	}
 
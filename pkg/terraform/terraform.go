@@ -430,6 +430,8 @@ func (t *Terraform) Plan(cluster interfaces.Cluster, preApply bool) (changesNeed
 		}
 	}
 
+	t.printCostEstimate(cluster, tfPlan)
+
 	destroyingEBSVolume, ebsVolumesToDestroy := tfPlan.IsDestroyingEBSVolume()
 	if !destroyingEBSVolume {
 		return changesNeeded, nil
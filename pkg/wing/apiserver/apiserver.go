@@ -3,6 +3,8 @@
 package apiserver
 
 import (
+	"net/http"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -13,7 +15,9 @@ import (
 	"github.com/jetstack/tarmak/pkg/apis/wing"
 	"github.com/jetstack/tarmak/pkg/apis/wing/install"
 	pkgversion "github.com/jetstack/tarmak/pkg/version"
+	"github.com/jetstack/tarmak/pkg/wing/pki"
 	wingregistry "github.com/jetstack/tarmak/pkg/wing/registry"
+	commandstorage "github.com/jetstack/tarmak/pkg/wing/registry/wing/command"
 	instancestorage "github.com/jetstack/tarmak/pkg/wing/registry/wing/instance"
 )
 
@@ -41,7 +45,8 @@ func init() {
 }
 
 type ExtraConfig struct {
-	// Place you custom config here.
+	// Bootstrap issues client certificates to instances, if set
+	Bootstrap http.Handler
 }
 
 type Config struct {
@@ -56,6 +61,7 @@ type WingServer struct {
 
 type completedConfig struct {
 	GenericConfig genericapiserver.CompletedConfig
+	ExtraConfig   *ExtraConfig
 }
 
 type CompletedConfig struct {
@@ -67,6 +73,7 @@ type CompletedConfig struct {
 func (cfg *Config) Complete() CompletedConfig {
 	c := completedConfig{
 		cfg.GenericConfig.Complete(),
+		&cfg.ExtraConfig,
 	}
 
 	version := pkgversion.Get()
@@ -90,11 +97,16 @@ func (c completedConfig) New() (*WingServer, error) {
 
 	v1alpha1storage := map[string]rest.Storage{}
 	v1alpha1storage["instances"] = wingregistry.RESTInPeace(instancestorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter))
+	v1alpha1storage["commands"] = wingregistry.RESTInPeace(commandstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter))
 	apiGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = v1alpha1storage
 
 	if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
 		return nil, err
 	}
 
+	if c.ExtraConfig.Bootstrap != nil {
+		s.GenericAPIServer.Handler.NonGoRestfulMux.Handle(pki.BootstrapPath, c.ExtraConfig.Bootstrap)
+	}
+
 	return s, nil
 }
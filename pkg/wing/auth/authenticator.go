@@ -0,0 +1,59 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package auth
+
+import (
+	"crypto/x509"
+	"net"
+	"net/http"
+
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/request/anonymous"
+	"k8s.io/apiserver/pkg/authentication/request/union"
+	x509request "k8s.io/apiserver/pkg/authentication/request/x509"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+const (
+	// Group of users with full access to the wing API
+	AdminsGroup = "wing:admins"
+
+	// User of requests originating from the wing server host itself, tarmak
+	// connects through a SSH tunnel via the bastion
+	LocalAdminUser = "wing:local-admin"
+)
+
+// NewAuthenticator authenticates instances by their client certificate signed
+// by the CA, local connections without client certificate as admin and
+// everything else as anonymous
+func NewAuthenticator(ca *x509.CertPool) authenticator.Request {
+	opts := x509request.DefaultVerifyOptions()
+	opts.Roots = ca
+
+	return union.New(
+		x509request.New(opts, x509request.CommonNameUserConversion),
+		authenticator.RequestFunc(authenticateLocal),
+		anonymous.NewAuthenticator(),
+	)
+}
+
+func authenticateLocal(req *http.Request) (*authenticator.Response, bool, error) {
+	if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		return nil, false, nil
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return nil, false, nil
+	}
+
+	return &authenticator.Response{
+		User: &user.DefaultInfo{
+			Name:   LocalAdminUser,
+			Groups: []string{AdminsGroup, user.AllAuthenticated},
+		},
+	}, true, nil
+}
@@ -0,0 +1,119 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package auth
+
+import (
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing"
+	"github.com/jetstack/tarmak/pkg/wing/pki"
+)
+
+var (
+	// non resource paths allowed for anonymous users
+	anonymousPaths = map[string]string{
+		pki.BootstrapPath: "post",
+		"/healthz":        "get",
+	}
+)
+
+// Authorizer gives admins full access, while instances are only allowed to
+// read instances and commands, to write their own instance and to report
+// command results
+type Authorizer struct{}
+
+var _ authorizer.Authorizer = &Authorizer{}
+
+func NewAuthorizer() *Authorizer {
+	return &Authorizer{}
+}
+
+func (a *Authorizer) Authorize(attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	u := attr.GetUser()
+	if u == nil {
+		return authorizer.DecisionNoOpinion, "no user", nil
+	}
+
+	switch {
+	case inGroup(u, AdminsGroup):
+		return authorizer.DecisionAllow, "", nil
+
+	case inGroup(u, pki.InstancesGroup):
+		return a.authorizeInstance(u, attr)
+
+	case inGroup(u, user.AllUnauthenticated):
+		if !attr.IsResourceRequest() && anonymousPaths[attr.GetPath()] == attr.GetVerb() {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionNoOpinion, "anonymous access denied", nil
+	}
+
+	return authorizer.DecisionNoOpinion, "unknown user", nil
+}
+
+func (a *Authorizer) authorizeInstance(u user.Info, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	if !attr.IsResourceRequest() {
+		if attr.IsReadOnly() {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionNoOpinion, "instances have read-only access to non resource paths", nil
+	}
+
+	if attr.GetAPIGroup() != wing.GroupName || attr.GetSubresource() != "" {
+		return authorizer.DecisionNoOpinion, "instances can only access instances and commands", nil
+	}
+
+	switch attr.GetResource() {
+	case "instances":
+		return a.authorizeInstanceInstances(u, attr)
+	case "commands":
+		return a.authorizeInstanceCommands(u, attr)
+	}
+
+	return authorizer.DecisionNoOpinion, "instances can only access instances and commands", nil
+}
+
+func (a *Authorizer) authorizeInstanceInstances(u user.Info, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	switch attr.GetVerb() {
+	case "get", "list", "watch":
+		return authorizer.DecisionAllow, "", nil
+
+	// the name of a created object is not known, the instance strategy
+	// verifies it matches the user
+	case "create":
+		return authorizer.DecisionAllow, "", nil
+
+	case "update", "patch":
+		if attr.GetName() == u.GetName() {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionNoOpinion, "instances can only update their own instance", nil
+	}
+
+	return authorizer.DecisionNoOpinion, "verb not allowed for instances", nil
+}
+
+// instances run commands and report their result, the command strategy
+// verifies that only their own result is changed
+func (a *Authorizer) authorizeInstanceCommands(u user.Info, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	switch attr.GetVerb() {
+	case "get", "list", "watch", "update":
+		return authorizer.DecisionAllow, "", nil
+	}
+
+	return authorizer.DecisionNoOpinion, "instances can only read commands and report results", nil
+}
+
+// IsInstance returns true if the user is authenticated as an instance
+func IsInstance(u user.Info) bool {
+	return u != nil && inGroup(u, pki.InstancesGroup)
+}
+
+func inGroup(u user.Info, group string) bool {
+	for _, g := range u.GetGroups() {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
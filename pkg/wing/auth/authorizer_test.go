@@ -0,0 +1,84 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package auth
+
+import (
+	"testing"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing"
+	"github.com/jetstack/tarmak/pkg/wing/pki"
+)
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	admin := &user.DefaultInfo{Name: LocalAdminUser, Groups: []string{AdminsGroup}}
+	instance := &user.DefaultInfo{Name: "i-1", Groups: []string{pki.InstancesGroup}}
+	anonymous := &user.DefaultInfo{Name: user.Anonymous, Groups: []string{user.AllUnauthenticated}}
+
+	instances := func(u user.Info, verb, name string) authorizer.AttributesRecord {
+		return authorizer.AttributesRecord{
+			User:            u,
+			Verb:            verb,
+			APIGroup:        wing.GroupName,
+			Resource:        "instances",
+			Name:            name,
+			ResourceRequest: true,
+		}
+	}
+
+	commands := func(u user.Info, verb, name string) authorizer.AttributesRecord {
+		return authorizer.AttributesRecord{
+			User:            u,
+			Verb:            verb,
+			APIGroup:        wing.GroupName,
+			Resource:        "commands",
+			Name:            name,
+			ResourceRequest: true,
+		}
+	}
+
+	path := func(u user.Info, verb, path string) authorizer.AttributesRecord {
+		return authorizer.AttributesRecord{
+			User: u,
+			Verb: verb,
+			Path: path,
+		}
+	}
+
+	for _, c := range []struct {
+		name  string
+		attr  authorizer.AttributesRecord
+		allow bool
+	}{
+		{"admin deletes instance", instances(admin, "delete", "i-2"), true},
+		{"instance lists instances", instances(instance, "list", ""), true},
+		{"instance creates instance", instances(instance, "create", ""), true},
+		{"instance updates itself", instances(instance, "update", "i-1"), true},
+		{"instance patches itself", instances(instance, "patch", "i-1"), true},
+		{"instance updates other instance", instances(instance, "update", "i-2"), false},
+		{"instance deletes itself", instances(instance, "delete", "i-1"), false},
+		{"instance watches commands", commands(instance, "watch", ""), true},
+		{"instance reports command result", commands(instance, "update", "exec-1"), true},
+		{"instance creates command", commands(instance, "create", ""), false},
+		{"instance deletes command", commands(instance, "delete", "exec-1"), false},
+		{"admin creates command", commands(admin, "create", ""), true},
+		{"anonymous lists commands", commands(anonymous, "list", ""), false},
+		{"instance reads api paths", path(instance, "get", "/apis"), true},
+		{"instance posts to bootstrap", path(instance, "post", pki.BootstrapPath), false},
+		{"anonymous bootstraps", path(anonymous, "post", pki.BootstrapPath), true},
+		{"anonymous checks health", path(anonymous, "get", "/healthz"), true},
+		{"anonymous lists instances", instances(anonymous, "list", ""), false},
+		{"anonymous reads api paths", path(anonymous, "get", "/apis"), false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			decision, reason, err := NewAuthorizer().Authorize(c.attr)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if allow := decision == authorizer.DecisionAllow; allow != c.allow {
+				t.Errorf("unexpected decision, exp=%t act=%t (%s)", c.allow, allow, reason)
+			}
+		})
+	}
+}
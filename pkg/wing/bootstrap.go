@@ -0,0 +1,217 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+
+	"github.com/jetstack/tarmak/pkg/wing/pki"
+	"github.com/jetstack/tarmak/pkg/wing/tags"
+)
+
+const (
+	clientCertFile = "client.pem"
+	clientKeyFile  = "client-key.pem"
+
+	// interval of checking whether the client certificate needs renewal
+	clientCertificateCheckInterval = time.Hour
+)
+
+// make sure a valid client certificate exists, request a new one from the
+// wing server if it is missing or about to expire
+func (w *Wing) ensureClientCertificate(t tags.Tags) (certFile string, keyFile string, err error) {
+	certFile = filepath.Join(w.flags.PKIDir, clientCertFile)
+	keyFile = filepath.Join(w.flags.PKIDir, clientKeyFile)
+
+	err = w.validClientCertificate(certFile)
+	if err == nil {
+		return certFile, keyFile, nil
+	}
+	w.log.Infof("requesting new client certificate: %s", err)
+
+	bootstrap := func() error {
+		return w.bootstrapClientCertificate(t, certFile, keyFile)
+	}
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = time.Second * 5
+	expBackoff.MaxElapsedTime = time.Minute * 5
+
+	err = backoff.RetryNotify(bootstrap, expBackoff, func(err error, d time.Duration) {
+		w.log.Warnf("failed to bootstrap client certificate, retrying in %s: %s", d, err)
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return certFile, keyFile, nil
+}
+
+// renew the client certificate periodically before it expires, the renewed
+// certificate is used for new connections to the wing server
+func (w *Wing) renewClientCertificateLoop(t tags.Tags) {
+	ticker := time.NewTicker(clientCertificateCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		certFile, keyFile, err := w.ensureClientCertificate(t)
+		if err != nil {
+			w.log.Errorf("error renewing client certificate: %s", err)
+			continue
+		}
+
+		if err := w.loadClientCertificate(certFile, keyFile); err != nil {
+			w.log.Errorf("error loading client certificate: %s", err)
+		}
+	}
+}
+
+// load the client certificate presented to the wing server
+func (w *Wing) loadClientCertificate(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("error loading client certificate: %s", err)
+	}
+
+	w.clientCertMu.Lock()
+	defer w.clientCertMu.Unlock()
+	w.clientCert = &cert
+
+	return nil
+}
+
+func (w *Wing) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	w.clientCertMu.Lock()
+	defer w.clientCertMu.Unlock()
+
+	if w.clientCert == nil {
+		return nil, fmt.Errorf("no client certificate loaded")
+	}
+	return w.clientCert, nil
+}
+
+// pool of the CA the wing server's certificate is verified against
+func (w *Wing) caPool() (*x509.CertPool, error) {
+	caPEM, err := ioutil.ReadFile(w.flags.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA: %s", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("error parsing CA '%s'", w.flags.CAFile)
+	}
+	return pool, nil
+}
+
+func (w *Wing) validClientCertificate(certFile string) error {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return err
+	}
+
+	cert, err := pki.ParseCertificate(certPEM)
+	if err != nil {
+		return err
+	}
+
+	if cert.Subject.CommonName != w.flags.InstanceName {
+		return fmt.Errorf("client certificate was issued for '%s'", cert.Subject.CommonName)
+	}
+
+	if time.Now().Add(pki.InstanceCertificateRenewBefore).After(cert.NotAfter) {
+		return fmt.Errorf("client certificate expires at %s", cert.NotAfter)
+	}
+
+	return nil
+}
+
+func (w *Wing) bootstrapClientCertificate(t tags.Tags, certFile, keyFile string) error {
+	keyPEM, csrPEM, err := pki.NewCertificateRequest(w.flags.InstanceName)
+	if err != nil {
+		return err
+	}
+
+	// the ssh host keys sign the certificate request to prove the identity
+	identity, err := t.InstanceIdentity(csrPEM)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(&pki.BootstrapRequest{
+		Identity:           identity,
+		CertificateRequest: csrPEM,
+	})
+	if err != nil {
+		return err
+	}
+
+	pool, err := w.caPool()
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{
+		Timeout: time.Second * 30,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: pool,
+			},
+		},
+	}
+
+	resp, err := httpClient.Post(
+		strings.TrimSuffix(w.flags.ServerURL, "/")+pki.BootstrapPath,
+		"application/json",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("wing server rejected bootstrap request: %s", resp.Status)
+	}
+
+	response := new(pki.BootstrapResponse)
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("error parsing bootstrap response: %s", err)
+	}
+
+	if _, err := pki.ParseCertificate(response.Certificate); err != nil {
+		return fmt.Errorf("error parsing issued client certificate: %s", err)
+	}
+
+	if err := os.MkdirAll(w.flags.PKIDir, 0700); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return fmt.Errorf("error writing client key: %s", err)
+	}
+
+	if err := ioutil.WriteFile(certFile, response.Certificate, 0644); err != nil {
+		return fmt.Errorf("error writing client certificate: %s", err)
+	}
+
+	w.log.Infof("retrieved client certificate for instance %s", w.flags.InstanceName)
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestCertificate(t *testing.T, dir string, serial int64) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "fakeInstanceName"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, clientCertFile)
+	keyFile = filepath.Join(dir, clientKeyFile)
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+// Test a renewed client certificate replaces the one presented to the server
+func TestWing_loadClientCertificate_renewed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wing-pki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	w := &Wing{}
+	if _, err := w.getClientCertificate(nil); err == nil {
+		t.Error("expected error without client certificate")
+	}
+
+	for _, serial := range []int64{1, 2} {
+		certFile, keyFile := writeTestCertificate(t, dir, serial)
+		if err := w.loadClientCertificate(certFile, keyFile); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cert, err := w.getClientCertificate(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act := parsed.SerialNumber.Int64(); act != serial {
+			t.Errorf("expected certificate with serial %d, got %d", serial, act)
+		}
+	}
+}
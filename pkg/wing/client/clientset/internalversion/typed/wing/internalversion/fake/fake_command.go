@@ -0,0 +1,126 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	wing "github.com/jetstack/tarmak/pkg/apis/wing"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCommands implements CommandInterface
+type FakeCommands struct {
+	Fake *FakeWing
+	ns   string
+}
+
+var commandsResource = schema.GroupVersionResource{Group: "wing.tarmak.io", Version: "", Resource: "commands"}
+
+var commandsKind = schema.GroupVersionKind{Group: "wing.tarmak.io", Version: "", Kind: "Command"}
+
+// Get takes name of the command, and returns the corresponding command object, and an error if there is any.
+func (c *FakeCommands) Get(name string, options v1.GetOptions) (result *wing.Command, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(commandsResource, c.ns, name), &wing.Command{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*wing.Command), err
+}
+
+// List takes label and field selectors, and returns the list of Commands that match those selectors.
+func (c *FakeCommands) List(opts v1.ListOptions) (result *wing.CommandList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(commandsResource, commandsKind, c.ns, opts), &wing.CommandList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &wing.CommandList{ListMeta: obj.(*wing.CommandList).ListMeta}
+	for _, item := range obj.(*wing.CommandList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested commands.
+func (c *FakeCommands) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(commandsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a command and creates it.  Returns the server's representation of the command, and an error, if there is any.
+func (c *FakeCommands) Create(command *wing.Command) (result *wing.Command, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(commandsResource, c.ns, command), &wing.Command{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*wing.Command), err
+}
+
+// Update takes the representation of a command and updates it. Returns the server's representation of the command, and an error, if there is any.
+func (c *FakeCommands) Update(command *wing.Command) (result *wing.Command, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(commandsResource, c.ns, command), &wing.Command{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*wing.Command), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCommands) UpdateStatus(command *wing.Command) (*wing.Command, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(commandsResource, "status", c.ns, command), &wing.Command{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*wing.Command), err
+}
+
+// Delete takes name of the command and deletes it. Returns an error if one occurs.
+func (c *FakeCommands) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(commandsResource, c.ns, name), &wing.Command{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCommands) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(commandsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &wing.CommandList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched command.
+func (c *FakeCommands) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *wing.Command, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(commandsResource, c.ns, name, pt, data, subresources...), &wing.Command{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*wing.Command), err
+}
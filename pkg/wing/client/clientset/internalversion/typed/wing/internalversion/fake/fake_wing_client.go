@@ -14,6 +14,10 @@ type FakeWing struct {
 	*testing.Fake
 }
 
+func (c *FakeWing) Commands(namespace string) internalversion.CommandInterface {
+	return &FakeCommands{c, namespace}
+}
+
 func (c *FakeWing) Instances(namespace string) internalversion.InstanceInterface {
 	return &FakeInstances{c, namespace}
 }
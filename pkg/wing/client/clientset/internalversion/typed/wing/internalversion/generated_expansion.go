@@ -4,4 +4,6 @@
 
 package internalversion
 
+type CommandExpansion interface{}
+
 type InstanceExpansion interface{}
@@ -11,6 +11,7 @@ import (
 
 type WingInterface interface {
 	RESTClient() rest.Interface
+	CommandsGetter
 	InstancesGetter
 }
 
@@ -19,6 +20,10 @@ type WingClient struct {
 	restClient rest.Interface
 }
 
+func (c *WingClient) Commands(namespace string) CommandInterface {
+	return newCommands(c, namespace)
+}
+
 func (c *WingClient) Instances(namespace string) InstanceInterface {
 	return newInstances(c, namespace)
 }
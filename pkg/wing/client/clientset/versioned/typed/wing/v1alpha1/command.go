@@ -0,0 +1,177 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+	scheme "github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CommandsGetter has a method to return a CommandInterface.
+// A group's client should implement this interface.
+type CommandsGetter interface {
+	Commands(namespace string) CommandInterface
+}
+
+// CommandInterface has methods to work with Command resources.
+type CommandInterface interface {
+	Create(*v1alpha1.Command) (*v1alpha1.Command, error)
+	Update(*v1alpha1.Command) (*v1alpha1.Command, error)
+	UpdateStatus(*v1alpha1.Command) (*v1alpha1.Command, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.Command, error)
+	List(opts v1.ListOptions) (*v1alpha1.CommandList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Command, err error)
+	CommandExpansion
+}
+
+// commands implements CommandInterface
+type commands struct {
+	client rest.Interface
+	ns     string
+}
+
+// newCommands returns a Commands
+func newCommands(c *WingV1alpha1Client, namespace string) *commands {
+	return &commands{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the command, and returns the corresponding command object, and an error if there is any.
+func (c *commands) Get(name string, options v1.GetOptions) (result *v1alpha1.Command, err error) {
+	result = &v1alpha1.Command{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("commands").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Commands that match those selectors.
+func (c *commands) List(opts v1.ListOptions) (result *v1alpha1.CommandList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.CommandList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("commands").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested commands.
+func (c *commands) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("commands").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a command and creates it.  Returns the server's representation of the command, and an error, if there is any.
+func (c *commands) Create(command *v1alpha1.Command) (result *v1alpha1.Command, err error) {
+	result = &v1alpha1.Command{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("commands").
+		Body(command).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a command and updates it. Returns the server's representation of the command, and an error, if there is any.
+func (c *commands) Update(command *v1alpha1.Command) (result *v1alpha1.Command, err error) {
+	result = &v1alpha1.Command{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("commands").
+		Name(command.Name).
+		Body(command).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *commands) UpdateStatus(command *v1alpha1.Command) (result *v1alpha1.Command, err error) {
+	result = &v1alpha1.Command{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("commands").
+		Name(command.Name).
+		SubResource("status").
+		Body(command).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the command and deletes it. Returns an error if one occurs.
+func (c *commands) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("commands").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *commands) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("commands").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched command.
+func (c *commands) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Command, err error) {
+	result = &v1alpha1.Command{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("commands").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
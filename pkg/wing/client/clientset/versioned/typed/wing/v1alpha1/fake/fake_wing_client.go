@@ -14,6 +14,10 @@ type FakeWingV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeWingV1alpha1) Commands(namespace string) v1alpha1.CommandInterface {
+	return &FakeCommands{c, namespace}
+}
+
 func (c *FakeWingV1alpha1) Instances(namespace string) v1alpha1.InstanceInterface {
 	return &FakeInstances{c, namespace}
 }
@@ -13,6 +13,7 @@ import (
 
 type WingV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	CommandsGetter
 	InstancesGetter
 }
 
@@ -21,6 +22,10 @@ type WingV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *WingV1alpha1Client) Commands(namespace string) CommandInterface {
+	return newCommands(c, namespace)
+}
+
 func (c *WingV1alpha1Client) Instances(namespace string) InstanceInterface {
 	return newInstances(c, namespace)
 }
@@ -39,6 +39,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=wing.tarmak.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("commands"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wing().V1alpha1().Commands().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("instances"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Wing().V1alpha1().Instances().Informer()}, nil
 
@@ -0,0 +1,75 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	time "time"
+
+	wingv1alpha1 "github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+	versioned "github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned"
+	internalinterfaces "github.com/jetstack/tarmak/pkg/wing/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/jetstack/tarmak/pkg/wing/client/listers/wing/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// CommandInformer provides access to a shared informer and lister for
+// Commands.
+type CommandInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.CommandLister
+}
+
+type commandInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewCommandInformer constructs a new informer for Command type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewCommandInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredCommandInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredCommandInformer constructs a new informer for Command type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredCommandInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WingV1alpha1().Commands(namespace).List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WingV1alpha1().Commands(namespace).Watch(options)
+			},
+		},
+		&wingv1alpha1.Command{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *commandInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredCommandInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *commandInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&wingv1alpha1.Command{}, f.defaultInformer)
+}
+
+func (f *commandInformer) Lister() v1alpha1.CommandLister {
+	return v1alpha1.NewCommandLister(f.Informer().GetIndexer())
+}
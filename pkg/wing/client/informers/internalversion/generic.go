@@ -39,6 +39,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=wing.tarmak.io, Version=internalVersion
+	case wing.SchemeGroupVersion.WithResource("commands"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wing().InternalVersion().Commands().Informer()}, nil
 	case wing.SchemeGroupVersion.WithResource("instances"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Wing().InternalVersion().Instances().Informer()}, nil
 
@@ -0,0 +1,75 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package internalversion
+
+import (
+	time "time"
+
+	wing "github.com/jetstack/tarmak/pkg/apis/wing"
+	clientsetinternalversion "github.com/jetstack/tarmak/pkg/wing/client/clientset/internalversion"
+	internalinterfaces "github.com/jetstack/tarmak/pkg/wing/client/informers/internalversion/internalinterfaces"
+	internalversion "github.com/jetstack/tarmak/pkg/wing/client/listers/wing/internalversion"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// CommandInformer provides access to a shared informer and lister for
+// Commands.
+type CommandInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() internalversion.CommandLister
+}
+
+type commandInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewCommandInformer constructs a new informer for Command type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewCommandInformer(client clientsetinternalversion.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredCommandInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredCommandInformer constructs a new informer for Command type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredCommandInformer(client clientsetinternalversion.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.Wing().Commands(namespace).List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.Wing().Commands(namespace).Watch(options)
+			},
+		},
+		&wing.Command{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *commandInformer) defaultInformer(client clientsetinternalversion.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredCommandInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *commandInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&wing.Command{}, f.defaultInformer)
+}
+
+func (f *commandInformer) Lister() internalversion.CommandLister {
+	return internalversion.NewCommandLister(f.Informer().GetIndexer())
+}
@@ -4,6 +4,14 @@
 
 package v1alpha1
 
+// CommandListerExpansion allows custom methods to be added to
+// CommandLister.
+type CommandListerExpansion interface{}
+
+// CommandNamespaceListerExpansion allows custom methods to be added to
+// CommandNamespaceLister.
+type CommandNamespaceListerExpansion interface{}
+
 // InstanceListerExpansion allows custom methods to be added to
 // InstanceLister.
 type InstanceListerExpansion interface{}
@@ -0,0 +1,296 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+const (
+	// timeout for commands that don't specify one
+	DefaultCommandTimeout = 5 * time.Minute
+)
+
+// the command has already been started by this instance
+var errCommandClaimed = errors.New("command has already been claimed")
+
+// CommandController runs the commands of the cluster that target this
+// instance and reports their results
+type CommandController struct {
+	indexer  cache.Indexer
+	queue    workqueue.RateLimitingInterface
+	informer cache.Controller
+	log      *logrus.Entry
+	wing     *Wing
+}
+
+func NewCommandController(queue workqueue.RateLimitingInterface, indexer cache.Indexer, informer cache.Controller, wing *Wing) *CommandController {
+	return &CommandController{
+		informer: informer,
+		indexer:  indexer,
+		queue:    queue,
+		log:      wing.log.WithField("tier", "command-controller"),
+		wing:     wing,
+	}
+}
+
+func (w *Wing) watchForCommands() {
+	commandListWatcher := cache.NewListWatchFromClient(w.clientset.WingV1alpha1().RESTClient(), "commands", w.flags.ClusterName, fields.Everything())
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	// deleted commands are not of interest
+	indexer, informer := cache.NewIndexerInformer(commandListWatcher, &v1alpha1.Command{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			key, err := cache.MetaNamespaceKeyFunc(obj)
+			if err == nil {
+				queue.Add(key)
+			}
+		},
+		UpdateFunc: func(old interface{}, new interface{}) {
+			key, err := cache.MetaNamespaceKeyFunc(new)
+			if err == nil {
+				queue.Add(key)
+			}
+		},
+	}, cache.Indexers{})
+
+	w.commandController = NewCommandController(queue, indexer, informer, w)
+
+	go w.commandController.Run(1, w.stopCh)
+}
+
+func (c *CommandController) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.sync(key.(string))
+	c.handleErr(err, key)
+	return true
+}
+
+// sync starts commands targeting this instance, that have not been started
+// yet
+func (c *CommandController) sync(key string) error {
+	obj, exists, err := c.indexer.GetByKey(key)
+	if err != nil {
+		c.log.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	command := obj.(*v1alpha1.Command)
+	if !c.wing.commandTargetsInstance(command) {
+		return nil
+	}
+
+	if commandResult(command, c.wing.flags.InstanceName) != nil {
+		return nil
+	}
+
+	// claim the command before running it, so it only runs once
+	result := v1alpha1.CommandResult{
+		InstanceName:   c.wing.flags.InstanceName,
+		State:          v1alpha1.CommandStateRunning,
+		StartTimestamp: metav1.Now(),
+	}
+	if err := c.wing.reportCommandResult(command.Name, result, true); err == errCommandClaimed {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	go func() {
+		c.log.Infof("running command %s: %v", command.Name, command.Spec.Command)
+		result := runCommand(command.Spec, result)
+		c.log.Infof("command %s %s with exit code %d", command.Name, result.State, result.ExitCode)
+
+		if err := c.wing.reportCommandResult(command.Name, result, false); err != nil {
+			c.log.Warnf("reporting result of command %s failed: %s", command.Name, err)
+		}
+	}()
+
+	return nil
+}
+
+func (c *CommandController) handleErr(err error, key interface{}) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+
+	if c.queue.NumRequeues(key) < 5 {
+		c.log.Infof("Error syncing command %v: %v", key, err)
+		c.queue.AddRateLimited(key)
+		return
+	}
+
+	c.queue.Forget(key)
+	runtime.HandleError(err)
+	c.log.Infof("Dropping command %q out of the queue: %v", key, err)
+}
+
+func (c *CommandController) Run(threadiness int, stopCh chan struct{}) {
+	defer runtime.HandleCrash()
+
+	defer c.queue.ShutDown()
+	c.log.Info("Starting Command controller")
+
+	go c.informer.Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("Timed out waiting for caches to sync"))
+		return
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	c.log.Info("Stopping Command controller")
+}
+
+func (c *CommandController) runWorker() {
+	for c.processNextItem() {
+	}
+}
+
+// a command targets this instance if pool and label selector match
+func (w *Wing) commandTargetsInstance(command *v1alpha1.Command) bool {
+	if command.Spec == nil || len(command.Spec.Command) == 0 {
+		return false
+	}
+
+	if command.Spec.InstancePool != "" && command.Spec.InstancePool != w.flags.InstancePool {
+		return false
+	}
+
+	if command.Spec.Selector == nil {
+		return true
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(command.Spec.Selector)
+	if err != nil {
+		w.log.Warnf("invalid selector of command %s: %s", command.Name, err)
+		return false
+	}
+
+	return selector.Matches(labels.Set(w.instanceLabels()))
+}
+
+// labels of this instance's object in the wing API
+func (w *Wing) instanceLabels() map[string]string {
+	if w.controller == nil {
+		return nil
+	}
+
+	obj, exists, err := w.controller.indexer.GetByKey(fmt.Sprintf("%s/%s", w.flags.ClusterName, w.flags.InstanceName))
+	if err != nil || !exists {
+		return nil
+	}
+
+	return obj.(*v1alpha1.Instance).Labels
+}
+
+func commandResult(command *v1alpha1.Command, instanceName string) *v1alpha1.CommandResult {
+	if command.Status == nil {
+		return nil
+	}
+	for pos := range command.Status.Results {
+		if command.Status.Results[pos].InstanceName == instanceName {
+			return &command.Status.Results[pos]
+		}
+	}
+	return nil
+}
+
+// report the result of this instance, keeping the results of other instances.
+// When claiming, an existing result of this instance is not overwritten.
+func (w *Wing) reportCommandResult(name string, result v1alpha1.CommandResult, claim bool) error {
+	commandAPI := w.clientset.WingV1alpha1().Commands(w.flags.ClusterName)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		command, err := commandAPI.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error get command: %s", err)
+		}
+
+		if command.Status == nil {
+			command.Status = &v1alpha1.CommandStatus{}
+		}
+		if existing := commandResult(command, result.InstanceName); existing != nil {
+			if claim {
+				return errCommandClaimed
+			}
+			*existing = result
+		} else {
+			command.Status.Results = append(command.Status.Results, result)
+		}
+
+		_, err = commandAPI.Update(command)
+		return err
+	})
+}
+
+// run a command with its timeout and return the completed result
+func runCommand(spec *v1alpha1.CommandSpec, result v1alpha1.CommandResult) v1alpha1.CommandResult {
+	timeout := spec.Timeout.Duration
+	if timeout == 0 {
+		timeout = DefaultCommandTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, spec.Command[0], spec.Command[1:]...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	result.CompletionTimestamp = metav1.Now()
+	result.State = v1alpha1.CommandStateSucceeded
+	result.ExitCode = 0
+
+	if err != nil {
+		result.State = v1alpha1.CommandStateFailed
+		result.ExitCode = -1
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Exited() {
+				result.ExitCode = status.ExitStatus()
+			}
+		}
+
+		if ctx.Err() == context.DeadlineExceeded {
+			fmt.Fprintf(&output, "\ncommand timed out after %s", timeout)
+		} else if result.ExitCode == -1 {
+			fmt.Fprintf(&output, "\nerror running command: %s", err)
+		}
+	}
+
+	result.Output = truncateHead(output.String(), maxMessageBytes)
+	return result
+}
@@ -0,0 +1,80 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+func TestWing_commandTargetsInstance(t *testing.T) {
+	w := &Wing{
+		log:   logrus.NewEntry(logrus.New()),
+		flags: &Flags{InstancePool: "worker"},
+	}
+
+	for _, c := range []struct {
+		name    string
+		spec    *v1alpha1.CommandSpec
+		targets bool
+	}{
+		{"no spec", nil, false},
+		{"no command", &v1alpha1.CommandSpec{}, false},
+		{"all pools", &v1alpha1.CommandSpec{Command: []string{"uptime"}}, true},
+		{"matching pool", &v1alpha1.CommandSpec{Command: []string{"uptime"}, InstancePool: "worker"}, true},
+		{"other pool", &v1alpha1.CommandSpec{Command: []string{"uptime"}, InstancePool: "master"}, false},
+		{"empty selector", &v1alpha1.CommandSpec{Command: []string{"uptime"}, Selector: &metav1.LabelSelector{}}, true},
+		{"non matching selector", &v1alpha1.CommandSpec{
+			Command:  []string{"uptime"},
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "a"}},
+		}, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			command := &v1alpha1.Command{
+				ObjectMeta: metav1.ObjectMeta{Name: "exec-1"},
+				Spec:       c.spec,
+			}
+			if act := w.commandTargetsInstance(command); act != c.targets {
+				t.Errorf("unexpected result, exp=%t act=%t", c.targets, act)
+			}
+		})
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	start := v1alpha1.CommandResult{InstanceName: "i-1", State: v1alpha1.CommandStateRunning}
+
+	result := runCommand(&v1alpha1.CommandSpec{Command: []string{"sh", "-c", "echo hello; echo world >&2"}}, start)
+	if result.State != v1alpha1.CommandStateSucceeded || result.ExitCode != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if exp, act := "hello\nworld\n", result.Output; exp != act {
+		t.Errorf("unexpected output, exp=%q act=%q", exp, act)
+	}
+	if result.InstanceName != "i-1" || result.CompletionTimestamp.IsZero() {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	result = runCommand(&v1alpha1.CommandSpec{Command: []string{"sh", "-c", "exit 3"}}, start)
+	if result.State != v1alpha1.CommandStateFailed || result.ExitCode != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	result = runCommand(&v1alpha1.CommandSpec{
+		Command: []string{"sleep", "10"},
+		Timeout: metav1.Duration{Duration: 100 * time.Millisecond},
+	}, start)
+	if result.State != v1alpha1.CommandStateFailed || !strings.Contains(result.Output, "timed out") {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	result = runCommand(&v1alpha1.CommandSpec{Command: []string{"/does/not/exist"}}, start)
+	if result.State != v1alpha1.CommandStateFailed || result.ExitCode != -1 || !strings.Contains(result.Output, "error running command") {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
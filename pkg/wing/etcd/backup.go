@@ -0,0 +1,149 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/sirupsen/logrus"
+)
+
+const backupTimeout = time.Minute
+
+type objectAPI interface {
+	GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+}
+
+// Backup stores snapshots of wing's state in S3, so the state survives the
+// loss of the bastion running etcd
+type Backup struct {
+	kv     clientv3.KV
+	prefix string
+	bucket string
+	key    string
+	log    *logrus.Entry
+
+	s3 objectAPI
+}
+
+// NewS3Backup stores snapshots at a s3://bucket/key URL, in the region of the
+// wing server
+func NewS3Backup(kv clientv3.KV, prefix, snapshotURL string, log *logrus.Entry) (*Backup, error) {
+	u, err := url.Parse(snapshotURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing snapshot URL '%s': %s", snapshotURL, err)
+	}
+	if u.Scheme != "s3" || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return nil, fmt.Errorf("snapshot URL '%s' needs to be of the form s3://bucket/key", snapshotURL)
+	}
+
+	awsSession, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := ec2metadata.New(awsSession).GetInstanceIdentityDocument()
+	if err != nil {
+		return nil, fmt.Errorf("error getting identity document of wing server: %s", err)
+	}
+
+	return &Backup{
+		kv:     kv,
+		prefix: prefix,
+		bucket: u.Host,
+		key:    strings.TrimPrefix(u.Path, "/"),
+		log:    log,
+		s3:     s3.New(awsSession, aws.NewConfig().WithRegion(document.Region)),
+	}, nil
+}
+
+// Upload saves a snapshot of the current state
+func (b *Backup) Upload() error {
+	ctx, cancel := context.WithTimeout(context.Background(), backupTimeout)
+	defer cancel()
+
+	var buf bytes.Buffer
+	snapshot, err := Save(ctx, b.kv, b.prefix, &buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.s3.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String(b.bucket),
+		Key:                  aws.String(b.key),
+		Body:                 bytes.NewReader(buf.Bytes()),
+		ContentType:          aws.String("application/json"),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	})
+	if err != nil {
+		return fmt.Errorf("error uploading snapshot to s3://%s/%s: %s", b.bucket, b.key, err)
+	}
+
+	b.log.Debugf("uploaded snapshot of %d keys at revision %d to s3://%s/%s", len(snapshot.Entries), snapshot.Revision, b.bucket, b.key)
+	return nil
+}
+
+// RestoreIfEmpty restores the latest snapshot, if there is no state in etcd
+// yet. This is the case after the bastion has been replaced.
+func (b *Backup) RestoreIfEmpty() error {
+	ctx, cancel := context.WithTimeout(context.Background(), backupTimeout)
+	defer cancel()
+
+	existing, err := existingKeys(ctx, b.kv, strings.TrimSuffix(b.prefix, "/"))
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	obj, err := b.s3.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			b.log.Infof("no snapshot found at s3://%s/%s, starting with empty state", b.bucket, b.key)
+			return nil
+		}
+		return fmt.Errorf("error downloading snapshot from s3://%s/%s: %s", b.bucket, b.key, err)
+	}
+	defer obj.Body.Close()
+
+	snapshot, err := Restore(ctx, b.kv, b.prefix, obj.Body, false)
+	if err != nil {
+		return err
+	}
+
+	b.log.Infof("restored %d keys of snapshot taken at %s", len(snapshot.Entries), snapshot.CreationTimestamp)
+	return nil
+}
+
+// Run uploads a snapshot every interval until stopCh is closed
+func (b *Backup) Run(interval time.Duration, stopCh <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.Upload(); err != nil {
+			b.log.Warnf("error backing up wing state: %s", err)
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package etcd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeObjectAPI stores objects in a map
+type fakeObjectAPI struct {
+	objects map[string][]byte
+}
+
+func (f *fakeObjectAPI) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	data, ok := f.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "not found", nil)
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (f *fakeObjectAPI) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	data, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func newFakeBackup(kv *fakeKV, objects *fakeObjectAPI) *Backup {
+	return &Backup{
+		kv:     kv,
+		prefix: "/wing",
+		bucket: "secrets",
+		key:    "bastion/wing-snapshot/snapshot.json",
+		log:    logrus.WithField("test", true),
+		s3:     objects,
+	}
+}
+
+func TestBackup_UploadRestoreIfEmpty(t *testing.T) {
+	objects := &fakeObjectAPI{objects: make(map[string][]byte)}
+
+	// nothing to restore yet
+	target := newFakeKV(nil)
+	if err := newFakeBackup(target, objects).RestoreIfEmpty(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp, act := 0, len(target.data); exp != act {
+		t.Errorf("unexpected number of keys: exp=%d act=%d", exp, act)
+	}
+
+	source := newFakeKV(map[string]string{
+		"/wing/wing.tarmak.io/instances/env-cluster/i-1": "instance-1",
+		"/wing/leader-election/snapshot/694d":            "bastion_1",
+	})
+	if err := newFakeBackup(source, objects).Upload(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := objects.objects["secrets/bastion/wing-snapshot/snapshot.json"]; !ok {
+		t.Fatal("expected snapshot to be uploaded")
+	}
+
+	// a new bastion starts with empty state
+	if err := newFakeBackup(target, objects).RestoreIfEmpty(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp, act := "instance-1", target.data["/wing/wing.tarmak.io/instances/env-cluster/i-1"]; exp != act {
+		t.Errorf("expected state to be restored: exp=%s act=%s", exp, act)
+	}
+
+	// existing state is never overwritten
+	target.data["/wing/wing.tarmak.io/instances/env-cluster/i-1"] = "newer"
+	if err := newFakeBackup(target, objects).RestoreIfEmpty(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp, act := "newer", target.data["/wing/wing.tarmak.io/instances/env-cluster/i-1"]; exp != act {
+		t.Errorf("expected existing state to be kept: exp=%s act=%s", exp, act)
+	}
+}
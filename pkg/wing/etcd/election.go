@@ -0,0 +1,84 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package etcd
+
+import (
+	"context"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/concurrency"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// seconds after which the leadership of an unresponsive wing server
+	// expires
+	leaderTTL = 15
+
+	leaderRetryInterval = 5 * time.Second
+	leaderResignTimeout = 5 * time.Second
+)
+
+// RunAsLeader campaigns for the leadership of key and calls run while this
+// process is the leader. The stop channel passed to run is closed when the
+// leadership is lost. It returns after stopCh is closed.
+func RunAsLeader(client *clientv3.Client, key, identity string, log *logrus.Entry, stopCh <-chan struct{}, run func(stopCh <-chan struct{})) {
+	for {
+		if err := runAsLeader(client, key, identity, log, stopCh, run); err != nil {
+			log.Warnf("leader election failed: %s", err)
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(leaderRetryInterval):
+		}
+	}
+}
+
+func runAsLeader(client *clientv3.Client, key, identity string, log *logrus.Entry, stopCh <-chan struct{}, run func(stopCh <-chan struct{})) error {
+	session, err := concurrency.NewSession(client, concurrency.WithTTL(leaderTTL))
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	election := concurrency.NewElection(session, key)
+	log.Debugf("campaigning for leadership of %s as %s", key, identity)
+	if err := election.Campaign(ctx, identity); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	log.Infof("became leader of %s", key)
+
+	leaderCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(leaderCh)
+	}()
+
+	select {
+	case <-stopCh:
+	case <-session.Done():
+		log.Warnf("lost leadership of %s", key)
+	}
+	close(leaderCh)
+	<-done
+
+	resignCtx, resignCancel := context.WithTimeout(context.Background(), leaderResignTimeout)
+	defer resignCancel()
+	return election.Resign(resignCtx)
+}
@@ -0,0 +1,64 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package etcd
+
+import (
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/transport"
+	"github.com/spf13/pflag"
+)
+
+const (
+	DefaultPrefix = "/registry/wing.tarmak.io"
+
+	// keys below the storage prefix used for leader election, they are not
+	// part of snapshots
+	LeaderElectionPrefix = "/leader-election"
+
+	dialTimeout = 10 * time.Second
+)
+
+// Options to connect to the etcd cluster storing wing's state
+type Options struct {
+	Servers  []string
+	CAFile   string
+	CertFile string
+	KeyFile  string
+	Prefix   string
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&o.Servers, "etcd-servers", []string{"http://127.0.0.1:2379"}, "list of etcd servers to connect with (scheme://ip:port), comma separated")
+	fs.StringVar(&o.CAFile, "etcd-cafile", "", "SSL Certificate Authority file used to secure etcd communication")
+	fs.StringVar(&o.CertFile, "etcd-certfile", "", "SSL certification file used to secure etcd communication")
+	fs.StringVar(&o.KeyFile, "etcd-keyfile", "", "SSL key file used to secure etcd communication")
+	fs.StringVar(&o.Prefix, "etcd-prefix", DefaultPrefix, "the prefix of wing's keys in etcd")
+}
+
+// NewClient connects to etcd, TLS is only used if any of the TLS files is set
+func NewClient(servers []string, caFile, certFile, keyFile string) (*clientv3.Client, error) {
+	config := clientv3.Config{
+		DialTimeout: dialTimeout,
+		Endpoints:   servers,
+	}
+
+	if caFile != "" || certFile != "" || keyFile != "" {
+		tlsInfo := transport.TLSInfo{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+			CAFile:   caFile,
+		}
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		config.TLS = tlsConfig
+	}
+
+	return clientv3.New(config)
+}
+
+func (o *Options) NewClient() (*clientv3.Client, error) {
+	return NewClient(o.Servers, o.CAFile, o.CertFile, o.KeyFile)
+}
@@ -0,0 +1,139 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+const (
+	snapshotVersion = 1
+
+	// default limit of operations in a single etcd transaction
+	maxTxnOps = 128
+)
+
+// Snapshot contains all keys of wing's state in etcd. Keys are relative to the
+// storage prefix, so a snapshot can be restored to a different prefix.
+type Snapshot struct {
+	Version           int             `json:"version"`
+	Prefix            string          `json:"prefix"`
+	Revision          int64           `json:"revision"`
+	CreationTimestamp time.Time       `json:"creationTimestamp"`
+	Entries           []SnapshotEntry `json:"entries"`
+}
+
+type SnapshotEntry struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Save writes a snapshot of all keys below prefix, except leader election
+// keys
+func Save(ctx context.Context, kv clientv3.KV, prefix string, w io.Writer) (*Snapshot, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	resp, err := kv.Get(ctx, prefix+"/", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, fmt.Errorf("error reading keys below %s: %s", prefix, err)
+	}
+
+	snapshot := &Snapshot{
+		Version:           snapshotVersion,
+		Prefix:            prefix,
+		Revision:          resp.Header.GetRevision(),
+		CreationTimestamp: time.Now().UTC(),
+		Entries:           []SnapshotEntry{},
+	}
+
+	for _, kv := range resp.Kvs {
+		key := strings.TrimPrefix(string(kv.Key), prefix)
+		if strings.HasPrefix(key, LeaderElectionPrefix+"/") {
+			continue
+		}
+		snapshot.Entries = append(snapshot.Entries, SnapshotEntry{
+			Key:   key,
+			Value: kv.Value,
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return nil, fmt.Errorf("error writing snapshot: %s", err)
+	}
+
+	return snapshot, nil
+}
+
+// Restore replaces all keys below prefix with the keys of a snapshot. Unless
+// force is set, it refuses to overwrite existing state.
+func Restore(ctx context.Context, kv clientv3.KV, prefix string, r io.Reader, force bool) (*Snapshot, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %s", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+
+	if !force {
+		existing, err := existingKeys(ctx, kv, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if existing > 0 {
+			return nil, fmt.Errorf("found %d existing keys below %s, use force to overwrite them", existing, prefix)
+		}
+	}
+
+	// replace all existing keys, except leader election keys
+	ops := []clientv3.Op{
+		clientv3.OpDelete(prefix+"/", clientv3.WithRange(prefix+LeaderElectionPrefix+"/")),
+		clientv3.OpDelete(clientv3.GetPrefixRangeEnd(prefix+LeaderElectionPrefix+"/"), clientv3.WithRange(clientv3.GetPrefixRangeEnd(prefix+"/"))),
+	}
+	for _, entry := range snapshot.Entries {
+		if !strings.HasPrefix(entry.Key, "/") || strings.HasPrefix(entry.Key, LeaderElectionPrefix+"/") {
+			return nil, fmt.Errorf("invalid key '%s' in snapshot", entry.Key)
+		}
+		ops = append(ops, clientv3.OpPut(prefix+entry.Key, string(entry.Value)))
+	}
+
+	// etcd limits the number of operations per transaction, larger snapshots
+	// are written in multiple transactions after the existing keys have been
+	// deleted
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > maxTxnOps {
+			n = maxTxnOps
+		}
+		if _, err := kv.Txn(ctx).Then(ops[:n]...).Commit(); err != nil {
+			return nil, fmt.Errorf("error restoring snapshot: %s", err)
+		}
+		ops = ops[n:]
+	}
+
+	return snapshot, nil
+}
+
+// number of keys below prefix, except leader election keys
+func existingKeys(ctx context.Context, kv clientv3.KV, prefix string) (int, error) {
+	resp, err := kv.Get(ctx, prefix+"/", clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return 0, fmt.Errorf("error reading keys below %s: %s", prefix, err)
+	}
+
+	count := 0
+	for _, kv := range resp.Kvs {
+		if !strings.HasPrefix(string(kv.Key), prefix+LeaderElectionPrefix+"/") {
+			count++
+		}
+	}
+	return count, nil
+}
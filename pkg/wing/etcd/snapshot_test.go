@@ -0,0 +1,212 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+// fakeKV stores keys in a map and supports prefix gets, puts and range
+// deletes in transactions
+type fakeKV struct {
+	clientv3.KV
+	data map[string]string
+	txns int
+}
+
+func newFakeKV(data map[string]string) *fakeKV {
+	if data == nil {
+		data = make(map[string]string)
+	}
+	return &fakeKV{data: data}
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	op := clientv3.OpGet(key, opts...)
+
+	var keys []string
+	for k := range f.data {
+		if k == key || (op.RangeBytes() != nil && strings.HasPrefix(k, key)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	resp := &clientv3.GetResponse{Header: &pb.ResponseHeader{Revision: 42}}
+	for _, k := range keys {
+		kv := &mvccpb.KeyValue{Key: []byte(k)}
+		if !op.IsKeysOnly() {
+			kv.Value = []byte(f.data[k])
+		}
+		resp.Kvs = append(resp.Kvs, kv)
+	}
+	return resp, nil
+}
+
+func (f *fakeKV) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	f.data[key] = val
+	return &clientv3.PutResponse{}, nil
+}
+
+func (f *fakeKV) Txn(ctx context.Context) clientv3.Txn {
+	f.txns++
+	return &fakeTxn{kv: f}
+}
+
+type fakeTxn struct {
+	clientv3.Txn
+	kv  *fakeKV
+	ops []clientv3.Op
+}
+
+func (t *fakeTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	t.ops = append(t.ops, ops...)
+	return t
+}
+
+func (t *fakeTxn) Commit() (*clientv3.TxnResponse, error) {
+	for _, op := range t.ops {
+		key := string(op.KeyBytes())
+		switch {
+		case op.IsPut():
+			t.kv.data[key] = string(op.ValueBytes())
+		case op.IsDelete():
+			end := string(op.RangeBytes())
+			for k := range t.kv.data {
+				if k == key || (end != "" && k >= key && k < end) {
+					delete(t.kv.data, k)
+				}
+			}
+		}
+	}
+	return &clientv3.TxnResponse{}, nil
+}
+
+func TestSnapshot_SaveRestore(t *testing.T) {
+	source := newFakeKV(map[string]string{
+		"/registry/wing.tarmak.io/wing.tarmak.io/instances/env-cluster/i-1": "instance-1",
+		"/registry/wing.tarmak.io/wing.tarmak.io/instances/env-cluster/i-2": "instance-2",
+		"/registry/wing.tarmak.io/leader-election/instance-gc/694d":         "bastion_1",
+		"/registry/other/key": "other",
+	})
+
+	var buf bytes.Buffer
+	snapshot, err := Save(context.Background(), source, "/registry/wing.tarmak.io/", &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp, act := 2, len(snapshot.Entries); exp != act {
+		t.Fatalf("unexpected number of entries: exp=%d act=%d", exp, act)
+	}
+	if exp, act := int64(42), snapshot.Revision; exp != act {
+		t.Errorf("unexpected revision: exp=%d act=%d", exp, act)
+	}
+
+	target := newFakeKV(map[string]string{
+		"/wing/leader-election/instance-gc/694d": "bastion_2",
+	})
+	if _, err := Restore(context.Background(), target, "/wing", bytes.NewReader(buf.Bytes()), false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for key, exp := range map[string]string{
+		"/wing/wing.tarmak.io/instances/env-cluster/i-1": "instance-1",
+		"/wing/wing.tarmak.io/instances/env-cluster/i-2": "instance-2",
+		"/wing/leader-election/instance-gc/694d":         "bastion_2",
+	} {
+		if act := target.data[key]; exp != act {
+			t.Errorf("unexpected value of %s: exp=%s act=%s", key, exp, act)
+		}
+	}
+	if exp, act := 3, len(target.data); exp != act {
+		t.Errorf("unexpected number of keys: exp=%d act=%d", exp, act)
+	}
+}
+
+func TestSnapshot_RestoreExisting(t *testing.T) {
+	var buf bytes.Buffer
+	source := newFakeKV(map[string]string{
+		"/wing/wing.tarmak.io/instances/env-cluster/i-1": "instance-1",
+	})
+	if _, err := Save(context.Background(), source, "/wing", &buf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	target := newFakeKV(map[string]string{
+		"/wing/wing.tarmak.io/instances/env-cluster/i-1": "newer",
+	})
+	if _, err := Restore(context.Background(), target, "/wing", bytes.NewReader(buf.Bytes()), false); err == nil {
+		t.Errorf("expected error restoring over existing state")
+	}
+	if exp, act := "newer", target.data["/wing/wing.tarmak.io/instances/env-cluster/i-1"]; exp != act {
+		t.Errorf("expected existing state to be kept: exp=%s act=%s", exp, act)
+	}
+
+	// keys missing in the snapshot are removed, leader election is kept
+	target.data["/wing/wing.tarmak.io/instances/env-cluster/i-2"] = "stale"
+	target.data["/wing/leader-election/instance-gc/694d"] = "bastion_2"
+	target.data["/wing0"] = "outside"
+	if _, err := Restore(context.Background(), target, "/wing", bytes.NewReader(buf.Bytes()), true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for key, exp := range map[string]string{
+		"/wing/wing.tarmak.io/instances/env-cluster/i-1": "instance-1",
+		"/wing/leader-election/instance-gc/694d":         "bastion_2",
+		"/wing0":                                         "outside",
+	} {
+		if act := target.data[key]; exp != act {
+			t.Errorf("unexpected value of %s: exp=%s act=%s", key, exp, act)
+		}
+	}
+	if _, ok := target.data["/wing/wing.tarmak.io/instances/env-cluster/i-2"]; ok {
+		t.Error("expected key missing in the snapshot to be removed")
+	}
+	if exp, act := 3, len(target.data); exp != act {
+		t.Errorf("unexpected number of keys: exp=%d act=%d", exp, act)
+	}
+}
+
+func TestSnapshot_RestoreLarge(t *testing.T) {
+	source := newFakeKV(nil)
+	for i := 0; i < 300; i++ {
+		source.data[fmt.Sprintf("/wing/wing.tarmak.io/instances/env-cluster/i-%03d", i)] = "instance"
+	}
+
+	var buf bytes.Buffer
+	if _, err := Save(context.Background(), source, "/wing", &buf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	target := newFakeKV(nil)
+	if _, err := Restore(context.Background(), target, "/wing", bytes.NewReader(buf.Bytes()), false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp, act := 300, len(target.data); exp != act {
+		t.Errorf("unexpected number of keys: exp=%d act=%d", exp, act)
+	}
+	// 2 deletes and 300 puts
+	if exp, act := 3, target.txns; exp != act {
+		t.Errorf("unexpected number of transactions: exp=%d act=%d", exp, act)
+	}
+}
+
+func TestSnapshot_RestoreInvalid(t *testing.T) {
+	for _, data := range []string{
+		`not json`,
+		`{"version":2,"entries":[]}`,
+		`{"version":1,"entries":[{"key":"no-slash","value":""}]}`,
+		`{"version":1,"entries":[{"key":"/leader-election/gc","value":""}]}`,
+	} {
+		if _, err := Restore(context.Background(), newFakeKV(nil), "/wing", strings.NewReader(data), false); err == nil {
+			t.Errorf("expected error restoring %s", data)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+const (
+	DefaultFactsInterval = 15 * time.Minute
+
+	// timeout for commands reporting software versions
+	factsCommandTimeout = 30 * time.Second
+)
+
+// filesystem types of local disks, all others are ignored for disk usage
+var localFilesystemTypes = map[string]bool{
+	"ext2":  true,
+	"ext3":  true,
+	"ext4":  true,
+	"xfs":   true,
+	"btrfs": true,
+}
+
+// gather and report facts periodically and after every converge, until wing
+// stops
+func (w *Wing) reportFactsLoop() {
+	ticker := time.NewTicker(w.flags.FactsInterval)
+	defer ticker.Stop()
+
+	for {
+		w.setFacts(w.gatherFacts())
+		if err := w.reportFacts(); err != nil {
+			w.log.Warn("reporting facts failed: ", err)
+		}
+
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+		case <-w.factsCh:
+		}
+	}
+}
+
+// trigger gathering facts, without blocking if facts are already being
+// gathered
+func (w *Wing) triggerFacts() {
+	select {
+	case w.factsCh <- struct{}{}:
+	default:
+	}
+}
+
+func (w *Wing) setFacts(facts *v1alpha1.InstanceFacts) {
+	w.factsMu.Lock()
+	defer w.factsMu.Unlock()
+	w.facts = facts
+}
+
+func (w *Wing) currentFacts() *v1alpha1.InstanceFacts {
+	w.factsMu.Lock()
+	defer w.factsMu.Unlock()
+	return w.facts.DeepCopy()
+}
+
+// report facts to the API server, keeping the rest of the instance's status
+func (w *Wing) reportFacts() error {
+	facts := w.currentFacts()
+	if facts == nil {
+		return nil
+	}
+
+	instanceAPI := w.clientset.WingV1alpha1().Instances(w.flags.ClusterName)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance, err := instanceAPI.Get(
+			w.flags.InstanceName,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return fmt.Errorf("error get existing instance: %s", err)
+		}
+
+		if instance.Status == nil {
+			instance.Status = &v1alpha1.InstanceStatus{}
+		}
+		instance.Status.Facts = facts
+
+		_, err = instanceAPI.Update(instance)
+		return err
+	})
+}
+
+// gather facts about the instance, facts that can't be gathered are left
+// empty
+func (w *Wing) gatherFacts() *v1alpha1.InstanceFacts {
+	facts := &v1alpha1.InstanceFacts{
+		LastUpdateTimestamp: metav1.Now(),
+	}
+
+	if w.tags != nil {
+		instanceType, err := w.tags.InstanceType()
+		if err != nil {
+			w.log.Debugf("unable to get instance type: %s", err)
+		}
+		facts.InstanceType = instanceType
+	}
+
+	if data, err := ioutil.ReadFile("/proc/sys/kernel/osrelease"); err != nil {
+		w.log.Debugf("unable to read kernel release: %s", err)
+	} else {
+		facts.Kernel = strings.TrimSpace(string(data))
+	}
+
+	if data, err := ioutil.ReadFile("/etc/os-release"); err != nil {
+		w.log.Debugf("unable to read os release: %s", err)
+	} else {
+		facts.OSRelease = parseOSRelease(data)
+	}
+
+	if data, err := ioutil.ReadFile("/proc/stat"); err != nil {
+		w.log.Debugf("unable to read boot time: %s", err)
+	} else if bootTime, err := parseBootTime(data); err != nil {
+		w.log.Debugf("unable to read boot time: %s", err)
+	} else {
+		facts.BootTimestamp = metav1.NewTime(bootTime)
+	}
+
+	facts.PuppetVersion = w.factsCommand("puppet", "--version")
+	facts.KubeletVersion = strings.TrimPrefix(w.factsCommand("kubelet", "--version"), "Kubernetes ")
+	facts.DockerVersion = parseDockerVersion(w.factsCommand("docker", "--version"))
+	facts.RebootRequired = w.rebootRequired()
+
+	if data, err := ioutil.ReadFile("/proc/mounts"); err != nil {
+		w.log.Debugf("unable to read mounts: %s", err)
+	} else {
+		facts.Disks = w.diskUsage(parseMounts(data))
+	}
+
+	return facts
+}
+
+// run a command and return its trimmed output, commands that are not
+// installed return an empty string
+func (w *Wing) factsCommand(name string, args ...string) string {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return ""
+	}
+
+	cmd := exec.Command(path, args...)
+	timer := time.AfterFunc(factsCommandTimeout, func() {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+	})
+	defer timer.Stop()
+
+	output, err := cmd.Output()
+	if err != nil {
+		w.log.Debugf("error running %s: %s", name, err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+// check for pending reboots on debian and redhat based systems
+func (w *Wing) rebootRequired() bool {
+	if _, err := os.Stat("/var/run/reboot-required"); err == nil {
+		return true
+	}
+
+	path, err := exec.LookPath("needs-restarting")
+	if err != nil {
+		return false
+	}
+
+	// exits with 1 if a reboot is required
+	err = exec.Command(path, "-r").Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus() == 1
+		}
+	}
+
+	return false
+}
+
+func (w *Wing) diskUsage(paths []string) []v1alpha1.InstanceDiskUsage {
+	var disks []v1alpha1.InstanceDiskUsage
+	for _, path := range paths {
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(path, &stat); err != nil {
+			w.log.Debugf("unable to get disk usage of %s: %s", path, err)
+			continue
+		}
+
+		disks = append(disks, v1alpha1.InstanceDiskUsage{
+			Path:       path,
+			TotalBytes: int64(stat.Blocks) * int64(stat.Bsize),
+			UsedBytes:  int64(stat.Blocks-stat.Bfree) * int64(stat.Bsize),
+		})
+	}
+	return disks
+}
+
+// PRETTY_NAME of /etc/os-release, falls back to NAME and VERSION_ID
+func parseOSRelease(data []byte) string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		values[parts[0]] = strings.Trim(parts[1], `"'`)
+	}
+
+	if name := values["PRETTY_NAME"]; name != "" {
+		return name
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s", values["NAME"], values["VERSION_ID"]))
+}
+
+// btime of /proc/stat
+func parseBootTime(data []byte) (time.Time, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[0] != "btime" {
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error parsing btime '%s': %s", fields[1], err)
+		}
+		return time.Unix(seconds, 0), nil
+	}
+
+	return time.Time{}, fmt.Errorf("btime not found")
+}
+
+// mount points of local filesystems in /proc/mounts, every device is only
+// listed once
+func parseMounts(data []byte) []string {
+	var paths []string
+	devices := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !localFilesystemTypes[fields[2]] || devices[fields[0]] {
+			continue
+		}
+		devices[fields[0]] = true
+		paths = append(paths, fields[1])
+	}
+
+	return paths
+}
+
+// version of 'Docker version 1.13.1, build 07f3374/1.13.1'
+func parseDockerVersion(output string) string {
+	fields := strings.Fields(output)
+	if len(fields) < 3 || fields[0] != "Docker" {
+		return ""
+	}
+	return strings.TrimSuffix(fields[2], ",")
+}
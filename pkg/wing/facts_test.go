@@ -0,0 +1,83 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		data string
+		exp  string
+	}{
+		{
+			name: "pretty name",
+			data: "NAME=\"CentOS Linux\"\nVERSION=\"7 (Core)\"\nID=\"centos\"\nVERSION_ID=\"7\"\nPRETTY_NAME=\"CentOS Linux 7 (Core)\"\n",
+			exp:  "CentOS Linux 7 (Core)",
+		},
+		{
+			name: "name and version",
+			data: "NAME=Ubuntu\nVERSION_ID='18.04'\n",
+			exp:  "Ubuntu 18.04",
+		},
+		{
+			name: "empty",
+			data: "",
+			exp:  "",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if act := parseOSRelease([]byte(c.data)); act != c.exp {
+				t.Errorf("unexpected os release, exp=%s act=%s", c.exp, act)
+			}
+		})
+	}
+}
+
+func TestParseBootTime(t *testing.T) {
+	data := "cpu  1051 0 1433 1120334 126 0 12 0 0 0\nintr 145662 22 9 0\nctxt 340276\nbtime 1545130345\nprocesses 2713\n"
+
+	bootTime, err := parseBootTime([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp := time.Unix(1545130345, 0); !bootTime.Equal(exp) {
+		t.Errorf("unexpected boot time, exp=%s act=%s", exp, bootTime)
+	}
+
+	if _, err := parseBootTime([]byte("cpu 1 2 3\n")); err == nil {
+		t.Error("expected error without btime")
+	}
+}
+
+func TestParseMounts(t *testing.T) {
+	data := `rootfs / rootfs rw 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/xvda1 / xfs rw,relatime,attr2,inode64,noquota 0 0
+tmpfs /run tmpfs rw,nosuid,nodev,mode=755 0 0
+/dev/xvdd /var/lib/docker xfs rw,relatime,attr2,inode64,noquota 0 0
+/dev/xvdd /var/lib/docker/overlay xfs rw,relatime,attr2,inode64,noquota 0 0
+/dev/xvde /var/lib/etcd ext4 rw,relatime,data=ordered 0 0
+`
+
+	exp := []string{"/", "/var/lib/docker", "/var/lib/etcd"}
+	if act := parseMounts([]byte(data)); !reflect.DeepEqual(exp, act) {
+		t.Errorf("unexpected mounts, exp=%v act=%v", exp, act)
+	}
+}
+
+func TestParseDockerVersion(t *testing.T) {
+	for output, exp := range map[string]string{
+		"Docker version 1.13.1, build 07f3374/1.13.1": "1.13.1",
+		"Docker version 18.06.1-ce, build e68fc7a":    "18.06.1-ce",
+		"":                                "",
+		"bash: docker: command not found": "",
+	} {
+		if act := parseDockerVersion(output); act != exp {
+			t.Errorf("unexpected docker version for '%s', exp=%s act=%s", output, exp, act)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/jetstack/tarmak/pkg/wing/provider"
+	"github.com/jetstack/tarmak/pkg/wing/signature"
+)
+
+// manifestRejectedError is returned for manifests failing signature
+// verification, these are never unpacked or applied
+type manifestRejectedError struct {
+	err error
+}
+
+func (e *manifestRejectedError) Error() string {
+	return fmt.Sprintf("manifest signature verification failed, refusing to apply: %s", e.err)
+}
+
+func isManifestRejected(err error) bool {
+	_, ok := err.(*manifestRejectedError)
+	return ok
+}
+
+// read the public key manifests are verified against
+func (w *Wing) loadManifestPublicKey() error {
+	if w.flags.ManifestPublicKeyFile == "" {
+		w.log.Warn("no manifest public key configured, manifest signatures are not verified")
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(w.flags.ManifestPublicKeyFile)
+	if err != nil {
+		return fmt.Errorf("error reading manifest public key: %s", err)
+	}
+
+	key, err := signature.ParsePublicKey(data)
+	if err != nil {
+		return fmt.Errorf("error parsing manifest public key '%s': %s", w.flags.ManifestPublicKeyFile, err)
+	}
+
+	w.manifestPublicKey = key
+	return nil
+}
+
+// verify the detached signature stored next to the manifest, this is a no-op
+// without a public key configured
+func (w *Wing) verifyManifest(manifestURL string, manifest []byte) error {
+	if w.manifestPublicKey == nil {
+		return nil
+	}
+
+	md5Sum := md5.Sum(manifest)
+	sig, err := provider.GetSignature(w.log, manifestURL, hex.EncodeToString(md5Sum[:]))
+	if err != nil {
+		return &manifestRejectedError{fmt.Errorf("error getting signature: %s", err)}
+	}
+
+	if err := signature.Verify(w.manifestPublicKey, manifest, sig); err != nil {
+		return &manifestRejectedError{err}
+	}
+
+	w.log.Info("manifest signature verified")
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jetstack/tarmak/pkg/wing/signature"
+)
+
+func TestWing_verifyManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wing-manifest-signature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, err := signature.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := signature.EncodePublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubFile := filepath.Join(dir, "manifest-signing.pem")
+	if err := ioutil.WriteFile(pubFile, pubPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := []byte("signed manifest")
+	manifestURL := filepath.Join(dir, "puppet.tar.gz")
+	sig, err := signature.Sign(key, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(manifestURL+signature.Suffix, sig, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	w := &Wing{
+		log:   logrus.NewEntry(logger),
+		flags: &Flags{},
+	}
+
+	// without a public key nothing is verified
+	if err := w.loadManifestPublicKey(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := w.verifyManifest(manifestURL, []byte("anything")); err != nil {
+		t.Errorf("expected no verification without public key, got: %s", err)
+	}
+
+	w.flags.ManifestPublicKeyFile = pubFile
+	if err := w.loadManifestPublicKey(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := w.verifyManifest(manifestURL, manifest); err != nil {
+		t.Errorf("expected signed manifest to be verified, got: %s", err)
+	}
+
+	if err := w.verifyManifest(manifestURL, []byte("tampered manifest")); !isManifestRejected(err) {
+		t.Errorf("expected tampered manifest to be rejected, got: %v", err)
+	}
+
+	if err := w.verifyManifest(filepath.Join(dir, "unsigned.tar.gz"), manifest); !isManifestRejected(err) {
+		t.Errorf("expected manifest without signature to be rejected, got: %v", err)
+	}
+
+	w.flags.ManifestPublicKeyFile = filepath.Join(dir, "missing.pem")
+	if err := w.loadManifestPublicKey(); err == nil {
+		t.Error("expected error loading missing public key")
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+const (
+	DefaultMetricsBindAddress = ":9102"
+
+	// location of puppet's summary of the last run
+	DefaultPuppetLastRunSummary = "/opt/puppetlabs/puppet/cache/state/last_run_summary.yaml"
+
+	metricsNamespace = "wing"
+)
+
+var (
+	puppetRunDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "puppet",
+		Name:      "run_duration_seconds",
+		Help:      "Duration of puppet apply runs.",
+		Buckets:   []float64{10, 30, 60, 120, 300, 600, 1200, 1800},
+	})
+
+	puppetRunExitCode = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "puppet",
+		Name:      "run_exit_code",
+		Help:      "Detailed exit code of the last puppet apply run.",
+	})
+
+	puppetRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "puppet",
+		Name:      "runs_total",
+		Help:      "Number of puppet apply runs by result.",
+	}, []string{"result"})
+
+	puppetRetriesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "puppet",
+		Name:      "retries_total",
+		Help:      "Number of puppet apply runs retried after a failed run.",
+	})
+
+	puppetResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "puppet",
+		Name:      "resources",
+		Help:      "Number of resources of the last puppet apply run by state, as reported in puppet's last run summary.",
+	}, []string{"state"})
+
+	convergeState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "converge",
+		Name:      "state",
+		Help:      "Current converge state of the instance, the active state is set to 1.",
+	}, []string{"state"})
+
+	convergeLastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "converge",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful converge.",
+	})
+
+	convergeSecondsSinceLastSuccess = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "converge",
+		Name:      "seconds_since_last_success",
+		Help:      "Seconds since the last successful converge, or since wing started if it has not converged yet.",
+	}, secondsSinceLastSuccess)
+
+	// time of the last successful converge, starts with wing's start
+	lastSuccess   = time.Now()
+	lastSuccessMu sync.Mutex
+)
+
+func init() {
+	prometheus.MustRegister(
+		puppetRunDuration,
+		puppetRunExitCode,
+		puppetRunsTotal,
+		puppetRetriesTotal,
+		puppetResources,
+		convergeState,
+		convergeLastSuccess,
+		convergeSecondsSinceLastSuccess,
+	)
+}
+
+// the resources section of puppet's last_run_summary.yaml
+type puppetRunSummary struct {
+	Resources map[string]float64 `yaml:"resources"`
+}
+
+func secondsSinceLastSuccess() float64 {
+	lastSuccessMu.Lock()
+	defer lastSuccessMu.Unlock()
+	return time.Since(lastSuccess).Seconds()
+}
+
+// record the result of a single puppet apply run
+func (w *Wing) observePuppetRun(duration time.Duration, retCode int, attempt int, err error) {
+	puppetRunDuration.Observe(duration.Seconds())
+	puppetRunExitCode.Set(float64(retCode))
+
+	if attempt > 1 {
+		puppetRetriesTotal.Inc()
+	}
+
+	if err != nil {
+		puppetRunsTotal.WithLabelValues("failure").Inc()
+	} else {
+		puppetRunsTotal.WithLabelValues("success").Inc()
+	}
+
+	if w.puppetLastRunSummary == "" {
+		return
+	}
+
+	summary, err := readPuppetRunSummary(w.puppetLastRunSummary)
+	if err != nil {
+		w.log.Debugf("unable to read puppet's last run summary: %s", err)
+		return
+	}
+
+	for state, count := range summary.Resources {
+		puppetResources.WithLabelValues(state).Set(count)
+	}
+}
+
+// record the converge state of the instance
+func observeConvergeState(state v1alpha1.InstanceManifestState) {
+	for _, s := range []v1alpha1.InstanceManifestState{
+		v1alpha1.InstanceManifestStateConverging,
+		v1alpha1.InstanceManifestStateConverged,
+		v1alpha1.InstanceManifestStateError,
+		v1alpha1.InstanceManifestStateRejected,
+	} {
+		value := 0.0
+		if s == state {
+			value = 1.0
+		}
+		convergeState.WithLabelValues(string(s)).Set(value)
+	}
+
+	if state == v1alpha1.InstanceManifestStateConverged {
+		now := time.Now()
+		lastSuccessMu.Lock()
+		lastSuccess = now
+		lastSuccessMu.Unlock()
+		convergeLastSuccess.Set(float64(now.Unix()))
+	}
+}
+
+func readPuppetRunSummary(path string) (*puppetRunSummary, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := new(puppetRunSummary)
+	if err := yaml.Unmarshal(data, summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// serve the metrics endpoint until wing stops
+func (w *Wing) serveMetrics() {
+	if w.flags.MetricsBindAddress == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler())
+	server := &http.Server{
+		Addr:    w.flags.MetricsBindAddress,
+		Handler: mux,
+	}
+
+	go func() {
+		<-w.stopCh
+		server.Close()
+	}()
+
+	go func() {
+		w.log.Infof("serving metrics on %s", w.flags.MetricsBindAddress)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			w.log.Warnf("error serving metrics: %s", err)
+		}
+	}()
+}
@@ -0,0 +1,68 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const lastRunSummary = `---
+version:
+  config: 1545130345
+  puppet: 5.5.6
+resources:
+  changed: 3
+  corrective_change: 0
+  failed: 1
+  failed_to_restart: 0
+  out_of_sync: 4
+  restarted: 0
+  scheduled: 0
+  skipped: 0
+  total: 312
+time:
+  exec: 12.3
+  total: 42.7
+  last_run: 1545130390
+changes:
+  total: 3
+events:
+  failure: 1
+  success: 3
+  total: 4
+`
+
+func TestReadPuppetRunSummary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wing-metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "last_run_summary.yaml")
+	if err := ioutil.WriteFile(path, []byte(lastRunSummary), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := readPuppetRunSummary(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for state, exp := range map[string]float64{
+		"changed":     3,
+		"failed":      1,
+		"out_of_sync": 4,
+		"total":       312,
+	} {
+		if act := summary.Resources[state]; act != exp {
+			t.Errorf("unexpected %s resources, exp=%v act=%v", state, exp, act)
+		}
+	}
+
+	if _, err := readPuppetRunSummary(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing summary")
+	}
+}
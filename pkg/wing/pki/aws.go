@@ -0,0 +1,145 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package pki
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	tagControl "github.com/jetstack/tarmak/pkg/tagging_control"
+)
+
+type describeInstancesAPI interface {
+	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+}
+
+// AWSVerifier verifies instances using their signed identity document and the
+// ssh host keys stored in their tags by tagging_control
+type AWSVerifier struct {
+	environment string
+	accountID   string
+	regions     map[string]bool
+
+	session *session.Session
+	ec2     map[string]describeInstancesAPI
+	ec2Lock sync.Mutex
+}
+
+var _ Verifier = &AWSVerifier{}
+
+// NewAWSVerifier only accepts instances of the environment, running in the
+// same account as the wing server and either in its region or in one of the
+// regions the environment's clusters are placed in
+func NewAWSVerifier(environment string, regions []string) (*AWSVerifier, error) {
+	awsSession, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := ec2metadata.New(awsSession).GetInstanceIdentityDocument()
+	if err != nil {
+		return nil, fmt.Errorf("error getting identity document of wing server: %s", err)
+	}
+
+	a := &AWSVerifier{
+		environment: environment,
+		accountID:   document.AccountID,
+		regions:     map[string]bool{document.Region: true},
+		session:     awsSession,
+		ec2:         make(map[string]describeInstancesAPI),
+	}
+	for _, region := range regions {
+		a.regions[region] = true
+	}
+
+	return a, nil
+}
+
+// ec2Client returns the EC2 client for region, instances are described in
+// the region they are running in
+func (a *AWSVerifier) ec2Client(region string) describeInstancesAPI {
+	a.ec2Lock.Lock()
+	defer a.ec2Lock.Unlock()
+
+	if _, ok := a.ec2[region]; !ok {
+		a.ec2[region] = ec2.New(a.session, aws.NewConfig().WithRegion(region))
+	}
+	return a.ec2[region]
+}
+
+func (a *AWSVerifier) Verify(request *BootstrapRequest) (string, error) {
+	identity := request.Identity
+
+	if err := tagControl.VerifyIdentityDocument(identity.InstanceDocumentRaw, identity.RSASignature); err != nil {
+		return "", err
+	}
+
+	document := new(ec2metadata.EC2InstanceIdentityDocument)
+	if err := json.Unmarshal(identity.InstanceDocumentRaw, document); err != nil {
+		return "", fmt.Errorf("failed to unmarshal identity document: %s", err)
+	}
+
+	if document.AccountID != a.accountID {
+		return "", fmt.Errorf("instance %s is not running in account %s", document.InstanceID, a.accountID)
+	}
+
+	if !a.regions[document.Region] {
+		return "", fmt.Errorf("instance %s is running in region %s, which is not used by environment %s", document.InstanceID, document.Region, a.environment)
+	}
+
+	out, err := a.ec2Client(document.Region).DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String(document.InstanceID)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe instance %s: %s", document.InstanceID, err)
+	}
+
+	var instance *ec2.Instance
+	for _, reservation := range out.Reservations {
+		for _, i := range reservation.Instances {
+			if i.InstanceId != nil && *i.InstanceId == document.InstanceID {
+				instance = i
+			}
+		}
+	}
+	if instance == nil {
+		return "", fmt.Errorf("instance %s not found", document.InstanceID)
+	}
+
+	if state := aws.StringValue(instance.State.Name); state != ec2.InstanceStateNameRunning && state != ec2.InstanceStateNamePending {
+		return "", fmt.Errorf("instance %s is in state %s", document.InstanceID, state)
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range instance.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	if tags["Environment"] != a.environment {
+		return "", fmt.Errorf("instance %s is not part of environment %s", document.InstanceID, a.environment)
+	}
+
+	// the certificate request needs to be signed by all ssh host keys
+	// tagging_control has stored on the instance
+	publicKeys, err := tagControl.PublicKeysFromTags(tags)
+	if err != nil {
+		return "", err
+	}
+	if len(publicKeys) == 0 {
+		return "", fmt.Errorf("instance %s has no public key tags", document.InstanceID)
+	}
+
+	for name, publicKey := range publicKeys {
+		err := tagControl.VerifyKeySignature(publicKey, identity.KeySignatures[name], request.CertificateRequest)
+		if err != nil {
+			return "", fmt.Errorf("could not verify public key %s of instance %s: %s", name, document.InstanceID, err)
+		}
+	}
+
+	return document.InstanceID, nil
+}
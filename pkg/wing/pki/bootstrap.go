@@ -0,0 +1,121 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package pki
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	tagControl "github.com/jetstack/tarmak/pkg/tagging_control"
+)
+
+const (
+	// Path of the bootstrap endpoint on the wing server
+	BootstrapPath = "/bootstrap/certificate"
+
+	// Maximum size of a bootstrap request
+	maxBootstrapRequestSize = 1 << 20
+)
+
+// BootstrapRequest is sent by an instance to retrieve its client certificate
+type BootstrapRequest struct {
+	// instance identity, the ssh host keys sign the certificate request
+	Identity *tagControl.TagInstanceRequest `json:"identity"`
+
+	// PEM encoded certificate request
+	CertificateRequest []byte `json:"certificateRequest"`
+}
+
+// BootstrapResponse contains the issued client certificate
+type BootstrapResponse struct {
+	// PEM encoded client certificate
+	Certificate []byte `json:"certificate"`
+
+	// PEM encoded CA certificate
+	CA []byte `json:"ca"`
+}
+
+// Verifier verifies the identity of an instance and returns its instance ID
+type Verifier interface {
+	Verify(request *BootstrapRequest) (instanceID string, err error)
+}
+
+// Bootstrap issues client certificates to verified instances
+type Bootstrap struct {
+	log      *logrus.Entry
+	ca       *CA
+	verifier Verifier
+}
+
+var _ http.Handler = &Bootstrap{}
+
+func NewBootstrap(log *logrus.Entry, ca *CA, verifier Verifier) *Bootstrap {
+	return &Bootstrap{
+		log:      log,
+		ca:       ca,
+		verifier: verifier,
+	}
+}
+
+func (b *Bootstrap) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBootstrapRequestSize))
+	if err != nil {
+		http.Error(w, "error reading request", http.StatusBadRequest)
+		return
+	}
+
+	request := new(BootstrapRequest)
+	if err := json.Unmarshal(data, request); err != nil {
+		http.Error(w, "error parsing request", http.StatusBadRequest)
+		return
+	}
+
+	response, err := b.issue(request)
+	if err != nil {
+		// until we verify the instance, we won't reply any meaningful error message
+		b.log.Warnf("rejected bootstrap request from %s: %s", r.RemoteAddr, err)
+		http.Error(w, "rejected", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		b.log.Warnf("error writing bootstrap response: %s", err)
+	}
+}
+
+func (b *Bootstrap) issue(request *BootstrapRequest) (*BootstrapResponse, error) {
+	if request.Identity == nil || len(request.CertificateRequest) == 0 {
+		return nil, fmt.Errorf("incomplete request")
+	}
+
+	instanceID, err := b.verifier.Verify(request)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := b.ca.SignClientCertificate(
+		request.CertificateRequest,
+		instanceID,
+		InstancesGroup,
+		InstanceCertificateValidity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	b.log.Infof("issued client certificate for instance %s", instanceID)
+
+	return &BootstrapResponse{
+		Certificate: cert,
+		CA:          b.ca.CertificatePEM(),
+	}, nil
+}
@@ -0,0 +1,200 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+)
+
+const (
+	// Organization of all instance client certificates
+	InstancesGroup = "wing:instances"
+
+	// Validity of issued instance client certificates
+	InstanceCertificateValidity = time.Hour * 24 * 365
+
+	// Instance client certificates get renewed within this time of expiry
+	InstanceCertificateRenewBefore = time.Hour * 24 * 30
+)
+
+// CA signs instance client certificates
+type CA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// LoadCA reads the PEM encoded CA certificate and private key from disk
+func LoadCA(certFile, keyFile string) (*CA, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA certificate: %s", err)
+	}
+
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA private key: %s", err)
+	}
+
+	return NewCA(certPEM, keyPEM)
+}
+
+// NewCA parses the PEM encoded CA certificate and private key
+func NewCA(certPEM, keyPEM []byte) (*CA, error) {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CA certificate: %s", err)
+	}
+
+	if !cert.IsCA {
+		return nil, fmt.Errorf("certificate '%s' is not a CA", cert.Subject.CommonName)
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CA private key: %s", err)
+	}
+
+	return &CA{
+		cert: cert,
+		key:  key,
+	}, nil
+}
+
+// Certificate returns the CA certificate
+func (c *CA) Certificate() *x509.Certificate {
+	return c.cert
+}
+
+// CertificatePEM returns the PEM encoded CA certificate
+func (c *CA) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+}
+
+// Pool returns a cert pool only containing the CA certificate
+func (c *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.cert)
+	return pool
+}
+
+// SignClientCertificate issues a client certificate for the public key of the
+// certificate request. The subject is overridden with the given common name
+// and organization.
+func (c *CA) SignClientCertificate(csrPEM []byte, commonName, organization string, validity time.Duration) ([]byte, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("error decoding PEM certificate request")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificate request: %s", err)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("error verifying certificate request signature: %s", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(c.cert.NotAfter) {
+		notAfter = c.cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{organization},
+		},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, c.cert, csr.PublicKey, c.key)
+	if err != nil {
+		return nil, fmt.Errorf("error signing certificate: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+// NewCertificateRequest generates a private key and a certificate request for
+// the given common name, both are returned PEM encoded
+func NewCertificateRequest(commonName string) (keyPEM []byte, csrPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating private key: %s", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: commonName,
+		},
+	}, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating certificate request: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+		nil
+}
+
+// ParseCertificate parses the first certificate of PEM encoded data
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("error decoding PEM certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("error decoding PEM private key")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key")
+		}
+		return signer, nil
+	}
+
+	return nil, fmt.Errorf("unsupported private key type '%s'", block.Type)
+}
@@ -0,0 +1,206 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package pki
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	tagControl "github.com/jetstack/tarmak/pkg/tagging_control"
+)
+
+func newTestCA(t *testing.T, notAfter time.Time) *CA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Wing test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ca, err := NewCA(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ca
+}
+
+func TestCA_SignClientCertificate(t *testing.T) {
+	caNotAfter := time.Now().Add(time.Hour * 24 * 7).Truncate(time.Second)
+	ca := newTestCA(t, caNotAfter)
+
+	_, csrPEM, err := NewCertificateRequest("i-requested")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM, err := ca.SignClientCertificate(csrPEM, "i-0daab936f4046f7a6", InstancesGroup, InstanceCertificateValidity)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// subject of the request is overridden
+	if exp, act := "i-0daab936f4046f7a6", cert.Subject.CommonName; exp != act {
+		t.Errorf("unexpected common name, exp=%s act=%s", exp, act)
+	}
+	if exp, act := []string{InstancesGroup}, cert.Subject.Organization; len(act) != 1 || exp[0] != act[0] {
+		t.Errorf("unexpected organization, exp=%v act=%v", exp, act)
+	}
+
+	// validity is capped by the CA
+	if !cert.NotAfter.Equal(caNotAfter) {
+		t.Errorf("unexpected expiry, exp=%s act=%s", caNotAfter, cert.NotAfter)
+	}
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		t.Errorf("unexpected error verifying certificate: %s", err)
+	}
+
+	if _, err := ca.SignClientCertificate([]byte("invalid"), "i-0daab936f4046f7a6", InstancesGroup, InstanceCertificateValidity); err == nil {
+		t.Error("expected an error for an invalid certificate request")
+	}
+}
+
+type fakeVerifier struct {
+	instanceID string
+	err        error
+}
+
+func (f *fakeVerifier) Verify(*BootstrapRequest) (string, error) {
+	return f.instanceID, f.err
+}
+
+func TestBootstrap_ServeHTTP(t *testing.T) {
+	ca := newTestCA(t, time.Now().Add(InstanceCertificateValidity*2))
+	log := logrus.NewEntry(logrus.New())
+
+	_, csrPEM, err := NewCertificateRequest("i-0daab936f4046f7a6")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := json.Marshal(&BootstrapRequest{
+		Identity:           &tagControl.TagInstanceRequest{},
+		CertificateRequest: csrPEM,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name     string
+		method   string
+		body     []byte
+		verifier *fakeVerifier
+		status   int
+	}{
+		{
+			name:     "verified",
+			method:   http.MethodPost,
+			body:     body,
+			verifier: &fakeVerifier{instanceID: "i-0daab936f4046f7a6"},
+			status:   http.StatusOK,
+		},
+		{
+			name:     "rejected",
+			method:   http.MethodPost,
+			body:     body,
+			verifier: &fakeVerifier{err: fmt.Errorf("signature mismatch")},
+			status:   http.StatusForbidden,
+		},
+		{
+			name:     "incomplete",
+			method:   http.MethodPost,
+			body:     []byte(`{}`),
+			verifier: &fakeVerifier{instanceID: "i-0daab936f4046f7a6"},
+			status:   http.StatusForbidden,
+		},
+		{
+			name:     "invalid",
+			method:   http.MethodPost,
+			body:     []byte(`{`),
+			verifier: &fakeVerifier{instanceID: "i-0daab936f4046f7a6"},
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:     "get",
+			method:   http.MethodGet,
+			verifier: &fakeVerifier{instanceID: "i-0daab936f4046f7a6"},
+			status:   http.StatusMethodNotAllowed,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(c.method, BootstrapPath, bytes.NewReader(c.body))
+
+			NewBootstrap(log, ca, c.verifier).ServeHTTP(rec, req)
+
+			if rec.Code != c.status {
+				t.Fatalf("unexpected status, exp=%d act=%d", c.status, rec.Code)
+			}
+
+			if c.status != http.StatusOK {
+				return
+			}
+
+			response := new(BootstrapResponse)
+			if err := json.NewDecoder(rec.Body).Decode(response); err != nil {
+				t.Fatal(err)
+			}
+
+			cert, err := ParseCertificate(response.Certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exp, act := c.verifier.instanceID, cert.Subject.CommonName; exp != act {
+				t.Errorf("unexpected common name, exp=%s act=%s", exp, act)
+			}
+			if !bytes.Equal(response.CA, ca.CertificatePEM()) {
+				t.Error("unexpected CA in response")
+			}
+		})
+	}
+}
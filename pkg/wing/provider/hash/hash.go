@@ -7,16 +7,20 @@ import (
 	"io"
 	"net/url"
 	"path"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+
+	wings3 "github.com/jetstack/tarmak/pkg/wing/provider/s3"
+	"github.com/jetstack/tarmak/pkg/wing/signature"
 )
 
 const (
 	S3HashObject   = "latest-puppet-hash"
 	S3HashDir      = "puppet-manifests"
 	S3LegacyObject = "puppet.tar.gz"
+	S3HashSuffix   = "-puppet.tar.gz"
 )
 
 type Hash struct{}
@@ -36,9 +40,10 @@ func (h *Hash) GetManifest(hashPath string) (io.ReadCloser, error) {
 	}
 	key = path.Join(key, S3HashObject)
 
-	cfg := aws.NewConfig()
-	awsSession := session.New(cfg)
-	s3Service := s3.New(awsSession)
+	s3Service, err := wings3.ClientForBucket(bucket)
+	if err != nil {
+		return nil, err
+	}
 
 	obj, err := s3Service.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(bucket),
@@ -51,7 +56,7 @@ func (h *Hash) GetManifest(hashPath string) (io.ReadCloser, error) {
 	b := new(bytes.Buffer)
 	b.ReadFrom(obj.Body)
 
-	key = fmt.Sprintf("%s/%s-puppet.tar.gz", path.Dir(key), b.String())
+	key = fmt.Sprintf("%s/%s%s", path.Dir(key), b.String(), S3HashSuffix)
 	obj, err = s3Service.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
@@ -66,3 +71,30 @@ func (h *Hash) GetManifest(hashPath string) (io.ReadCloser, error) {
 func (h *Hash) Name() string {
 	return "hash"
 }
+
+// SignatureURL returns the location of the detached signature of the manifest
+// with the given md5 hash. Signatures are stored next to the manifest objects
+// in the hash directory.
+func SignatureURL(manifestPath, md5Hash string) (string, error) {
+	manifestURL, err := url.Parse(manifestPath)
+	if err != nil {
+		return "", err
+	}
+
+	base := path.Base(manifestURL.Path)
+	switch {
+	// pinned manifest object
+	case strings.HasSuffix(base, S3HashSuffix):
+		manifestURL.Path += signature.Suffix
+	// legacy object, signatures are in the hash object directory
+	case base == S3LegacyObject && manifestURL.Scheme == "s3":
+		manifestURL.Path = path.Join(path.Dir(manifestURL.Path), S3HashDir, md5Hash+S3HashSuffix+signature.Suffix)
+	case strings.HasSuffix(base, ".tar.gz"):
+		manifestURL.Path += signature.Suffix
+	// hash object directory
+	default:
+		manifestURL.Path = path.Join(manifestURL.Path, md5Hash+S3HashSuffix+signature.Suffix)
+	}
+
+	return manifestURL.String(), nil
+}
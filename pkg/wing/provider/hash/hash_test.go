@@ -0,0 +1,26 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package hash
+
+import (
+	"testing"
+)
+
+func TestSignatureURL(t *testing.T) {
+	md5Hash := "0123456789abcdef"
+	for manifestURL, exp := range map[string]string{
+		"s3://bucket/env-cluster/puppet-manifests":                      "s3://bucket/env-cluster/puppet-manifests/0123456789abcdef-puppet.tar.gz.sig",
+		"s3://bucket/env-cluster/puppet.tar.gz":                         "s3://bucket/env-cluster/puppet-manifests/0123456789abcdef-puppet.tar.gz.sig",
+		"s3://bucket/env-cluster/puppet-manifests/fedcba-puppet.tar.gz": "s3://bucket/env-cluster/puppet-manifests/fedcba-puppet.tar.gz.sig",
+		"/var/lib/wing/puppet.tar.gz":                                   "/var/lib/wing/puppet.tar.gz.sig",
+		"/var/lib/wing/puppet-manifests":                                "/var/lib/wing/puppet-manifests/0123456789abcdef-puppet.tar.gz.sig",
+	} {
+		act, err := SignatureURL(manifestURL, md5Hash)
+		if err != nil {
+			t.Errorf("unexpected error for '%s': %s", manifestURL, err)
+			continue
+		}
+		if exp != act {
+			t.Errorf("unexpected signature url for '%s': exp=%s act=%s", manifestURL, exp, act)
+		}
+	}
+}
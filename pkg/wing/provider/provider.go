@@ -3,6 +3,7 @@ package provider
 
 import (
 	"io"
+	"io/ioutil"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
@@ -18,13 +19,46 @@ type Provider interface {
 }
 
 func GetManifest(log *logrus.Entry, manifestURL string) (io.ReadCloser, error) {
-	var result *multierror.Error
-
-	for _, p := range []Provider{
+	return getManifest(log, manifestURL, []Provider{
 		new(hash.Hash),
 		new(s3.S3),
 		new(file.File),
-	} {
+	})
+}
+
+// GetPinnedManifest retrieves the exact manifest object at the given path,
+// without resolving the latest hash pointer
+func GetPinnedManifest(log *logrus.Entry, manifestPath string) (io.ReadCloser, error) {
+	return getManifest(log, manifestPath, []Provider{
+		new(s3.S3),
+		new(file.File),
+	})
+}
+
+// GetSignature retrieves the detached signature of the manifest with the
+// given md5 hash
+func GetSignature(log *logrus.Entry, manifestURL, md5Hash string) ([]byte, error) {
+	signatureURL, err := hash.SignatureURL(manifestURL, md5Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := getManifest(log, signatureURL, []Provider{
+		new(s3.S3),
+		new(file.File),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return ioutil.ReadAll(rc)
+}
+
+func getManifest(log *logrus.Entry, manifestURL string, providers []Provider) (io.ReadCloser, error) {
+	var result *multierror.Error
+
+	for _, p := range providers {
 		rc, err := p.GetManifest(manifestURL)
 		if err != nil {
 			result = multierror.Append(result, err)
@@ -11,6 +11,37 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
+// API is the part of the S3 API used to retrieve manifests
+type API interface {
+	GetBucketLocation(*s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error)
+	GetObject(*s3.GetObjectInput) (*s3.GetObjectOutput, error)
+}
+
+// newClient creates an S3 client for a region, an empty region uses the
+// default region of the instance
+var newClient = func(region string) API {
+	cfg := aws.NewConfig()
+	if region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	return s3.New(session.New(cfg))
+}
+
+// ClientForBucket returns an S3 client for the region the bucket is located
+// in. Manifests of clusters placed in another region than their hub are
+// stored in the hub's region, and S3's redirects to other regions are not
+// followed.
+func ClientForBucket(bucket string) (API, error) {
+	location, err := newClient("").GetBucketLocation(&s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error finding region of bucket '%s': %s", bucket, err)
+	}
+
+	return newClient(s3.NormalizeBucketLocation(aws.StringValue(location.LocationConstraint))), nil
+}
+
 type S3 struct{}
 
 func (s *S3) GetManifest(manifestString string) (io.ReadCloser, error) {
@@ -22,9 +53,10 @@ func (s *S3) GetManifest(manifestString string) (io.ReadCloser, error) {
 	bucket := manifestURL.Host
 	key := manifestURL.Path
 
-	cfg := aws.NewConfig()
-	awsSession := session.New(cfg)
-	s3Service := s3.New(awsSession)
+	s3Service, err := ClientForBucket(bucket)
+	if err != nil {
+		return nil, err
+	}
 
 	result, err := s3Service.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(bucket),
@@ -0,0 +1,91 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package s3
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// fakeS3 serves objects only for buckets located in its own region, like S3
+// without following redirects
+type fakeS3 struct {
+	region  string
+	buckets map[string]string // bucket name to region
+	objects map[string]string // bucket/key to content
+}
+
+func (f *fakeS3) GetBucketLocation(input *s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error) {
+	region, ok := f.buckets[aws.StringValue(input.Bucket)]
+	if !ok {
+		return nil, fmt.Errorf("NoSuchBucket")
+	}
+	// buckets in us-east-1 have no location constraint
+	if region == "us-east-1" {
+		return &s3.GetBucketLocationOutput{}, nil
+	}
+	return &s3.GetBucketLocationOutput{LocationConstraint: aws.String(region)}, nil
+}
+
+func (f *fakeS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	bucket := aws.StringValue(input.Bucket)
+	if f.buckets[bucket] != f.region {
+		return nil, fmt.Errorf("PermanentRedirect: bucket '%s' is not in region %s", bucket, f.region)
+	}
+	content, ok := f.objects[bucket+aws.StringValue(input.Key)]
+	if !ok {
+		return nil, fmt.Errorf("NoSuchKey")
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewBufferString(content))}, nil
+}
+
+func TestS3_GetManifest_CrossRegion(t *testing.T) {
+	defer func(orig func(string) API) { newClient = orig }(newClient)
+
+	var regions []string
+	newClient = func(region string) API {
+		regions = append(regions, region)
+		// the instance's default region is the cluster's region
+		if region == "" {
+			region = "us-west-2"
+		}
+		return &fakeS3{
+			region: region,
+			buckets: map[string]string{
+				"hub-secrets":     "eu-west-1",
+				"virginia-bucket": "us-east-1",
+			},
+			objects: map[string]string{
+				"hub-secrets/env-cluster/puppet.tar.gz":     "manifest",
+				"virginia-bucket/env-cluster/puppet.tar.gz": "manifest",
+			},
+		}
+	}
+
+	for bucket, region := range map[string]string{
+		"hub-secrets":     "eu-west-1",
+		"virginia-bucket": "us-east-1",
+	} {
+		regions = nil
+
+		rc, err := new(S3).GetManifest(fmt.Sprintf("s3://%s/env-cluster/puppet.tar.gz", bucket))
+		if err != nil {
+			t.Errorf("unexpected error for bucket '%s': %s", bucket, err)
+			continue
+		}
+		content, err := ioutil.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp, act := "manifest", string(content); exp != act {
+			t.Errorf("unexpected manifest for bucket '%s': exp=%s act=%s", bucket, exp, act)
+		}
+		if exp, act := region, regions[len(regions)-1]; exp != act {
+			t.Errorf("expected client for region %s, got %s", exp, act)
+		}
+	}
+}
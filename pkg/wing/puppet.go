@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -26,8 +27,9 @@ import (
 	"github.com/jetstack/tarmak/pkg/wing/provider"
 )
 
-// This make sure puppet is converged when neccessary
-func (w *Wing) runPuppet() (*v1alpha1.InstanceStatus, error) {
+// This make sure puppet is converged when neccessary, if spec contains a path
+// or a hash the manifest is pinned to that specific version
+func (w *Wing) runPuppet(spec *v1alpha1.InstanceSpecManifest) (*v1alpha1.InstanceStatus, error) {
 	// start converging mainfest
 	status := &v1alpha1.InstanceStatus{
 		Converge: &v1alpha1.InstanceStatusManifest{
@@ -35,12 +37,26 @@ func (w *Wing) runPuppet() (*v1alpha1.InstanceStatus, error) {
 		},
 	}
 
+	observeConvergeState(status.Converge.State)
+
 	err := w.reportStatus(status)
 	if err != nil {
 		w.log.Warn("reporting status failed: ", err)
 	}
 
-	originalReader, err := w.getManifests(w.flags.ManifestURL)
+	if spec != nil && spec.Hash != "" && !strings.HasPrefix(spec.Hash, "sha256:") {
+		return status, fmt.Errorf("unsupported manifest hash '%s', only sha256 is supported", spec.Hash)
+	}
+
+	var originalReader io.ReadCloser
+	manifestURL := w.flags.ManifestURL
+	if spec != nil && spec.Path != "" {
+		w.log.Infof("using pinned manifest '%s'", spec.Path)
+		manifestURL = spec.Path
+		originalReader, err = provider.GetPinnedManifest(w.log, spec.Path)
+	} else {
+		originalReader, err = w.getManifests(manifestURL)
+	}
 	if err != nil {
 		return status, err
 	}
@@ -54,6 +70,12 @@ func (w *Wing) runPuppet() (*v1alpha1.InstanceStatus, error) {
 	if err != nil {
 		return status, err
 	}
+
+	// verify the manifest before anything is unpacked
+	if err := w.verifyManifest(manifestURL, buf); err != nil {
+		return status, err
+	}
+
 	// create reader from buffer
 	reader := bytes.NewReader(buf)
 
@@ -63,6 +85,12 @@ func (w *Wing) runPuppet() (*v1alpha1.InstanceStatus, error) {
 		return status, err
 	}
 	hashString := fmt.Sprintf("sha256:%x", hash.Sum(nil))
+	status.Converge.Hash = hashString
+
+	// refuse to apply manifests not matching the requested hash
+	if spec != nil && spec.Hash != "" && spec.Hash != hashString {
+		return status, fmt.Errorf("manifest hash '%s' does not match the requested hash '%s', refusing to apply", hashString, spec.Hash)
+	}
 
 	// roll back reader
 	reader.Seek(0, 0)
@@ -88,19 +116,34 @@ func (w *Wing) runPuppet() (*v1alpha1.InstanceStatus, error) {
 	var puppetMessages []string
 	var puppetRetCodes []int
 
+	attempt := 0
 	puppetApplyCmd := func() error {
+		attempt++
+		start := time.Now()
 		output, retCode, err := w.puppetApply(dir)
 
 		if err == nil && retCode != 0 {
 			err = fmt.Errorf("puppet apply has not converged yet (return code %d)", retCode)
 		}
 
+		w.observePuppetRun(time.Since(start), retCode, attempt, err)
+
 		if err != nil {
 			output = fmt.Sprintf("puppet apply error: %s\n%s", err, output)
 		}
 
-		puppetMessages = append(puppetMessages, output)
+		report, reportErr := w.puppetReport(start)
+		if reportErr != nil {
+			w.log.Debugf("unable to read puppet's last run report: %s", reportErr)
+		}
+
+		// only keep the output of the most recent runs
+		puppetMessages = append(puppetMessages, truncateHead(output, maxMessageBytes))
 		puppetRetCodes = append(puppetRetCodes, retCode)
+		if len(puppetMessages) > maxStatusMessages {
+			puppetMessages = puppetMessages[len(puppetMessages)-maxStatusMessages:]
+			puppetRetCodes = puppetRetCodes[len(puppetRetCodes)-maxStatusMessages:]
+		}
 
 		// start converging mainfest
 		status = &v1alpha1.InstanceStatus{
@@ -109,6 +152,7 @@ func (w *Wing) runPuppet() (*v1alpha1.InstanceStatus, error) {
 				Messages:  puppetMessages,
 				ExitCodes: puppetRetCodes,
 				Hash:      hashString,
+				Report:    report,
 			},
 		}
 		statusErr := w.reportStatus(status)
@@ -123,30 +167,9 @@ func (w *Wing) runPuppet() (*v1alpha1.InstanceStatus, error) {
 	expBackoff.InitialInterval = time.Second * 30
 	expBackoff.MaxElapsedTime = time.Minute * 30
 
-	// add context to backoff
-	ctx, cancelRetries := context.WithCancel(context.Background())
-	b := backoff.WithContext(expBackoff, ctx)
-
-	quitCh := make(chan struct{})
-	defer close(quitCh)
-
-	// cancel retries when supposed to stop
-
-	go func() {
-		for {
-			select {
-			case <-w.convergeStopCh:
-				cancelRetries()
-				return
-			case <-quitCh:
-				return
-			}
-		}
-	}()
-
-	err = backoff.Retry(puppetApplyCmd, b)
-	if err != nil {
-		w.log.Error("error applying puppet:", err)
+	// a manifest that never converged is an error, not a success
+	if err := w.retryConverge(puppetApplyCmd, expBackoff); err != nil {
+		return status, fmt.Errorf("error applying puppet: %s", err)
 	}
 
 	return status, nil
@@ -156,20 +179,94 @@ func (w *Wing) converge() {
 	w.convergeWG.Add(1)
 	defer w.convergeWG.Done()
 
-	// run puppet
-	status, err := w.runPuppet()
+	// run puppet, unless the requested manifest is unknown
+	var status *v1alpha1.InstanceStatus
+	spec, err := w.convergeSpec()
+	if err == nil {
+		status, err = w.runPuppet(spec)
+	} else {
+		status = &v1alpha1.InstanceStatus{
+			Converge: &v1alpha1.InstanceStatusManifest{},
+		}
+	}
 	if err != nil {
 		status.Converge.State = v1alpha1.InstanceManifestStateError
-		status.Converge.Messages = append(status.Converge.Messages, err.Error())
+		if isManifestRejected(err) {
+			status.Converge.State = v1alpha1.InstanceManifestStateRejected
+		}
+		status.Converge.Messages = append(status.Converge.Messages, truncateHead(err.Error(), maxMessageBytes))
 		w.log.Error(err)
 	} else {
 		status.Converge.State = v1alpha1.InstanceManifestStateConverged
 	}
+	observeConvergeState(status.Converge.State)
+	w.setLastConverge(time.Now())
 
 	// feedback puppet status to apiserver
 	if err := w.reportStatus(status); err != nil {
 		w.log.Warn("reporting status failed: ", err)
 	}
+
+	// software versions might have changed
+	w.triggerFacts()
+}
+
+// get the requested manifest from the instance's spec, nil means the latest
+// manifest is applied. Failing to get the instance is retried, as applying
+// the latest manifest instead could revert a pinned manifest.
+func (w *Wing) convergeSpec() (*v1alpha1.InstanceSpecManifest, error) {
+	var instance *v1alpha1.Instance
+	getInstance := func() error {
+		var err error
+		instance, err = w.clientset.WingV1alpha1().Instances(w.flags.ClusterName).Get(
+			w.flags.InstanceName,
+			metav1.GetOptions{},
+		)
+		// a not yet registered instance has no manifest requested
+		if kerr, ok := err.(*apierrors.StatusError); ok && kerr.ErrStatus.Reason == metav1.StatusReasonNotFound {
+			instance = nil
+			return nil
+		}
+		if err != nil {
+			w.log.Warn("unable to get instance spec, retrying: ", err)
+		}
+		return err
+	}
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = time.Second * 5
+	expBackoff.MaxElapsedTime = time.Minute * 5
+
+	if err := w.retryConverge(getInstance, expBackoff); err != nil {
+		return nil, fmt.Errorf("error getting instance spec: %s", err)
+	}
+
+	if instance == nil || instance.Spec == nil || instance.Spec.Converge == nil {
+		return nil, nil
+	}
+
+	return instance.Spec.Converge.DeepCopy(), nil
+}
+
+// retryConverge retries op until it succeeds, b gives up or the converge is
+// stopped
+func (w *Wing) retryConverge(op backoff.Operation, b backoff.BackOff) error {
+	ctx, cancelRetries := context.WithCancel(context.Background())
+	defer cancelRetries()
+
+	quitCh := make(chan struct{})
+	defer close(quitCh)
+
+	// cancel retries when supposed to stop
+	go func() {
+		select {
+		case <-w.convergeStopCh:
+			cancelRetries()
+		case <-quitCh:
+		}
+	}()
+
+	return backoff.Retry(op, backoff.WithContext(b, ctx))
 }
 
 func (w *Wing) puppetCommand(dir string) Command {
@@ -292,6 +389,11 @@ func (w *Wing) puppetApply(dir string) (output string, retCode int, err error) {
 
 // report status to the API server
 func (w *Wing) reportStatus(status *v1alpha1.InstanceStatus) error {
+	status = status.DeepCopy()
+	if status.Facts == nil {
+		status.Facts = w.currentFacts()
+	}
+
 	instanceAPI := w.clientset.WingV1alpha1().Instances(w.flags.ClusterName)
 	instance, err := instanceAPI.Get(
 		w.flags.InstanceName,
@@ -303,7 +405,8 @@ func (w *Wing) reportStatus(status *v1alpha1.InstanceStatus) error {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: w.flags.InstanceName,
 				},
-				Status: status.DeepCopy(),
+				InstancePool: w.flags.InstancePool,
+				Status:       status,
 			}
 			_, err := instanceAPI.Create(instance)
 			if err != nil {
@@ -314,7 +417,13 @@ func (w *Wing) reportStatus(status *v1alpha1.InstanceStatus) error {
 		return fmt.Errorf("error get existing instance: %s", err)
 	}
 
-	instance.Status = status.DeepCopy()
+	if instance.InstancePool == "" {
+		instance.InstancePool = w.flags.InstancePool
+	}
+	if status.Facts == nil && instance.Status != nil {
+		status.Facts = instance.Status.Facts
+	}
+	instance.Status = status
 	_, err = instanceAPI.Update(instance)
 	if err != nil {
 		return fmt.Errorf("error updating existing instance: %s", err)
@@ -0,0 +1,67 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/jetstack/tarmak/pkg/wing/schedule"
+)
+
+// how often the agent checks whether a reconverge is due
+const reconvergeCheckInterval = time.Minute
+
+// periodically reconverge to revert manual changes on the instance, only
+// within the maintenance windows if any are configured
+func (w *Wing) reconvergeLoop(windows []*schedule.Window) {
+	w.log.Infof("reconverging every %s (jitter %s) in maintenance windows %v", w.flags.ReconvergeInterval, w.flags.ReconvergeJitter, windows)
+
+	ticker := time.NewTicker(reconvergeCheckInterval)
+	defer ticker.Stop()
+
+	jitter := w.reconvergeJitter()
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if !reconvergeDue(time.Now(), w.lastConverge(), w.flags.ReconvergeInterval+jitter, windows) {
+			continue
+		}
+
+		w.log.Info("reconverging to revert drift")
+		w.convergeWG.Wait()
+		w.converge()
+		jitter = w.reconvergeJitter()
+	}
+}
+
+func (w *Wing) reconvergeJitter() time.Duration {
+	if w.flags.ReconvergeJitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(w.flags.ReconvergeJitter)))
+}
+
+// a reconverge is due if the last converge is older than interval and now is
+// within a maintenance window
+func reconvergeDue(now, lastConverge time.Time, interval time.Duration, windows []*schedule.Window) bool {
+	if now.Sub(lastConverge) < interval {
+		return false
+	}
+	return schedule.Allowed(windows, now)
+}
+
+func (w *Wing) setLastConverge(t time.Time) {
+	w.lastConvergeMu.Lock()
+	defer w.lastConvergeMu.Unlock()
+	w.lastConvergeTime = t
+}
+
+func (w *Wing) lastConverge() time.Time {
+	w.lastConvergeMu.Lock()
+	defer w.lastConvergeMu.Unlock()
+	return w.lastConvergeTime
+}
@@ -0,0 +1,37 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jetstack/tarmak/pkg/wing/schedule"
+)
+
+func TestReconvergeDue(t *testing.T) {
+	windows, err := schedule.ParseWindows("Mon-Fri 01:00-05:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tuesday 03:00 UTC
+	now := time.Date(2018, 12, 18, 3, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		name         string
+		now          time.Time
+		lastConverge time.Time
+		windows      []*schedule.Window
+		exp          bool
+	}{
+		{"interval not passed", now, now.Add(-time.Hour), nil, false},
+		{"interval passed", now, now.Add(-7 * time.Hour), nil, true},
+		{"never converged", now, time.Time{}, nil, true},
+		{"in maintenance window", now, now.Add(-7 * time.Hour), windows, true},
+		{"outside of maintenance window", now.Add(8 * time.Hour), now.Add(-7 * time.Hour), windows, false},
+	} {
+		if act := reconvergeDue(test.now, test.lastConverge, 6*time.Hour, test.windows); test.exp != act {
+			t.Errorf("%s: exp=%t act=%t", test.name, test.exp, act)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package command
+
+import (
+	"context"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/names"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing"
+	"github.com/jetstack/tarmak/pkg/wing/auth"
+)
+
+// NewStrategy creates and returns a commandStrategy instance
+func NewStrategy(typer runtime.ObjectTyper) commandStrategy {
+	return commandStrategy{typer, names.SimpleNameGenerator}
+}
+
+// GetAttrs returns labels.Set, fields.Set, the presence of Initializers if any
+// and error in case the given runtime.Object is not a Command
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+	command, ok := obj.(*wing.Command)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("given object is not a Command")
+	}
+	return labels.Set(command.ObjectMeta.Labels), SelectableFields(command), command.Initializers != nil, nil
+}
+
+// MatchCommand is the filter used by the generic etcd backend to watch events
+// from etcd to clients of the apiserver only interested in specific labels/fields.
+func MatchCommand(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+	return storage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// SelectableFields returns a field set that represents the object.
+func SelectableFields(obj *wing.Command) fields.Set {
+	return generic.ObjectMetaFieldsSet(&obj.ObjectMeta, true)
+}
+
+type commandStrategy struct {
+	runtime.ObjectTyper
+	names.NameGenerator
+}
+
+func (commandStrategy) NamespaceScoped() bool {
+	return true
+}
+
+// results are only reported by instances
+func (commandStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	obj.(*wing.Command).Status = nil
+}
+
+func (commandStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+}
+
+func (commandStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	command := obj.(*wing.Command)
+	specPath := field.NewPath("spec")
+
+	allErrs := field.ErrorList{}
+	if command.Spec == nil {
+		return append(allErrs, field.Required(specPath, "a command needs a spec"))
+	}
+	if len(command.Spec.Command) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("command"), "a command needs to be specified"))
+	}
+	if command.Spec.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("timeout"), command.Spec.Timeout.Duration.String(), "timeout can not be negative"))
+	}
+	if command.Spec.Selector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(command.Spec.Selector, specPath.Child("selector"))...)
+	}
+	return allErrs
+}
+
+func (commandStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (commandStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (commandStrategy) Canonicalize(obj runtime.Object) {
+}
+
+func (commandStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	newCommand := obj.(*wing.Command)
+	oldCommand := old.(*wing.Command)
+
+	allErrs := field.ErrorList{}
+	if !apiequality.Semantic.DeepEqual(newCommand.Spec, oldCommand.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "the spec of a command is immutable"))
+	}
+
+	u, ok := genericapirequest.UserFrom(ctx)
+	if !ok || !auth.IsInstance(u) {
+		return allErrs
+	}
+
+	// instances can only report their own result
+	oldResults := otherResults(oldCommand, u.GetName())
+	newResults := otherResults(newCommand, u.GetName())
+	if !apiequality.Semantic.DeepEqual(newResults, oldResults) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("status", "results"), "instances can only report their own result"))
+	}
+	return allErrs
+}
+
+// results of all instances except the named one
+func otherResults(command *wing.Command, instanceName string) map[string]wing.CommandResult {
+	results := make(map[string]wing.CommandResult)
+	if command.Status == nil {
+		return results
+	}
+	for _, result := range command.Status.Results {
+		if result.InstanceName != instanceName {
+			results[result.InstanceName] = result
+		}
+	}
+	return results
+}
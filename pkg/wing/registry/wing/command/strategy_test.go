@@ -0,0 +1,86 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package command
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing"
+	"github.com/jetstack/tarmak/pkg/wing/pki"
+)
+
+func TestCommandStrategy_ValidateUpdate(t *testing.T) {
+	strategy := NewStrategy(nil)
+	instance := genericapirequest.WithUser(context.Background(), &user.DefaultInfo{Name: "i-1", Groups: []string{pki.InstancesGroup}})
+
+	command := func(results ...wing.CommandResult) *wing.Command {
+		return &wing.Command{
+			Spec:   &wing.CommandSpec{Command: []string{"uptime"}},
+			Status: &wing.CommandStatus{Results: results},
+		}
+	}
+
+	for _, c := range []struct {
+		name  string
+		old   *wing.Command
+		new   *wing.Command
+		valid bool
+	}{
+		{
+			name:  "instance reports own result",
+			old:   command(wing.CommandResult{InstanceName: "i-2", State: "running"}),
+			new:   command(wing.CommandResult{InstanceName: "i-2", State: "running"}, wing.CommandResult{InstanceName: "i-1", State: "running"}),
+			valid: true,
+		},
+		{
+			name:  "instance updates own result",
+			old:   command(wing.CommandResult{InstanceName: "i-1", State: "running"}),
+			new:   command(wing.CommandResult{InstanceName: "i-1", State: "succeeded"}),
+			valid: true,
+		},
+		{
+			name:  "instance changes other result",
+			old:   command(wing.CommandResult{InstanceName: "i-2", State: "running"}),
+			new:   command(wing.CommandResult{InstanceName: "i-2", State: "succeeded"}),
+			valid: false,
+		},
+		{
+			name:  "instance removes other result",
+			old:   command(wing.CommandResult{InstanceName: "i-2", State: "running"}),
+			new:   command(wing.CommandResult{InstanceName: "i-1", State: "running"}),
+			valid: false,
+		},
+		{
+			name: "instance changes spec",
+			old:  command(),
+			new: &wing.Command{
+				Spec: &wing.CommandSpec{Command: []string{"reboot"}},
+			},
+			valid: false,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			errs := strategy.ValidateUpdate(instance, c.new, c.old)
+			if valid := len(errs) == 0; valid != c.valid {
+				t.Errorf("unexpected validation result, exp=%t act=%t: %v", c.valid, valid, errs)
+			}
+		})
+	}
+}
+
+func TestCommandStrategy_Validate(t *testing.T) {
+	strategy := NewStrategy(nil)
+
+	if errs := strategy.Validate(context.Background(), &wing.Command{}); len(errs) == 0 {
+		t.Error("expected error for command without spec")
+	}
+	if errs := strategy.Validate(context.Background(), &wing.Command{Spec: &wing.CommandSpec{}}); len(errs) == 0 {
+		t.Error("expected error for command without command")
+	}
+	if errs := strategy.Validate(context.Background(), &wing.Command{Spec: &wing.CommandSpec{Command: []string{"uptime"}}}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
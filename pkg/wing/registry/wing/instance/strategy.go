@@ -5,15 +5,18 @@ import (
 	"context"
 	"fmt"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/generic"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/apiserver/pkg/storage/names"
 
 	"github.com/jetstack/tarmak/pkg/apis/wing"
+	"github.com/jetstack/tarmak/pkg/wing/auth"
 )
 
 // NewStrategy creates and returns a instanceStrategy instance
@@ -62,7 +65,21 @@ func (instanceStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.O
 }
 
 func (instanceStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
-	return nil
+	u, ok := genericapirequest.UserFrom(ctx)
+	if !ok || !auth.IsInstance(u) {
+		return nil
+	}
+
+	// instances can only create their own instance without a spec
+	allErrs := field.ErrorList{}
+	instance := obj.(*wing.Instance)
+	if instance.Name != u.GetName() {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "name"), "instances can only create their own instance"))
+	}
+	if instance.Spec != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "instances can not set the spec"))
+	}
+	return allErrs
 }
 
 func (instanceStrategy) AllowCreateOnUpdate() bool {
@@ -77,5 +94,23 @@ func (instanceStrategy) Canonicalize(obj runtime.Object) {
 }
 
 func (instanceStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
-	return field.ErrorList{}
+	u, ok := genericapirequest.UserFrom(ctx)
+	if !ok || !auth.IsInstance(u) {
+		return field.ErrorList{}
+	}
+
+	// instances can only update their status and set their instance pool once
+	allErrs := field.ErrorList{}
+	newInstance := obj.(*wing.Instance)
+	oldInstance := old.(*wing.Instance)
+	if !apiequality.Semantic.DeepEqual(newInstance.Spec, oldInstance.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "instances can only update their status"))
+	}
+	if newInstance.InstanceID != oldInstance.InstanceID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("instanceID"), "instances can only update their status"))
+	}
+	if oldInstance.InstancePool != "" && newInstance.InstancePool != oldInstance.InstancePool {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("instancePool"), "instances can not change their instance pool"))
+	}
+	return allErrs
 }
@@ -0,0 +1,202 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+)
+
+const (
+	// location of puppet's report of the last run
+	DefaultPuppetLastRunReport = "/opt/puppetlabs/puppet/cache/state/last_run_report.yaml"
+
+	// limits to keep the instance status small
+	maxReportResources   = 25
+	maxResourceEvents    = 5
+	maxEventMessageBytes = 1024
+	maxStatusMessages    = 5
+	maxMessageBytes      = 16 * 1024
+)
+
+// puppet's last_run_report.yaml, only the fields used by wing
+type puppetRunReport struct {
+	Status           string                          `yaml:"status"`
+	Time             string                          `yaml:"time"`
+	Metrics          map[string]puppetReportMetric   `yaml:"metrics"`
+	ResourceStatuses map[string]puppetResourceStatus `yaml:"resource_statuses"`
+}
+
+type puppetReportMetric struct {
+	// list of [name, label, value]
+	Values [][]interface{} `yaml:"values"`
+}
+
+type puppetResourceStatus struct {
+	File           string              `yaml:"file"`
+	Line           int                 `yaml:"line"`
+	Changed        bool                `yaml:"changed"`
+	Failed         bool                `yaml:"failed"`
+	OutOfSync      bool                `yaml:"out_of_sync"`
+	EvaluationTime float64             `yaml:"evaluation_time"`
+	Events         []puppetReportEvent `yaml:"events"`
+}
+
+type puppetReportEvent struct {
+	Property string `yaml:"property"`
+	Status   string `yaml:"status"`
+	Message  string `yaml:"message"`
+}
+
+func readPuppetRunReport(path string) (*puppetRunReport, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(puppetRunReport)
+	if err := yaml.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// read puppet's report of a run started after the given time
+func (w *Wing) puppetReport(startedAfter time.Time) (*v1alpha1.PuppetReport, error) {
+	if w.puppetLastRunReport == "" {
+		return nil, nil
+	}
+
+	report, err := readPuppetRunReport(w.puppetLastRunReport)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := report.summary()
+	if err != nil {
+		return nil, err
+	}
+
+	// puppet didn't get as far as writing a new report
+	if summary.Time.Time.Before(startedAfter.Truncate(time.Second)) {
+		return nil, fmt.Errorf("report from %s is older than the puppet run", summary.Time)
+	}
+
+	return summary, nil
+}
+
+// summary builds a bounded summary of the report
+func (r *puppetRunReport) summary() (*v1alpha1.PuppetReport, error) {
+	t, err := time.Parse(time.RFC3339Nano, r.Time)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing report time '%s': %s", r.Time, err)
+	}
+
+	summary := &v1alpha1.PuppetReport{
+		Status: r.Status,
+		Time:   metav1.NewTime(t),
+		Resources: v1alpha1.PuppetResourceCounts{
+			Total:     r.metric("resources", "total"),
+			Changed:   r.metric("resources", "changed"),
+			Failed:    r.metric("resources", "failed"),
+			OutOfSync: r.metric("resources", "out_of_sync"),
+			Skipped:   r.metric("resources", "skipped"),
+		},
+		Duration: metav1.Duration{
+			Duration: time.Duration(r.metricFloat("time", "total") * float64(time.Second)),
+		},
+	}
+
+	var resources []v1alpha1.PuppetResource
+	for name, status := range r.ResourceStatuses {
+		if !status.OutOfSync && !status.Failed && !status.Changed {
+			continue
+		}
+		resources = append(resources, status.resource(name))
+	}
+
+	// failed resources first, then by name
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Failed != resources[j].Failed {
+			return resources[i].Failed
+		}
+		return resources[i].Resource < resources[j].Resource
+	})
+
+	if len(resources) > maxReportResources {
+		summary.Omitted = len(resources) - maxReportResources
+		resources = resources[:maxReportResources]
+	}
+	summary.OutOfSync = resources
+
+	return summary, nil
+}
+
+func (s *puppetResourceStatus) resource(name string) v1alpha1.PuppetResource {
+	resource := v1alpha1.PuppetResource{
+		Resource: name,
+		File:     s.File,
+		Line:     s.Line,
+		Changed:  s.Changed,
+		Failed:   s.Failed,
+		Duration: metav1.Duration{
+			Duration: time.Duration(s.EvaluationTime * float64(time.Second)),
+		},
+	}
+
+	for pos, event := range s.Events {
+		if pos >= maxResourceEvents {
+			break
+		}
+		resource.Events = append(resource.Events, v1alpha1.PuppetEvent{
+			Property: event.Property,
+			Status:   event.Status,
+			Message:  truncate(event.Message, maxEventMessageBytes),
+		})
+	}
+
+	return resource
+}
+
+func (r *puppetRunReport) metric(metric, name string) int {
+	return int(r.metricFloat(metric, name))
+}
+
+func (r *puppetRunReport) metricFloat(metric, name string) float64 {
+	for _, value := range r.Metrics[metric].Values {
+		if len(value) != 3 || value[0] != name {
+			continue
+		}
+		switch v := value[2].(type) {
+		case int:
+			return float64(v)
+		case float64:
+			return v
+		}
+	}
+	return 0
+}
+
+// keep the beginning of a string, limited to max bytes
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "... (truncated)"
+}
+
+// keep the end of a string, limited to max bytes, as puppet reports errors
+// at the end of its output
+func truncateHead(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return "(truncated) ..." + s[len(s)-max:]
+}
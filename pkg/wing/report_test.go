@@ -0,0 +1,224 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package wing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const lastRunReport = `--- !ruby/object:Puppet::Transaction::Report
+host: ip-10-99-64-12.eu-west-1.compute.internal
+time: '2018-12-18T10:59:05.491398096+00:00'
+configuration_version: 1545130345
+transaction_uuid: 3ba7a6c2-5a43-4b1c-9f9e-8bd6f8a5b3b0
+report_format: 10
+puppet_version: 5.5.6
+status: failed
+environment: production
+metrics:
+  resources: !ruby/object:Puppet::Util::Metric
+    name: resources
+    label: Resources
+    values:
+    - - total
+      - Total
+      - 312
+    - - failed
+      - Failed
+      - 1
+    - - changed
+      - Changed
+      - 1
+    - - out_of_sync
+      - Out of sync
+      - 2
+    - - skipped
+      - Skipped
+      - 3
+  time: !ruby/object:Puppet::Util::Metric
+    name: time
+    label: Time
+    values:
+    - - total
+      - Total
+      - 42.5
+resource_statuses:
+  File[/etc/kubernetes/kubelet.yaml]:
+    title: "/etc/kubernetes/kubelet.yaml"
+    file: "/tmp/wing-puppet-tar-gz/modules/kubernetes/manifests/kubelet.pp"
+    line: 120
+    resource: File[/etc/kubernetes/kubelet.yaml]
+    resource_type: File
+    evaluation_time: 0.012
+    failed: false
+    changed: true
+    out_of_sync: true
+    skipped: false
+    events:
+    - !ruby/object:Puppet::Transaction::Event
+      property: content
+      status: success
+      message: content changed '{md5}a' to '{md5}b'
+  Service[kubelet.service]:
+    title: kubelet.service
+    file: "/tmp/wing-puppet-tar-gz/modules/kubernetes/manifests/kubelet.pp"
+    line: 140
+    resource: Service[kubelet.service]
+    resource_type: Service
+    evaluation_time: 1.5
+    failed: true
+    changed: false
+    out_of_sync: true
+    skipped: false
+    events:
+    - !ruby/object:Puppet::Transaction::Event
+      property: ensure
+      status: failure
+      message: 'Could not start Service[kubelet.service]: Execution of ''/bin/systemctl start kubelet.service'' returned 1'
+  Package[socat]:
+    title: socat
+    file: "/tmp/wing-puppet-tar-gz/modules/kubernetes/manifests/kubelet.pp"
+    line: 40
+    resource: Package[socat]
+    resource_type: Package
+    evaluation_time: 0.2
+    failed: false
+    changed: false
+    out_of_sync: false
+    skipped: false
+    events: []
+`
+
+func writeReport(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "wing-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "last_run_report.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestWing_puppetReport(t *testing.T) {
+	path, cleanup := writeReport(t, lastRunReport)
+	defer cleanup()
+
+	w := &Wing{puppetLastRunReport: path}
+	started := time.Date(2018, 12, 18, 10, 58, 0, 0, time.UTC)
+
+	report, err := w.puppetReport(started)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if exp, act := "failed", report.Status; exp != act {
+		t.Errorf("unexpected status, exp=%s act=%s", exp, act)
+	}
+	if exp, act := 42500*time.Millisecond, report.Duration.Duration; exp != act {
+		t.Errorf("unexpected duration, exp=%s act=%s", exp, act)
+	}
+	if exp, act := 312, report.Resources.Total; exp != act {
+		t.Errorf("unexpected total resources, exp=%d act=%d", exp, act)
+	}
+	if exp, act := 1, report.Resources.Failed; exp != act {
+		t.Errorf("unexpected failed resources, exp=%d act=%d", exp, act)
+	}
+	if exp, act := 3, report.Resources.Skipped; exp != act {
+		t.Errorf("unexpected skipped resources, exp=%d act=%d", exp, act)
+	}
+
+	if exp, act := 2, len(report.OutOfSync); exp != act {
+		t.Fatalf("unexpected number of out of sync resources, exp=%d act=%d", exp, act)
+	}
+
+	failed := report.OutOfSync[0]
+	if exp, act := "Service[kubelet.service]", failed.Resource; exp != act {
+		t.Errorf("expected failed resource first, exp=%s act=%s", exp, act)
+	}
+	if !failed.Failed || failed.Line != 140 || len(failed.Events) != 1 {
+		t.Errorf("unexpected failed resource: %+v", failed)
+	}
+	if exp, act := "ensure", failed.Events[0].Property; exp != act {
+		t.Errorf("unexpected event property, exp=%s act=%s", exp, act)
+	}
+	if !strings.Contains(failed.Events[0].Message, "Could not start Service[kubelet.service]") {
+		t.Errorf("unexpected event message: %s", failed.Events[0].Message)
+	}
+
+	if exp, act := "File[/etc/kubernetes/kubelet.yaml]", report.OutOfSync[1].Resource; exp != act {
+		t.Errorf("unexpected changed resource, exp=%s act=%s", exp, act)
+	}
+}
+
+func TestWing_puppetReport_Stale(t *testing.T) {
+	path, cleanup := writeReport(t, lastRunReport)
+	defer cleanup()
+
+	w := &Wing{puppetLastRunReport: path}
+	if _, err := w.puppetReport(time.Date(2018, 12, 18, 11, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("expected error for report older than the puppet run")
+	}
+}
+
+func TestPuppetRunReport_summary_Bounds(t *testing.T) {
+	report := &puppetRunReport{
+		Status:           "failed",
+		Time:             "2018-12-18T10:59:05+00:00",
+		ResourceStatuses: make(map[string]puppetResourceStatus),
+	}
+
+	var events []puppetReportEvent
+	for i := 0; i < maxResourceEvents+3; i++ {
+		events = append(events, puppetReportEvent{
+			Property: "ensure",
+			Status:   "failure",
+			Message:  strings.Repeat("x", maxEventMessageBytes*2),
+		})
+	}
+	for i := 0; i < maxReportResources+10; i++ {
+		report.ResourceStatuses[fmt.Sprintf("Exec[%03d]", i)] = puppetResourceStatus{
+			Failed:    true,
+			OutOfSync: true,
+			Events:    events,
+		}
+	}
+
+	summary, err := report.summary()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if exp, act := maxReportResources, len(summary.OutOfSync); exp != act {
+		t.Errorf("unexpected number of resources, exp=%d act=%d", exp, act)
+	}
+	if exp, act := 10, summary.Omitted; exp != act {
+		t.Errorf("unexpected number of omitted resources, exp=%d act=%d", exp, act)
+	}
+	if exp, act := "Exec[000]", summary.OutOfSync[0].Resource; exp != act {
+		t.Errorf("unexpected first resource, exp=%s act=%s", exp, act)
+	}
+
+	resource := summary.OutOfSync[0]
+	if exp, act := maxResourceEvents, len(resource.Events); exp != act {
+		t.Errorf("unexpected number of events, exp=%d act=%d", exp, act)
+	}
+	if act := len(resource.Events[0].Message); act > maxEventMessageBytes+32 {
+		t.Errorf("event message not truncated, length=%d", act)
+	}
+}
+
+func TestTruncateHead(t *testing.T) {
+	act := truncateHead("first line\nsecond line\nError: failed", 13)
+	if !strings.HasSuffix(act, "Error: failed") || strings.Contains(act, "first") {
+		t.Errorf("unexpected truncated message: %s", act)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// separates windows in a list of windows
+const WindowSeparator = ";"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Window is a recurring weekly time window, for example
+// 'Mon-Fri 01:00-05:00 Europe/London'. Windows ending before they start
+// span midnight, the days refer to the start of the window.
+type Window struct {
+	days     [7]bool
+	start    time.Duration // since midnight
+	end      time.Duration // since midnight
+	location *time.Location
+	text     string
+}
+
+// ParseWindow parses '<days> <HH:MM>-<HH:MM> [timezone]'. Days are '*' or a
+// comma separated list of days and ranges of days like 'Mon-Fri,Sun'. The
+// timezone defaults to UTC.
+func ParseWindow(text string) (*Window, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("invalid window '%s', expected '<days> <HH:MM>-<HH:MM> [timezone]'", text)
+	}
+
+	w := &Window{
+		location: time.UTC,
+		text:     strings.Join(fields, " "),
+	}
+
+	if err := w.parseDays(fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid days in window '%s': %s", text, err)
+	}
+
+	times := strings.Split(fields[1], "-")
+	if len(times) != 2 {
+		return nil, fmt.Errorf("invalid time range '%s' in window '%s'", fields[1], text)
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(times[0]); err != nil {
+		return nil, fmt.Errorf("invalid start in window '%s': %s", text, err)
+	}
+	if w.end, err = parseTimeOfDay(times[1]); err != nil {
+		return nil, fmt.Errorf("invalid end in window '%s': %s", text, err)
+	}
+	if w.start == w.end {
+		return nil, fmt.Errorf("empty time range in window '%s'", text)
+	}
+	if w.start == 24*time.Hour {
+		return nil, fmt.Errorf("window '%s' can not start at 24:00", text)
+	}
+
+	if len(fields) == 3 {
+		if w.location, err = time.LoadLocation(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid timezone in window '%s': %s", text, err)
+		}
+	}
+
+	return w, nil
+}
+
+// ParseWindows parses a list of windows separated by ';', an empty list
+// returns no windows
+func ParseWindows(text string) ([]*Window, error) {
+	var windows []*Window
+	for _, part := range strings.Split(text, WindowSeparator) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		w, err := ParseWindow(part)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// Contains returns true if t is within the window
+func (w *Window) Contains(t time.Time) bool {
+	t = t.In(w.location)
+	day := t.Weekday()
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	if w.start < w.end {
+		return w.days[day] && offset >= w.start && offset < w.end
+	}
+
+	// window spans midnight
+	previousDay := (day + 6) % 7
+	return (w.days[day] && offset >= w.start) || (w.days[previousDay] && offset < w.end)
+}
+
+func (w *Window) String() string {
+	return w.text
+}
+
+// Allowed returns true if t is within any of the windows, or if there are no
+// windows
+func Allowed(windows []*Window, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Window) parseDays(text string) error {
+	if text == "*" {
+		for pos := range w.days {
+			w.days[pos] = true
+		}
+		return nil
+	}
+
+	for _, part := range strings.Split(text, ",") {
+		bounds := strings.Split(part, "-")
+		if len(bounds) > 2 {
+			return fmt.Errorf("invalid range of days '%s'", part)
+		}
+
+		first, ok := weekdays[strings.ToLower(bounds[0])]
+		if !ok {
+			return fmt.Errorf("unknown day '%s'", bounds[0])
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, ok = weekdays[strings.ToLower(bounds[1])]; !ok {
+				return fmt.Errorf("unknown day '%s'", bounds[1])
+			}
+		}
+
+		// ranges can wrap around the week, like Sat-Mon
+		for day := first; ; day = (day + 1) % 7 {
+			w.days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// parse HH:MM into the duration since midnight, 24:00 is allowed as end of
+// day
+func parseTimeOfDay(text string) (time.Duration, error) {
+	parts := strings.Split(text, ":")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", text)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", text)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", text)
+	}
+
+	if hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("time '%s' out of range", text)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
@@ -0,0 +1,114 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestWindow_Contains(t *testing.T) {
+	for _, test := range []struct {
+		window string
+		times  map[string]bool
+	}{
+		{
+			window: "Mon-Fri 01:00-05:00",
+			times: map[string]bool{
+				"2018-12-17T00:59:59Z": false, // Monday
+				"2018-12-17T01:00:00Z": true,
+				"2018-12-17T04:59:59Z": true,
+				"2018-12-17T05:00:00Z": false,
+				"2018-12-21T03:00:00Z": true,  // Friday
+				"2018-12-22T03:00:00Z": false, // Saturday
+			},
+		},
+		{
+			window: "Sat,Sun 22:00-02:00",
+			times: map[string]bool{
+				"2018-12-22T23:00:00Z": true,  // Saturday
+				"2018-12-23T01:00:00Z": true,  // Sunday, started Saturday
+				"2018-12-24T01:00:00Z": true,  // Monday, started Sunday
+				"2018-12-24T23:00:00Z": false, // Monday
+				"2018-12-22T01:00:00Z": false, // Saturday, Friday not included
+			},
+		},
+		{
+			window: "Fri-Mon 00:00-24:00",
+			times: map[string]bool{
+				"2018-12-17T23:59:59Z": true,  // Monday
+				"2018-12-18T12:00:00Z": false, // Tuesday
+				"2018-12-21T00:00:00Z": true,  // Friday
+			},
+		},
+		{
+			window: "* 02:00-03:00 Europe/Berlin",
+			times: map[string]bool{
+				"2018-12-18T01:30:00Z": true, // 02:30 CET
+				"2018-12-18T02:30:00Z": false,
+				"2018-07-18T00:30:00Z": true, // 02:30 CEST
+				"2018-07-18T01:30:00Z": false,
+			},
+		},
+	} {
+		w, err := ParseWindow(test.window)
+		if err != nil {
+			t.Fatalf("unexpected error parsing '%s': %s", test.window, err)
+		}
+		for ts, exp := range test.times {
+			if act := w.Contains(mustParseTime(t, ts)); exp != act {
+				t.Errorf("window '%s' contains %s: exp=%t act=%t", test.window, ts, exp, act)
+			}
+		}
+	}
+}
+
+func TestParseWindow_Invalid(t *testing.T) {
+	for _, window := range []string{
+		"",
+		"Mon",
+		"Mon 01:00",
+		"Mon 01:00-01:00",
+		"Mon 24:00-01:00",
+		"Mon 01:00-25:00",
+		"Mon 1:60-02:00",
+		"Mon 01-02",
+		"Mo 01:00-02:00",
+		"Mon-Tue-Wed 01:00-02:00",
+		"Mon 01:00-02:00 Mars/Olympus",
+		"Mon 01:00-02:00 UTC extra",
+	} {
+		if _, err := ParseWindow(window); err == nil {
+			t.Errorf("expected error parsing '%s'", window)
+		}
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	ts := mustParseTime(t, "2018-12-18T03:00:00Z") // Tuesday
+
+	if !Allowed(nil, ts) {
+		t.Error("expected no windows to always allow")
+	}
+
+	windows, err := ParseWindows("Mon 01:00-05:00; Tue 02:00-04:00 UTC;")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp, act := 2, len(windows); exp != act {
+		t.Fatalf("unexpected number of windows: exp=%d act=%d", exp, act)
+	}
+	if !Allowed(windows, ts) {
+		t.Error("expected time to be allowed by second window")
+	}
+	if Allowed(windows, ts.Add(2*time.Hour)) {
+		t.Error("expected time outside of windows not to be allowed")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package server
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+	clientset "github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned"
+	listers "github.com/jetstack/tarmak/pkg/wing/client/listers/wing/v1alpha1"
+)
+
+const (
+	DefaultInstanceGCGracePeriod = time.Hour
+
+	instanceGCInterval = 5 * time.Minute
+
+	instanceTerminatedReason = "instance not found at the cloud provider"
+)
+
+// instanceGC marks instances that no longer exist at the cloud provider as
+// terminated and deletes them after a grace period
+type instanceGC struct {
+	client      clientset.Interface
+	lister      listers.InstanceLister
+	live        func() (map[string]bool, error)
+	gracePeriod time.Duration
+	log         *logrus.Entry
+	now         func() time.Time
+}
+
+func newInstanceGC(client clientset.Interface, lister listers.InstanceLister, live func() (map[string]bool, error), gracePeriod time.Duration, log *logrus.Entry) *instanceGC {
+	return &instanceGC{
+		client:      client,
+		lister:      lister,
+		live:        live,
+		gracePeriod: gracePeriod,
+		log:         log.WithField("tier", "instance-gc"),
+		now:         time.Now,
+	}
+}
+
+func (g *instanceGC) Run(stopCh <-chan struct{}) {
+	g.log.Infof("starting instance garbage collection with a grace period of %s", g.gracePeriod)
+	wait.Until(g.collect, instanceGCInterval, stopCh)
+}
+
+func (g *instanceGC) collect() {
+	live, err := g.live()
+	if err != nil {
+		g.log.Warnf("error listing instances of the cloud provider: %s", err)
+		return
+	}
+
+	// never treat all instances as vanished, the provider query is most
+	// likely broken
+	if len(live) == 0 {
+		g.log.Warn("cloud provider returned no instances, skipping garbage collection")
+		return
+	}
+
+	instances, err := g.lister.List(labels.Everything())
+	if err != nil {
+		g.log.Warnf("error listing instances: %s", err)
+		return
+	}
+
+	for _, instance := range instances {
+		if err := g.collectInstance(instance, live[instance.Name]); err != nil {
+			g.log.Warnf("error collecting instance %s/%s: %s", instance.Namespace, instance.Name, err)
+		}
+	}
+}
+
+func (g *instanceGC) collectInstance(instance *v1alpha1.Instance, live bool) error {
+	terminated := instance.Status != nil && instance.Status.Terminated != nil
+	instanceAPI := g.client.WingV1alpha1().Instances(instance.Namespace)
+
+	if live {
+		if !terminated {
+			return nil
+		}
+		g.log.Infof("instance %s/%s exists again", instance.Namespace, instance.Name)
+		instance = instance.DeepCopy()
+		instance.Status.Terminated = nil
+		_, err := instanceAPI.Update(instance)
+		return err
+	}
+
+	if !terminated {
+		g.log.Infof("instance %s/%s not found at the cloud provider, marking it as terminated", instance.Namespace, instance.Name)
+		instance = instance.DeepCopy()
+		if instance.Status == nil {
+			instance.Status = &v1alpha1.InstanceStatus{}
+		}
+		instance.Status.Terminated = &v1alpha1.InstanceTermination{
+			Timestamp: metav1.NewTime(g.now()),
+			Reason:    instanceTerminatedReason,
+		}
+		_, err := instanceAPI.Update(instance)
+		return err
+	}
+
+	if g.now().Sub(instance.Status.Terminated.Timestamp.Time) < g.gracePeriod {
+		return nil
+	}
+
+	g.log.Infof("deleting terminated instance %s/%s", instance.Namespace, instance.Name)
+	err := instanceAPI.Delete(instance.Name, &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+	"github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned/fake"
+	listers "github.com/jetstack/tarmak/pkg/wing/client/listers/wing/v1alpha1"
+)
+
+func newTestInstanceGC(t *testing.T, live map[string]bool, liveErr error, instances ...*v1alpha1.Instance) (*instanceGC, *fake.Clientset) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	var objects []runtime.Object
+	for _, instance := range instances {
+		if err := indexer.Add(instance); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, instance)
+	}
+
+	client := fake.NewSimpleClientset(objects...)
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+
+	gc := newInstanceGC(
+		client,
+		listers.NewInstanceLister(indexer),
+		func() (map[string]bool, error) { return live, liveErr },
+		time.Hour,
+		logrus.NewEntry(logger),
+	)
+	gc.now = func() time.Time { return time.Unix(1545130390, 0) }
+
+	return gc, client
+}
+
+func terminatedInstance(name string, since time.Duration) *v1alpha1.Instance {
+	instance := newInstance(name, "worker", v1alpha1.InstanceManifestStateConverged)
+	instance.Status.Terminated = &v1alpha1.InstanceTermination{
+		Timestamp: metav1.NewTime(time.Unix(1545130390, 0).Add(-since)),
+		Reason:    instanceTerminatedReason,
+	}
+	return instance
+}
+
+func getInstance(t *testing.T, client *fake.Clientset, name string) *v1alpha1.Instance {
+	instance, err := client.WingV1alpha1().Instances("env-cluster").Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return instance
+}
+
+func TestInstanceGC(t *testing.T) {
+	gc, client := newTestInstanceGC(t,
+		map[string]bool{"i-live": true, "i-back": true},
+		nil,
+		newInstance("i-live", "worker", v1alpha1.InstanceManifestStateConverged),
+		newInstance("i-vanished", "worker", v1alpha1.InstanceManifestStateConverged),
+		newInstance("i-unknown", "worker", ""),
+		terminatedInstance("i-back", 2*time.Hour),
+		terminatedInstance("i-recent", 30*time.Minute),
+		terminatedInstance("i-expired", 2*time.Hour),
+	)
+
+	gc.collect()
+
+	if instance := getInstance(t, client, "i-live"); instance == nil || instance.Status.Terminated != nil {
+		t.Errorf("expected live instance to be untouched: %+v", instance)
+	}
+
+	for _, name := range []string{"i-vanished", "i-unknown"} {
+		instance := getInstance(t, client, name)
+		if instance == nil || instance.Status == nil || instance.Status.Terminated == nil {
+			t.Errorf("expected %s to be marked terminated: %+v", name, instance)
+			continue
+		}
+		if exp, act := gc.now().Unix(), instance.Status.Terminated.Timestamp.Unix(); exp != act {
+			t.Errorf("unexpected termination timestamp of %s: exp=%d act=%d", name, exp, act)
+		}
+	}
+
+	if instance := getInstance(t, client, "i-back"); instance == nil || instance.Status.Terminated != nil {
+		t.Errorf("expected termination of instance that exists again to be cleared: %+v", instance)
+	}
+
+	if instance := getInstance(t, client, "i-recent"); instance == nil {
+		t.Errorf("expected instance within grace period not to be deleted")
+	}
+
+	if instance := getInstance(t, client, "i-expired"); instance != nil {
+		t.Errorf("expected instance after grace period to be deleted: %+v", instance)
+	}
+}
+
+func TestInstanceGC_ProviderFailure(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		live map[string]bool
+		err  error
+	}{
+		{"error", nil, fmt.Errorf("access denied")},
+		{"empty", map[string]bool{}, nil},
+	} {
+		gc, client := newTestInstanceGC(t, test.live, test.err,
+			newInstance("i-1", "worker", v1alpha1.InstanceManifestStateConverged),
+			terminatedInstance("i-2", 2*time.Hour),
+		)
+
+		gc.collect()
+
+		if len(client.Actions()) != 0 {
+			t.Errorf("%s: expected no API calls, got %+v", test.name, client.Actions())
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package server
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	listers "github.com/jetstack/tarmak/pkg/wing/client/listers/wing/v1alpha1"
+)
+
+const (
+	DefaultMetricsBindAddress = ":9102"
+
+	// state of instances that have not reported a status yet
+	instanceStateUnknown = "unknown"
+
+	// state of instances that vanished at the cloud provider
+	instanceStateTerminated = "terminated"
+)
+
+var (
+	instancesDesc = prometheus.NewDesc(
+		"wing_instances",
+		"Number of instances by cluster, instance pool and converge state.",
+		[]string{"cluster", "instance_pool", "state"},
+		nil,
+	)
+
+	instanceLastUpdateDesc = prometheus.NewDesc(
+		"wing_instance_last_update_timestamp_seconds",
+		"Unix timestamp of the last converge status update of an instance.",
+		[]string{"cluster", "instance_pool", "instance_id", "state"},
+		nil,
+	)
+)
+
+// instanceCollector exports the converge state of all instances known to the
+// wing server
+type instanceCollector struct {
+	lister listers.InstanceLister
+	log    *logrus.Entry
+}
+
+var _ prometheus.Collector = &instanceCollector{}
+
+func newInstanceCollector(lister listers.InstanceLister, log *logrus.Entry) *instanceCollector {
+	return &instanceCollector{
+		lister: lister,
+		log:    log,
+	}
+}
+
+func (c *instanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- instancesDesc
+	ch <- instanceLastUpdateDesc
+}
+
+func (c *instanceCollector) Collect(ch chan<- prometheus.Metric) {
+	instances, err := c.lister.List(labels.Everything())
+	if err != nil {
+		c.log.Warnf("error listing instances: %s", err)
+		return
+	}
+
+	type key struct {
+		cluster, pool, state string
+	}
+	counts := make(map[key]int)
+
+	for _, instance := range instances {
+		state := instanceStateUnknown
+		if instance.Status != nil && instance.Status.Terminated != nil {
+			state = instanceStateTerminated
+		} else if instance.Status != nil && instance.Status.Converge != nil && instance.Status.Converge.State != "" {
+			state = string(instance.Status.Converge.State)
+
+			ch <- prometheus.MustNewConstMetric(
+				instanceLastUpdateDesc,
+				prometheus.GaugeValue,
+				float64(instance.Status.Converge.LastUpdateTimestamp.Unix()),
+				instance.Namespace, instance.InstancePool, instance.Name, state,
+			)
+		}
+
+		counts[key{instance.Namespace, instance.InstancePool, state}]++
+	}
+
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			instancesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			k.cluster, k.pool, k.state,
+		)
+	}
+}
+
+// expose the metrics of the default registry, this includes the API server's
+// own metrics
+func serveMetrics(address string, log *logrus.Entry, stopCh <-chan struct{}) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler())
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-stopCh
+		server.Close()
+	}()
+
+	go func() {
+		log.Infof("serving metrics on %s", address)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Warnf("error serving metrics: %s", err)
+		}
+	}()
+}
@@ -0,0 +1,89 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package server
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
+	listers "github.com/jetstack/tarmak/pkg/wing/client/listers/wing/v1alpha1"
+)
+
+func newInstance(name, pool string, state v1alpha1.InstanceManifestState) *v1alpha1.Instance {
+	instance := &v1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "env-cluster",
+		},
+		InstancePool: pool,
+	}
+	if state != "" {
+		instance.Status = &v1alpha1.InstanceStatus{
+			Converge: &v1alpha1.InstanceStatusManifest{
+				State:               state,
+				LastUpdateTimestamp: metav1.NewTime(time.Unix(1545130390, 0)),
+			},
+		}
+	}
+	return instance
+}
+
+func TestInstanceCollector(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, instance := range []*v1alpha1.Instance{
+		newInstance("i-1", "worker", v1alpha1.InstanceManifestStateConverged),
+		newInstance("i-2", "worker", v1alpha1.InstanceManifestStateConverged),
+		newInstance("i-3", "worker", v1alpha1.InstanceManifestStateError),
+		newInstance("i-4", "master", ""),
+	} {
+		if err := indexer.Add(instance); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newInstanceCollector(listers.NewInstanceLister(indexer), logrus.NewEntry(logger)))
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	metrics := make(map[string][]*dto.Metric)
+	for _, family := range families {
+		metrics[family.GetName()] = family.GetMetric()
+	}
+
+	counts := make(map[string]float64)
+	for _, m := range metrics["wing_instances"] {
+		l := make(map[string]string)
+		for _, pair := range m.GetLabel() {
+			l[pair.GetName()] = pair.GetValue()
+		}
+		counts[l["instance_pool"]+"/"+l["state"]] = m.GetGauge().GetValue()
+	}
+
+	for key, exp := range map[string]float64{
+		"worker/converged": 2,
+		"worker/error":     1,
+		"master/unknown":   1,
+	} {
+		if act := counts[key]; act != exp {
+			t.Errorf("unexpected count of %s, exp=%v act=%v", key, exp, act)
+		}
+	}
+
+	if exp, act := 3, len(metrics["wing_instance_last_update_timestamp_seconds"]); exp != act {
+		t.Errorf("unexpected number of last update metrics, exp=%d act=%d", exp, act)
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"io"
 	"net"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,21 +19,56 @@ import (
 	admissionmetrics "k8s.io/apiserver/pkg/admission/metrics"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
 	"github.com/jetstack/tarmak/pkg/wing/admission/plugin/instanceinittime"
 	"github.com/jetstack/tarmak/pkg/wing/admission/winginitializer"
 	"github.com/jetstack/tarmak/pkg/wing/apiserver"
+	"github.com/jetstack/tarmak/pkg/wing/auth"
 	clientset "github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned"
 	informers "github.com/jetstack/tarmak/pkg/wing/client/informers/externalversions"
+	"github.com/jetstack/tarmak/pkg/wing/etcd"
+	"github.com/jetstack/tarmak/pkg/wing/pki"
 	"github.com/jetstack/tarmak/pkg/wing/tags"
 )
 
-const defaultEtcdPathPrefix = "/registry/wing.tarmak.io"
+// DefaultSnapshotInterval is the interval of backing up the state to S3
+const DefaultSnapshotInterval = 10 * time.Minute
 
 type WingServerOptions struct {
 	RecommendedOptions *genericoptions.RecommendedOptions
 
+	Environment string
+
+	// regions the environment's clusters are placed in, instances are only
+	// accepted from and garbage collected in these and the wing server's
+	// region
+	Regions []string
+
+	// CA to issue and verify instance client certificates, if not set
+	// authentication and authorization are disabled
+	CACertFile string
+	CAKeyFile  string
+
+	// address to serve prometheus metrics on, empty disables metrics
+	MetricsBindAddress string
+
+	// grace period before deleting instances that vanished at the cloud
+	// provider, zero disables garbage collection
+	InstanceGCGracePeriod time.Duration
+
+	// run controllers only on the elected leader, when multiple wing servers
+	// share an etcd cluster
+	LeaderElect bool
+
+	// S3 URL to back up the state to periodically, it is restored from there
+	// on start when etcd contains no state
+	SnapshotURL      string
+	SnapshotInterval time.Duration
+
+	Tags tags.Tags
+
 	SharedInformerFactory informers.SharedInformerFactory
 	StdOut                io.Writer
 	StdErr                io.Writer
@@ -38,7 +77,7 @@ type WingServerOptions struct {
 func NewWingServerOptions(out, errOut io.Writer) *WingServerOptions {
 	o := &WingServerOptions{
 		RecommendedOptions: genericoptions.NewRecommendedOptions(
-			defaultEtcdPathPrefix,
+			etcd.DefaultPrefix,
 			apiserver.Codecs.LegacyCodec(v1alpha1.SchemeGroupVersion),
 			genericoptions.NewProcessInfo("wing-apiserver", "wing"),
 		),
@@ -67,6 +106,7 @@ func NewCommandStartWingServer(out, errOut io.Writer, stopCh <-chan struct{}) *c
 			if env == "" {
 				env = os.Getenv("WING_ENVIRONMENT")
 			}
+			o.Environment = env
 
 			t, err := tags.New(nil, env)
 			if err != nil {
@@ -76,6 +116,7 @@ func NewCommandStartWingServer(out, errOut io.Writer, stopCh <-chan struct{}) *c
 			if err := t.EnsureMachineTags(); err != nil {
 				return err
 			}
+			o.Tags = t
 
 			if err := o.Complete(); err != nil {
 				return err
@@ -93,13 +134,35 @@ func NewCommandStartWingServer(out, errOut io.Writer, stopCh <-chan struct{}) *c
 	flags := cmd.Flags()
 	o.RecommendedOptions.Etcd.AddFlags(flags)
 	o.RecommendedOptions.SecureServing.AddFlags(flags)
+	flags.StringVar(&o.CACertFile, "ca-cert-file", os.Getenv("WING_CA_CERT_FILE"), "CA certificate to verify and issue instance client certificates")
+	flags.StringVar(&o.CAKeyFile, "ca-key-file", os.Getenv("WING_CA_KEY_FILE"), "CA private key to issue instance client certificates")
+	flags.StringVar(&o.MetricsBindAddress, "metrics-bind-address", DefaultMetricsBindAddress, "address to serve prometheus metrics on, empty disables metrics")
+	flags.DurationVar(&o.InstanceGCGracePeriod, "instance-gc-grace-period", DefaultInstanceGCGracePeriod, "grace period before deleting instances that no longer exist at the cloud provider, 0 disables garbage collection")
+	flags.BoolVar(&o.LeaderElect, "leader-elect", true, "elect a leader through etcd to run controllers, required when running multiple wing servers")
+	flags.StringVar(&o.SnapshotURL, "snapshot-url", os.Getenv("WING_SNAPSHOT_URL"), "s3://bucket/key to back up the state to, it is restored from there if etcd contains no state on start")
+	flags.DurationVar(&o.SnapshotInterval, "snapshot-interval", DefaultSnapshotInterval, "interval of backing up the state to --snapshot-url")
+	flags.StringSliceVar(&o.Regions, "regions", defaultRegions(), "regions the environment's clusters are placed in, in addition to the region of the wing server")
 
 	return cmd
 }
 
+// defaultRegions reads the comma separated regions from WING_REGIONS
+func defaultRegions() []string {
+	var regions []string
+	for _, region := range strings.Split(os.Getenv("WING_REGIONS"), ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
 func (o WingServerOptions) Validate(args []string) error {
 	errors := []error{}
 	errors = append(errors, o.RecommendedOptions.Validate()...)
+	if (o.CACertFile == "") != (o.CAKeyFile == "") {
+		errors = append(errors, fmt.Errorf("--ca-cert-file and --ca-key-file need to be specified together"))
+	}
 	return utilerrors.NewAggregate(errors)
 }
 
@@ -169,6 +232,27 @@ func (o *WingServerOptions) Config() (*apiserver.Config, error) {
 		ExtraConfig:   apiserver.ExtraConfig{},
 	}
 
+	if o.CACertFile != "" {
+		ca, err := pki.LoadCA(o.CACertFile, o.CAKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		verifier, err := pki.NewAWSVerifier(o.Environment, o.Regions)
+		if err != nil {
+			return nil, err
+		}
+
+		serverConfig.SecureServing.ClientCA = ca.Pool()
+		serverConfig.Authentication.Authenticator = auth.NewAuthenticator(ca.Pool())
+		serverConfig.Authorization.Authorizer = auth.NewAuthorizer()
+		config.ExtraConfig.Bootstrap = pki.NewBootstrap(
+			logrus.NewEntry(logrus.New()).WithField("app", "wing-server"),
+			ca,
+			verifier,
+		)
+	}
+
 	return config, nil
 }
 
@@ -183,5 +267,111 @@ func (o WingServerOptions) RunWingServer(stopCh <-chan struct{}) error {
 		return err
 	}
 
+	log := logrus.NewEntry(logrus.New()).WithField("app", "wing-server")
+
+	// restore the state before serving, in case the bastion has been replaced
+	var backup *etcd.Backup
+	if o.SnapshotURL != "" {
+		storage := o.RecommendedOptions.Etcd.StorageConfig
+		client, err := etcd.NewClient(storage.ServerList, storage.CAFile, storage.CertFile, storage.KeyFile)
+		if err != nil {
+			return fmt.Errorf("error connecting to etcd to restore state: %s", err)
+		}
+		defer client.Close()
+
+		backup, err = etcd.NewS3Backup(client, storage.Prefix, o.SnapshotURL, log)
+		if err != nil {
+			return err
+		}
+		if err := backup.RestoreIfEmpty(); err != nil {
+			return fmt.Errorf("error restoring state: %s", err)
+		}
+	}
+
+	// listers need to be requested before the informers are started
+	if o.MetricsBindAddress != "" {
+		lister := o.SharedInformerFactory.Wing().V1alpha1().Instances().Lister()
+		prometheus.MustRegister(newInstanceCollector(lister, log))
+
+		err := server.GenericAPIServer.AddPostStartHook("start-wing-metrics", func(context genericapiserver.PostStartHookContext) error {
+			serveMetrics(o.MetricsBindAddress, log, context.StopCh)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.InstanceGCGracePeriod > 0 && o.Tags != nil {
+		lister := o.SharedInformerFactory.Wing().V1alpha1().Instances().Lister()
+
+		err := server.GenericAPIServer.AddPostStartHook("start-wing-instance-gc", func(context genericapiserver.PostStartHookContext) error {
+			client, err := clientset.NewForConfig(context.LoopbackClientConfig)
+			if err != nil {
+				return err
+			}
+			live := func() (map[string]bool, error) {
+				return o.Tags.LiveInstances(o.Regions)
+			}
+			gc := newInstanceGC(client, lister, live, o.InstanceGCGracePeriod, log)
+
+			go func() {
+				if !cache.WaitForCacheSync(context.StopCh, o.SharedInformerFactory.Wing().V1alpha1().Instances().Informer().HasSynced) {
+					return
+				}
+				o.runController("instance-gc", log, context.StopCh, gc.Run)
+			}()
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if backup != nil {
+		err := server.GenericAPIServer.AddPostStartHook("start-wing-snapshot", func(context genericapiserver.PostStartHookContext) error {
+			go o.runController("snapshot", log, context.StopCh, func(stopCh <-chan struct{}) {
+				backup.Run(o.SnapshotInterval, stopCh)
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = server.GenericAPIServer.AddPostStartHook("start-wing-informers", func(context genericapiserver.PostStartHookContext) error {
+		o.SharedInformerFactory.Start(context.StopCh)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
 	return server.GenericAPIServer.PrepareRun().Run(stopCh)
 }
+
+// run a controller, only while being the leader if leader election is enabled
+func (o WingServerOptions) runController(name string, log *logrus.Entry, stopCh <-chan struct{}, run func(stopCh <-chan struct{})) {
+	if !o.LeaderElect {
+		run(stopCh)
+		return
+	}
+
+	storage := o.RecommendedOptions.Etcd.StorageConfig
+	client, err := etcd.NewClient(storage.ServerList, storage.CAFile, storage.CertFile, storage.KeyFile)
+	if err != nil {
+		log.Errorf("error connecting to etcd for leader election, not running %s: %s", name, err)
+		return
+	}
+	defer client.Close()
+
+	identity, err := os.Hostname()
+	if err != nil {
+		identity = "unknown"
+	}
+	identity = fmt.Sprintf("%s_%d", identity, os.Getpid())
+
+	key := fmt.Sprintf("%s%s/%s", strings.TrimSuffix(storage.Prefix, "/"), etcd.LeaderElectionPrefix, name)
+	etcd.RunAsLeader(client, key, identity, log, stopCh, run)
+}
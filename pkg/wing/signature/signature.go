@@ -0,0 +1,99 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// detached signatures are stored next to the signed object with this suffix
+const Suffix = ".sig"
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// GenerateKey creates a new ECDSA P-256 signing key
+func GenerateKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// Sign creates a base64 encoded detached signature over the sha256 hash of
+// data
+func Sign(key *ecdsa.PrivateKey, data []byte) ([]byte, error) {
+	sig, err := key.Sign(rand.Reader, Digest(data), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error signing: %s", err)
+	}
+	return Encode(sig), nil
+}
+
+// Digest returns the hash of data, which signatures are created over
+func Digest(data []byte) []byte {
+	digest := sha256.Sum256(data)
+	return digest[:]
+}
+
+// Encode turns an ASN.1 DER ECDSA signature over Digest of the data, e.g.
+// created by an external signer, into a detached signature
+func Encode(der []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(der) + "\n")
+}
+
+// Verify checks that signature has been created by Sign for data with the
+// private key belonging to pub
+func Verify(pub *ecdsa.PublicKey, data []byte, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("%s: error decoding: %s", ErrInvalidSignature, err)
+	}
+
+	var esig struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(sig, &esig); err != nil {
+		return fmt.Errorf("%s: %s", ErrInvalidSignature, err)
+	} else if len(rest) != 0 {
+		return fmt.Errorf("%s: trailing data", ErrInvalidSignature)
+	}
+
+	if !ecdsa.Verify(pub, Digest(data), esig.R, esig.S) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// EncodePublicKey encodes pub as PEM
+func EncodePublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling public key: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// ParsePublicKey parses a PEM encoded ECDSA public key
+func ParsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("no PUBLIC KEY PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, expected ECDSA", key)
+	}
+	return pub, nil
+}
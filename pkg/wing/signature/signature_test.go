@@ -0,0 +1,75 @@
+// Copyright Jetstack Ltd. See LICENSE for details.
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data := []byte("puppet.tar.gz content")
+	sig, err := Sign(key, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// round trip the public key, like it's baked into instances
+	pubPEM, err := EncodePublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pub, err := ParsePublicKey(pubPEM)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := Verify(pub, data, sig); err != nil {
+		t.Errorf("expected valid signature, got: %s", err)
+	}
+
+	if err := Verify(pub, []byte("tampered content"), sig); err == nil {
+		t.Error("expected tampered data to be rejected")
+	}
+
+	if err := Verify(pub, data, []byte("not base64!")); err == nil {
+		t.Error("expected malformed signature to be rejected")
+	}
+
+	otherKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := Verify(&otherKey.PublicKey, data, sig); err == nil {
+		t.Error("expected signature of a different key to be rejected")
+	}
+}
+
+func TestEncodeExternalSignature(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// sign the digest outside of this package, like KMS does
+	data := []byte("puppet.tar.gz content")
+	r, s, err := ecdsa.Sign(rand.Reader, key, Digest(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := Verify(&key.PublicKey, data, Encode(der)); err != nil {
+		t.Errorf("expected valid signature, got: %s", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
@@ -38,33 +39,130 @@ func New(log *logrus.Entry, e string) *AWSTags {
 }
 
 func (a *AWSTags) EnsureMachineTags() error {
-	document, err := a.requestData("document")
+	request, err := a.InstanceIdentity(nil)
 	if err != nil {
 		return err
 	}
 
+	if err := a.callLambdaFunction(request); err != nil {
+		return err
+	}
+
+	a.log.Infof("successfully ensured instance tags")
+
+	return nil
+}
+
+// InstanceIdentity returns the signed instance identity document, together
+// with signatures of data by the local ssh host keys. If data is nil, the
+// identity document gets signed.
+func (a *AWSTags) InstanceIdentity(data []byte) (*tagControl.TagInstanceRequest, error) {
+	document, err := a.requestData("document")
+	if err != nil {
+		return nil, err
+	}
+
 	rsaSig, err := a.requestData("signature")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	pks, sigs, err := a.fetchLocalKeys(document)
+	if data == nil {
+		data = document
+	}
+
+	pks, sigs, err := a.fetchLocalKeys(data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	request := &tagControl.TagInstanceRequest{
+	return &tagControl.TagInstanceRequest{
 		KeySignatures:       sigs,
 		PublicKeys:          pks,
 		InstanceDocumentRaw: document,
 		RSASignature:        rsaSig,
+	}, nil
+}
+
+// InstanceType returns the EC2 instance type from the instance identity
+// document
+func (a *AWSTags) InstanceType() (string, error) {
+	data, err := a.requestData("document")
+	if err != nil {
+		return "", err
 	}
 
-	if err := a.callLambdaFunction(request); err != nil {
-		return err
+	document := new(ec2metadata.EC2InstanceIdentityDocument)
+	if err := json.Unmarshal(data, document); err != nil {
+		return "", fmt.Errorf("failed to unmarshal identity document: %s", err)
 	}
 
-	a.log.Infof("successfully ensured instance tags")
+	return document.InstanceType, nil
+}
+
+// LiveInstances returns the IDs of all instances of the environment, that
+// have not been terminated, in the region of this instance and the given
+// regions. It fails if any of the regions can't be queried, so that instances
+// of that region are not mistaken as gone.
+func (a *AWSTags) LiveInstances(regions []string) (map[string]bool, error) {
+	data, err := a.requestData("document")
+	if err != nil {
+		return nil, err
+	}
+
+	document := new(ec2metadata.EC2InstanceIdentityDocument)
+	if err := json.Unmarshal(data, document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal identity document: %s", err)
+	}
+
+	instances := make(map[string]bool)
+	queried := make(map[string]bool)
+	for _, region := range append([]string{document.Region}, regions...) {
+		if queried[region] {
+			continue
+		}
+		queried[region] = true
+
+		if err := a.liveInstancesInRegion(region, instances); err != nil {
+			return nil, err
+		}
+	}
+
+	return instances, nil
+}
+
+func (a *AWSTags) liveInstancesInRegion(region string, instances map[string]bool) error {
+	svc := ec2.New(session.New(&aws.Config{
+		Region: aws.String(region),
+	}))
+
+	err := svc.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			&ec2.Filter{
+				Name:   aws.String("tag:Environment"),
+				Values: []*string{aws.String(a.environment)},
+			},
+			&ec2.Filter{
+				Name: aws.String("instance-state-name"),
+				Values: []*string{
+					aws.String("pending"),
+					aws.String("running"),
+					aws.String("stopping"),
+					aws.String("stopped"),
+				},
+			},
+		},
+	}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				instances[aws.StringValue(instance.InstanceId)] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe instances in region %s: %s", region, err)
+	}
 
 	return nil
 }
@@ -104,7 +202,7 @@ func (a *AWSTags) callLambdaFunction(request *tagControl.TagInstanceRequest) err
 	return nil
 }
 
-func (a *AWSTags) fetchLocalKeys(document []byte) (map[string][]byte, map[string]*ssh.Signature, error) {
+func (a *AWSTags) fetchLocalKeys(data []byte) (map[string][]byte, map[string]*ssh.Signature, error) {
 	fs, err := ioutil.ReadDir(keyDir)
 	if err != nil {
 		return nil, nil, err
@@ -154,7 +252,7 @@ func (a *AWSTags) fetchLocalKeys(document []byte) (map[string][]byte, map[string
 			return nil, nil, fmt.Errorf("failed to parse local private key %s: %s", path, err)
 		}
 
-		sig, err := signer.Sign(rand.Reader, document)
+		sig, err := signer.Sign(rand.Reader, data)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -7,10 +7,15 @@ import (
 
 	"github.com/jetstack/tarmak/pkg/wing/tags/aws"
 	"github.com/sirupsen/logrus"
+
+	tagControl "github.com/jetstack/tarmak/pkg/tagging_control"
 )
 
 type Tags interface {
 	EnsureMachineTags() error
+	InstanceIdentity(data []byte) (*tagControl.TagInstanceRequest, error)
+	InstanceType() (string, error)
+	LiveInstances(regions []string) (map[string]bool, error)
 }
 
 func New(log *logrus.Entry, environment string) (Tags, error) {
@@ -2,7 +2,10 @@
 package wing
 
 import (
+	"crypto/ecdsa"
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -19,11 +22,13 @@ import (
 
 	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
 	client "github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned"
+	"github.com/jetstack/tarmak/pkg/wing/schedule"
 	"github.com/jetstack/tarmak/pkg/wing/tags"
 )
 
 const (
 	DefaultInstanceName = "$(hostname)"
+	DefaultPKIDir       = "/etc/wing/pki"
 )
 
 type Wing struct {
@@ -40,8 +45,37 @@ type Wing struct {
 	// controller loop
 	controller *Controller
 
+	// runs commands targeting this instance
+	commandController *CommandController
+
 	// allows overriding puppet command for testing
 	puppetCommandOverride Command
+
+	// puppet's last run summary, parsed for metrics
+	puppetLastRunSummary string
+
+	// puppet's last run report, parsed for the instance status
+	puppetLastRunReport string
+
+	// cloud provider specific instance details
+	tags tags.Tags
+
+	// latest facts gathered about the instance
+	facts   *v1alpha1.InstanceFacts
+	factsMu sync.Mutex
+	factsCh chan struct{} // triggers gathering facts
+
+	// completion of the last converge, for periodic reconverges
+	lastConvergeTime time.Time
+	lastConvergeMu   sync.Mutex
+
+	// public key manifest signatures are verified against
+	manifestPublicKey *ecdsa.PublicKey
+
+	// client certificate presented to the wing server, it is replaced when
+	// renewed
+	clientCert   *tls.Certificate
+	clientCertMu sync.Mutex
 }
 
 type Flags struct {
@@ -49,6 +83,20 @@ type Flags struct {
 	ServerURL    string
 	ClusterName  string
 	InstanceName string
+	InstancePool string
+	CAFile       string
+	PKIDir       string
+
+	MetricsBindAddress string
+	FactsInterval      time.Duration
+
+	// periodic reconverges, a zero interval disables them
+	ReconvergeInterval time.Duration
+	ReconvergeJitter   time.Duration
+	MaintenanceWindows string
+
+	// public key to verify manifest signatures, empty disables verification
+	ManifestPublicKeyFile string
 }
 
 func New(flags *Flags) *Wing {
@@ -56,10 +104,13 @@ func New(flags *Flags) *Wing {
 	logger.Level = logrus.DebugLevel
 
 	t := &Wing{
-		log:            logger.WithField("app", "wing"),
-		flags:          flags,
-		stopCh:         make(chan struct{}),
-		convergeStopCh: make(chan struct{}),
+		log:                  logger.WithField("app", "wing"),
+		flags:                flags,
+		stopCh:               make(chan struct{}),
+		convergeStopCh:       make(chan struct{}),
+		puppetLastRunSummary: DefaultPuppetLastRunSummary,
+		puppetLastRunReport:  DefaultPuppetLastRunReport,
+		factsCh:              make(chan struct{}, 1),
 	}
 	return t
 }
@@ -81,6 +132,7 @@ func (w *Wing) Run(args []string) error {
 	if err := t.EnsureMachineTags(); err != nil {
 		return err
 	}
+	w.tags = t
 
 	if w.flags.InstanceName == DefaultInstanceName {
 		instanceName, err := os.Hostname()
@@ -97,16 +149,21 @@ func (w *Wing) Run(args []string) error {
 	if w.flags.ManifestURL == "" {
 		errors = append(errors, fmt.Errorf("--manifest-url flag cannot be empty"))
 	}
+	maintenanceWindows, err := schedule.ParseWindows(w.flags.MaintenanceWindows)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("invalid --maintenance-windows: %s", err))
+	}
+	if err := w.loadManifestPublicKey(); err != nil {
+		errors = append(errors, err)
+	}
 	if err := utilerrors.NewAggregate(errors); err != nil {
 		return err
 	}
 
 	// create connection to wing server
-	restConfig := &rest.Config{
-		Host: w.flags.ServerURL,
-		TLSClientConfig: rest.TLSClientConfig{
-			Insecure: true,
-		},
+	restConfig, err := w.restConfig(t)
+	if err != nil {
+		return err
 	}
 	clientset, err := client.NewForConfig(restConfig)
 	if err != nil {
@@ -114,17 +171,38 @@ func (w *Wing) Run(args []string) error {
 	}
 	w.clientset = clientset
 
+	// renew the client certificate before it expires
+	if w.flags.CAFile != "" {
+		go w.renewClientCertificateLoop(t)
+	}
+
 	// listen to signals
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
 	w.signalHandler(signalCh)
 
+	// expose metrics about convergence
+	w.serveMetrics()
+
+	// publish facts about the instance
+	if w.flags.FactsInterval > 0 {
+		go w.reportFactsLoop()
+	}
+
 	// run converge loop after first start
 	go w.converge()
 
+	// revert drift periodically
+	if w.flags.ReconvergeInterval > 0 {
+		go w.reconvergeLoop(maintenanceWindows)
+	}
+
 	// start watching for API server events that trigger applies
 	w.watchForNotifications()
 
+	// start watching for commands to run
+	w.watchForCommands()
+
 	// Wait for all goroutines to exit
 	<-w.stopCh
 	w.convergeWG.Wait()
@@ -132,6 +210,45 @@ func (w *Wing) Run(args []string) error {
 	return err
 }
 
+func (w *Wing) restConfig(t tags.Tags) (*rest.Config, error) {
+	if w.flags.CAFile == "" {
+		w.log.Warn("no CA specified, not verifying the wing server and not using client certificate authentication")
+		return &rest.Config{
+			Host: w.flags.ServerURL,
+			TLSClientConfig: rest.TLSClientConfig{
+				Insecure: true,
+			},
+		}, nil
+	}
+
+	certFile, keyFile, err := w.ensureClientCertificate(t)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.loadClientCertificate(certFile, keyFile); err != nil {
+		return nil, err
+	}
+
+	pool, err := w.caPool()
+	if err != nil {
+		return nil, err
+	}
+
+	// the client certificate is looked up on every handshake, so that
+	// renewed certificates are used without restarting
+	return &rest.Config{
+		Host: w.flags.ServerURL,
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSHandshakeTimeout: 10 * time.Second,
+			TLSClientConfig: &tls.Config{
+				RootCAs:              pool,
+				GetClientCertificate: w.getClientCertificate,
+			},
+		},
+	}, nil
+}
+
 func (w *Wing) Must(err error) *Wing {
 	if err != nil {
 		w.log.Fatal(err)
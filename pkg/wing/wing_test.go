@@ -3,12 +3,15 @@ package wing
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -16,11 +19,14 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/hashicorp/go-multierror"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 
+	"github.com/jetstack/tarmak/pkg/apis/wing/v1alpha1"
 	client "github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned"
+	"github.com/jetstack/tarmak/pkg/wing/client/clientset/versioned/scheme"
 	"github.com/jetstack/tarmak/pkg/wing/mocks"
 )
 
@@ -34,6 +40,9 @@ type fakeWing struct {
 	fakeHTTPClient *mocks.MockHTTPClient
 	fakeCommand    *mocks.MockCommand
 
+	// status code of wing server responses
+	apiStatusCode int
+
 	signalCh chan os.Signal
 }
 
@@ -71,6 +80,7 @@ func newFakeWing(t *testing.T) *fakeWing {
 			stopCh:         make(chan struct{}),
 			convergeStopCh: make(chan struct{}),
 		},
+		apiStatusCode: http.StatusNotFound,
 	}
 
 	w.signalCh = make(chan os.Signal, 1)
@@ -84,17 +94,27 @@ func newFakeWing(t *testing.T) *fakeWing {
 	w.fakeHTTPClient = mocks.NewMockHTTPClient(w.ctrl)
 	w.clientset = client.New(w.fakeRest)
 
-	w.fakeHTTPClient.EXPECT().Do(gomock.Any()).AnyTimes().Return(&http.Response{StatusCode: 0, Body: nopCloser{bytes.NewBufferString("")}}, nil)
+	// by default the instance is not registered with the wing server
+	w.fakeHTTPClient.EXPECT().Do(gomock.Any()).AnyTimes().DoAndReturn(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: w.apiStatusCode, Body: nopCloser{bytes.NewBufferString("")}}, nil
+	})
+	w.fakeRest.EXPECT().Get().AnyTimes().DoAndReturn(w.newRequest)
+	w.fakeRest.EXPECT().Post().AnyTimes().DoAndReturn(w.newRequest)
+
+	return w
+}
+
+func (w *fakeWing) newRequest() *rest.Request {
 	contentConfig := rest.ContentConfig{
 		GroupVersion: &schema.GroupVersion{
 			Version: "v1",
 		},
 	}
 
-	request := rest.NewRequest(w.fakeHTTPClient, "verb", nil, "versionedAPIPath", contentConfig, rest.Serializers{}, nil, nil, time.Second)
-	w.fakeRest.EXPECT().Get().AnyTimes().Return(request)
+	codec := scheme.Codecs.LegacyCodec(v1alpha1.SchemeGroupVersion)
+	serializers := rest.Serializers{Encoder: codec, Decoder: codec}
 
-	return w
+	return rest.NewRequest(w.fakeHTTPClient, "verb", nil, "versionedAPIPath", contentConfig, serializers, nil, nil, time.Second)
 }
 
 // this tests when the SIGTERM hits wing when it's currently running puppet
@@ -450,6 +470,123 @@ func TestWing_SIGHUP_puppet_converged(t *testing.T) {
 	}
 }
 
+// Test a manifest is not applied when its hash does not match the requested one
+func TestWing_runPuppet_hash_mismatch(t *testing.T) {
+	w := newFakeWing(t)
+	defer w.ctrl.Finish()
+	defer deleteTmpFiles(t)
+
+	status, err := w.runPuppet(&v1alpha1.InstanceSpecManifest{
+		Hash: "sha256:0000",
+	})
+	if err == nil {
+		t.Fatal("expected error for hash mismatch")
+	}
+	if !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(status.Converge.Hash, "sha256:") || status.Converge.Hash == "sha256:0000" {
+		t.Errorf("expected computed hash in status, got '%s'", status.Converge.Hash)
+	}
+
+	_, err = w.runPuppet(&v1alpha1.InstanceSpecManifest{
+		Hash: "md5:0000",
+	})
+	if err == nil {
+		t.Error("expected error for unsupported hash type")
+	}
+}
+
+// Test a pinned path is used instead of the manifest URL
+func TestWing_runPuppet_pinned_path(t *testing.T) {
+	w := newFakeWing(t)
+	defer w.ctrl.Finish()
+	defer deleteTmpFiles(t)
+
+	pinnedPath := manifestURLgz
+	w.flags.ManifestURL = filepath.Join(os.TempDir(), "does-not-exist")
+
+	hashBytes, err := ioutil.ReadFile(pinnedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := fmt.Sprintf("sha256:%x", sha256.Sum256(hashBytes))
+
+	w.fakeCommand.EXPECT().Start()
+	w.fakeCommand.EXPECT().Wait().Return(nil)
+
+	status, err := w.runPuppet(&v1alpha1.InstanceSpecManifest{
+		Path: pinnedPath,
+		Hash: hash,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp, act := hash, status.Converge.Hash; exp != act {
+		t.Errorf("unexpected hash, exp=%s act=%s", exp, act)
+	}
+}
+
+// Test no manifest is applied, if the instance's spec can't be retrieved
+func TestWing_converge_spec_unavailable(t *testing.T) {
+	w := newFakeWing(t)
+	defer w.ctrl.Finish()
+	defer deleteTmpFiles(t)
+
+	w.apiStatusCode = http.StatusInternalServerError
+
+	// stop retrying after the first attempt
+	close(w.convergeStopCh)
+
+	if _, err := w.convergeSpec(); err == nil {
+		t.Fatal("expected error getting instance spec")
+	}
+
+	// puppet is never started
+	w.converge()
+}
+
+// Test a failing puppet run is not reported as a successful converge
+func TestWing_converge_puppet_failed(t *testing.T) {
+	w := newFakeWing(t)
+	defer w.ctrl.Finish()
+	defer deleteTmpFiles(t)
+
+	process := exec.Command("false")
+	if err := process.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	processErr := process.Wait()
+
+	// stop retrying after the first failed run
+	w.fakeCommand.EXPECT().Start()
+	w.fakeCommand.EXPECT().Wait().Return(processErr).Do(func() {
+		close(w.convergeStopCh)
+	})
+	w.fakeCommand.EXPECT().Process().AnyTimes().Return(nil)
+
+	lastSuccess := float64(time.Now().Add(-time.Hour).Unix())
+	convergeLastSuccess.Set(lastSuccess)
+
+	w.converge()
+
+	metric := new(dto.Metric)
+	if err := convergeLastSuccess.Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if act := metric.GetGauge().GetValue(); act != lastSuccess {
+		t.Errorf("expected last success to stay at %v, got %v", lastSuccess, act)
+	}
+
+	metric = new(dto.Metric)
+	if err := convergeState.WithLabelValues(string(v1alpha1.InstanceManifestStateError)).Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if act := metric.GetGauge().GetValue(); act != 1 {
+		t.Errorf("expected converge state error, got %v", act)
+	}
+}
+
 func createTmpFiles() error {
 	file, err := ioutil.TempFile(os.TempDir(), "manifestURL")
 	if err != nil {
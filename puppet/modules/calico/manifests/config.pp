@@ -4,6 +4,7 @@ class calico::config{
 
   $mtu = $::calico::mtu
   $namespace = $::calico::namespace
+  $networking_backend = $::calico::networking_backend
 
   if $::calico::backend == 'etcd' {
     $etcd_endpoints = $::calico::etcd_endpoints
@@ -25,6 +26,8 @@ class calico::config{
 
   } else {
     $pod_network = $::calico::pod_network
+    # use calico's IPAM if addresses are allocated from custom IP pools
+    $calico_ipam = !empty($::calico::ip_pools)
 
     kubernetes::apply{'calico-config':
       ensure    => 'present',
@@ -1,4 +1,9 @@
 # calico init.pp
+#
+# @param encapsulation Encapsulation of pod traffic between nodes
+# @param ip_pools IP pools (name, cidr, block_size, node_selector, nat_outgoing) replacing the default pool, requires the kubernetes backend
+# @param bgp_peers BGP peers (name, peer_ip, as_number, node_selector), requires the kubernetes backend
+# @param default_deny Deny all pod traffic outside of kube-system not allowed by a network policy, requires the kubernetes backend
 class calico(
   Array[String] $etcd_cluster = $::calico::params::etcd_cluster,
   Integer[1,65535] $etcd_overlay_port = $::calico::params::etcd_overlay_port,
@@ -12,8 +17,18 @@ class calico(
   Optional[Integer] $typha_replicas = undef,
   Optional[String] $pod_network = undef,
   Integer[1000,65535] $mtu = 1480,
+  Enum['ipip', 'vxlan', 'none'] $encapsulation = 'ipip',
+  Array[Hash] $ip_pools = [],
+  Array[Hash] $bgp_peers = [],
+  Boolean $default_deny = false,
 ) inherits ::calico::params
 {
+  # vxlan routes are programmed by felix without running BIRD
+  $networking_backend = $encapsulation ? {
+    'vxlan' => 'vxlan',
+    default => 'bird',
+  }
+
   $path = defined('$::path') ? {
       default => '/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/opt/bin',
       true    => $::path
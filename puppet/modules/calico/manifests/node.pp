@@ -17,6 +17,19 @@ class calico::node (
   $mtu = $::calico::mtu
   $ipv4_pool_cidr = $::calico::pod_network
   $backend = $::calico::backend
+  $encapsulation = $::calico::encapsulation
+  $networking_backend = $::calico::networking_backend
+  # the default pool is only created if no custom IP pools are configured
+  $default_pool = empty($::calico::ip_pools)
+
+  $ipv4_pool_ipip = $encapsulation ? {
+    'ipip'  => $ipv4_pool_ipip_mode,
+    default => 'never',
+  }
+  $ipv4_pool_vxlan = $encapsulation ? {
+    'vxlan' => 'always',
+    default => 'never',
+  }
 
   $typha_enabled  = $::calico::typha_enabled
   $typha_replicas = $::calico::typha_replicas
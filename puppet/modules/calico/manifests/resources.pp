@@ -0,0 +1,54 @@
+# Calico Resources
+#
+# Manages IP pools, BGP peers and the default deny policy as Calico
+# resources, this requires the kubernetes backend.
+class calico::resources {
+  include ::kubernetes
+  include ::calico
+
+  $ip_pools = $::calico::ip_pools
+  $bgp_peers = $::calico::bgp_peers
+  $default_deny = $::calico::default_deny
+  $namespace = $::calico::namespace
+
+  $ipip_mode = $::calico::encapsulation ? {
+    'ipip'  => $::calico::node::ipv4_pool_ipip_mode ? {
+      'cross-subnet' => 'CrossSubnet',
+      'off'          => 'Never',
+      default        => 'Always',
+    },
+    default => 'Never',
+  }
+  $vxlan_mode = $::calico::encapsulation ? {
+    'vxlan' => 'Always',
+    default => 'Never',
+  }
+
+  if $::calico::backend != 'kubernetes' {
+    if !empty($ip_pools) or !empty($bgp_peers) or $default_deny {
+      fail('ip_pools, bgp_peers and default_deny require the kubernetes backend')
+    }
+  } else {
+    $manifests = delete([
+      empty($ip_pools) ? {
+        true    => '',
+        default => template('calico/ip-pools.yaml.erb'),
+      },
+      empty($bgp_peers) ? {
+        true    => '',
+        default => template('calico/bgp-peers.yaml.erb'),
+      },
+      $default_deny ? {
+        true    => template('calico/default-deny.yaml.erb'),
+        default => '',
+      },
+    ], '')
+
+    if !empty($manifests) {
+      kubernetes::apply{'calico-resources':
+        ensure    => 'present',
+        manifests => $manifests,
+      }
+    }
+  }
+}
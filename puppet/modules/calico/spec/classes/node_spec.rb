@@ -34,6 +34,8 @@ describe 'calico::node' do
         #{etcd_cluster}
         #{etcd_tls}
         #{typha_enabled}
+        #{encapsulation}
+        #{ip_pools}
       }
     "
   end
@@ -43,6 +45,8 @@ describe 'calico::node' do
   let(:etcd_cluster) { '' }
   let(:etcd_tls) { '' }
   let(:typha_enabled) { '' }
+  let(:encapsulation) { '' }
+  let(:ip_pools) { '' }
 
   let(:calico_node) do
     catalogue.resource('Kubernetes::Apply', 'calico-node').send(:parameters)[:manifests].join("\n")
@@ -71,6 +75,37 @@ describe 'calico::node' do
     end
   end
 
+  context 'with vxlan encapsulation' do
+    let(:encapsulation) do
+      'encapsulation => \'vxlan\','
+    end
+
+    it 'is valid yaml' do
+      YAML.load(calico_node)
+    end
+
+    it 'uses vxlan instead of ipip' do
+      expect(calico_node).to match(/name: CALICO_IPV4POOL_VXLAN\s+value: "always"/)
+      expect(calico_node).to match(/name: CALICO_IPV4POOL_IPIP\s+value: "never"/)
+      expect(calico_node).to match(/name: FELIX_VXLANMTU\s+value: "1480"/)
+      expect(calico_node).not_to match(/-bird-live/)
+    end
+  end
+
+  context 'with ip pools' do
+    let(:backend) do
+      'backend => \'kubernetes\','
+    end
+    let(:ip_pools) do
+      "ip_pools => [{'name' => 'pool-a', 'cidr' => '10.234.0.0/17'}],"
+    end
+
+    it 'disables the default pool' do
+      expect(calico_node).to match(/name: NO_DEFAULT_POOLS\s+value: "true"/)
+      expect(calico_node).not_to match(/name: CALICO_IPV4POOL_CIDR/)
+    end
+  end
+
   context 'with backend etcd' do
     let(:backend) do
       'backend => \'etcd\','
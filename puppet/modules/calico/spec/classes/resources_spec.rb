@@ -0,0 +1,77 @@
+require 'spec_helper'
+require 'yaml'
+
+describe 'calico::resources' do
+  let(:pre_condition) do
+    "
+      class kubernetes{
+        $_authorization_mode = ['RBAC']
+        $version = '1.16.15'
+      }
+      define kubernetes::apply(
+        Enum['present', 'absent'] $ensure = 'present',
+        $manifests,
+      ){
+        if $manifests and $ensure == 'present' {
+          kubernetes::addon_manager_labels($manifests[0])
+        }
+      }
+      class{'calico':
+        backend => '#{backend}',
+        #{params}
+      }
+      class{'calico::node':
+        ipv4_pool_ipip_mode => 'cross-subnet',
+      }
+    "
+  end
+
+  let(:backend) { 'kubernetes' }
+  let(:params) { '' }
+
+  let(:manifests) do
+    catalogue.resource('Kubernetes::Apply', 'calico-resources').send(:parameters)[:manifests]
+  end
+
+  context 'without resources' do
+    it { should_not contain_kubernetes__apply('calico-resources') }
+  end
+
+  context 'with ip pools, bgp peers and default deny' do
+    let(:params) do
+      "
+        ip_pools     => [{'name' => 'pool-a', 'cidr' => '10.234.0.0/17', 'block_size' => 24, 'nat_outgoing' => true, 'node_selector' => 'all()'}],
+        bgp_peers    => [{'name' => 'rack1-tor', 'peer_ip' => '10.99.0.1', 'as_number' => 64512}],
+        default_deny => true,
+      "
+    end
+
+    it 'is valid yaml' do
+      manifests.each do |manifest|
+        YAML.load_stream(manifest)
+      end
+    end
+
+    it 'has the ip pool' do
+      expect(manifests[0]).to match(%r{cidr: 10.234.0.0/17})
+      expect(manifests[0]).to match(%r{blockSize: 24})
+      expect(manifests[0]).to match(%r{ipipMode: CrossSubnet})
+    end
+
+    it 'has the bgp peer' do
+      expect(manifests[1]).to match(%r{peerIP: 10.99.0.1})
+      expect(manifests[1]).to match(%r{asNumber: 64512})
+    end
+
+    it 'has the default deny policy' do
+      expect(manifests[2]).to match(%r{kind: GlobalNetworkPolicy})
+    end
+  end
+
+  context 'with etcd backend' do
+    let(:backend) { 'etcd' }
+    let(:params) { 'default_deny => true,' }
+
+    it { should compile.and_raise_error(/require the kubernetes backend/) }
+  end
+end
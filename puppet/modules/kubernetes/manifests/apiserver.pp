@@ -4,8 +4,15 @@ class kubernetes::apiserver(
   Optional[Boolean] $audit_enabled = undef,
   Optional[Boolean] $aws_iam_authenticator_init = false,
   String $audit_log_directory = '/var/log/kubernetes',
+  Enum['json', 'legacy'] $audit_log_format = 'json',
+  Optional[Integer] $audit_log_maxage = undef,
   Integer $audit_log_maxbackup = 1,
   Integer $audit_log_maxsize = 100,
+  Optional[String] $audit_policy = undef,
+  Optional[String] $audit_webhook_server = undef,
+  Optional[String] $audit_webhook_ca = undef,
+  Enum['batch', 'blocking'] $audit_webhook_mode = 'batch',
+  String $audit_webhook_initial_backoff = '10s',
   $admission_control = undef,
   $disable_admission_control = [],
   Hash[String,Boolean] $feature_gates = {},
@@ -265,6 +272,12 @@ class kubernetes::apiserver(
       notify => Service["${service_name}.service"],
     }
 
+    if $audit_policy == undef {
+      $_audit_policy = file('kubernetes/audit-policy.yaml')
+    } else {
+      $_audit_policy = $audit_policy
+    }
+
     $audit_policy_file = "${::kubernetes::config_dir}/audit-policy.yaml"
     if $::kubernetes::use_hyperkube {
       file{$audit_policy_file:
@@ -272,7 +285,7 @@ class kubernetes::apiserver(
         mode    => '0640',
         owner   => 'root',
         group   => $::kubernetes::params::group,
-        content => file('kubernetes/audit-policy.yaml'),
+        content => $_audit_policy,
         require => Kubernetes::Symlink[$command_name],
         notify  => Service["${service_name}.service"],
       }
@@ -282,11 +295,39 @@ class kubernetes::apiserver(
         mode    => '0640',
         owner   => 'root',
         group   => $::kubernetes::params::group,
-        content => file('kubernetes/audit-policy.yaml'),
+        content => $_audit_policy,
         #require => Kubernetes::Symlink[$command_name],
         notify  => Service["${service_name}.service"],
       }
     }
+
+    $audit_webhook_config_file = "${::kubernetes::config_dir}/audit-webhook-kubeconfig.yaml"
+    if $audit_webhook_server != undef {
+      $audit_webhook_ca_file = "${::kubernetes::config_dir}/audit-webhook-ca.pem"
+      if $audit_webhook_ca != undef {
+        file{$audit_webhook_ca_file:
+          ensure  => file,
+          mode    => '0640',
+          owner   => 'root',
+          group   => $::kubernetes::params::group,
+          content => $audit_webhook_ca,
+          notify  => Service["${service_name}.service"],
+        }
+      }
+
+      file{$audit_webhook_config_file:
+        ensure  => file,
+        mode    => '0640',
+        owner   => 'root',
+        group   => $::kubernetes::params::group,
+        content => template('kubernetes/audit-webhook-kubeconfig.yaml.erb'),
+        notify  => Service["${service_name}.service"],
+      }
+    } else {
+      file{$audit_webhook_config_file:
+        ensure => absent,
+      }
+    }
   }
 
   if $::kubernetes::use_hyperkube {
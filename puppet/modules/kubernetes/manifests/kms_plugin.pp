@@ -0,0 +1,68 @@
+# class kubernetes::kms_plugin
+#
+# Runs the AWS KMS plugin (aws-encryption-provider), which the API server uses
+# for envelope encryption of resources at rest
+class kubernetes::kms_plugin(
+  String $key_arn,
+  String $region,
+  String $version = $::kubernetes::params::aws_encryption_provider_version,
+  String $download_url = $::kubernetes::params::aws_encryption_provider_download_url,
+  String $socket_path = '/var/run/kmsplugin/socket.sock',
+  Array[String] $systemd_wants = [],
+  Array[String] $systemd_requires = [],
+  Array[String] $systemd_after = [],
+  Array[String] $systemd_before = [],
+) inherits ::kubernetes::params {
+  require ::kubernetes
+
+  $service_name = 'kube-kms-plugin'
+
+  $_systemd_wants = $systemd_wants
+  $_systemd_requires = $systemd_requires
+  $_systemd_after = ['network.target'] + $systemd_after
+  $_systemd_before = ['kube-apiserver.service'] + $systemd_before
+
+  $_download_url = regsubst(
+    $download_url,
+    '#VERSION#',
+    $version,
+    'G'
+  )
+
+  $_dest_dir = "${::kubernetes::dest_dir}/aws-encryption-provider-${version}"
+  $plugin_path = "${_dest_dir}/aws-encryption-provider"
+  $socket_dir = dirname($socket_path)
+
+  file { $_dest_dir:
+      ensure => directory,
+      mode   => '0755',
+  }
+  -> exec {"aws-encryption-provider-${version}-download":
+      command => "curl -sL ${_download_url} -o ${plugin_path}",
+      creates => $plugin_path,
+      path    => ['/usr/bin', '/bin'],
+  }
+  -> file {$plugin_path:
+      ensure => file,
+      mode   => '0755',
+      owner  => 'root',
+      group  => 'root',
+  }
+  -> file{"${::kubernetes::systemd_dir}/${service_name}.service":
+      ensure  => file,
+      mode    => '0644',
+      owner   => 'root',
+      group   => 'root',
+      content => template("kubernetes/${service_name}.service.erb"),
+      notify  => Service["${service_name}.service"],
+  }
+  ~> exec { "${service_name}-daemon-reload":
+      command     => 'systemctl daemon-reload',
+      path        => $::kubernetes::path,
+      refreshonly => true,
+  }
+  -> service{ "${service_name}.service":
+      ensure => running,
+      enable => true,
+  }
+}
@@ -155,6 +155,47 @@ describe 'kubernetes::apiserver' do
     it { should contain_class('kubernetes::aws_iam_authenticator_init').with('auth_token_webhook_file' => '/foo/bar/baz')}
   end
 
+  context 'audit' do
+    context 'default' do
+      it do
+        should contain_file(service_file).with_content(%r{--audit-log-format=json})
+        should contain_file('/etc/kubernetes/audit-policy.yaml').with_content(%r{kind: Policy})
+        should contain_file('/etc/kubernetes/audit-webhook-kubeconfig.yaml').with_ensure('absent')
+        should_not contain_file(service_file).with_content(%r{--audit-webhook-config-file})
+        should_not contain_file(service_file).with_content(%r{--audit-log-maxage})
+      end
+    end
+
+    context 'custom policy and rotation' do
+      let(:params) { {
+        'audit_policy' => "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata\n",
+        'audit_log_maxage' => 30,
+        'audit_log_maxbackup' => 5,
+      } }
+      it do
+        should contain_file('/etc/kubernetes/audit-policy.yaml').with_content(%r{level: Metadata})
+        should contain_file(service_file).with_content(%r{--audit-log-maxage=30})
+        should contain_file(service_file).with_content(%r{--audit-log-maxbackup=5})
+      end
+    end
+
+    context 'webhook backend' do
+      let(:params) { {
+        'audit_webhook_server' => 'https://audit.example.com/events',
+        'audit_webhook_ca' => 'my-ca',
+        'audit_webhook_mode' => 'blocking',
+      } }
+      it do
+        should contain_file('/etc/kubernetes/audit-webhook-ca.pem').with_content('my-ca')
+        should contain_file('/etc/kubernetes/audit-webhook-kubeconfig.yaml').with_content(%r{server: https://audit.example.com/events})
+        should contain_file('/etc/kubernetes/audit-webhook-kubeconfig.yaml').with_content(%r{certificate-authority: /etc/kubernetes/audit-webhook-ca.pem})
+        should contain_file(service_file).with_content(%r{--audit-webhook-config-file=/etc/kubernetes/audit-webhook-kubeconfig.yaml})
+        should contain_file(service_file).with_content(%r{--audit-webhook-mode=blocking})
+        should contain_file(service_file).with_content(%r{--audit-webhook-initial-backoff=10s})
+      end
+    end
+  end
+
   context 'runtime_config' do
     let :kubernetes_version do
       '1.6.2'
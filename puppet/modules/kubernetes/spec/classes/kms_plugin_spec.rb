@@ -0,0 +1,24 @@
+require 'spec_helper'
+
+describe 'kubernetes::kms_plugin' do
+  let :service_file do
+    '/etc/systemd/system/kube-kms-plugin.service'
+  end
+
+  let(:params) { {
+    'key_arn' => 'arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab',
+    'region'  => 'eu-west-1',
+  } }
+
+  context 'with default values for all other parameters' do
+    it { should contain_class('kubernetes::kms_plugin') }
+    it { should contain_exec('aws-encryption-provider-0.2.0-download').with_command(/#{Regexp.escape('/releases/download/v0.2.0/aws-encryption-provider_0.2.0_linux_amd64')}/) }
+    it do
+      should contain_file(service_file).with_content(/#{Regexp.escape('--key=arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab')}/)
+      should contain_file(service_file).with_content(/#{Regexp.escape('--region=eu-west-1')}/)
+      should contain_file(service_file).with_content(/#{Regexp.escape('--listen=/var/run/kmsplugin/socket.sock')}/)
+      should contain_file(service_file).with_content(/Before=kube-apiserver.service/)
+    end
+    it { should contain_service('kube-kms-plugin.service').with_ensure('running') }
+  end
+end
@@ -0,0 +1,32 @@
+class kubernetes_addons::aws_vpc_cni(
+  String $image='',
+  String $version='1.6.3',
+  Optional[Integer[0]] $warm_ip_target=undef,
+  Integer $mtu=9001,
+  String $log_level='INFO',
+  String $request_cpu='10m',
+  String $request_mem='64Mi',
+) inherits ::kubernetes_addons::params {
+  require ::kubernetes
+
+  # the image is hosted in the ECR registry of the region
+  if $image == '' {
+    $_image = "602401143452.dkr.ecr.${aws_region}.amazonaws.com/amazon-k8s-cni"
+  } else {
+    $_image = $image
+  }
+
+  $authorization_mode = $::kubernetes::_authorization_mode
+  if member($authorization_mode, 'RBAC'){
+    $rbac_enabled = true
+  } else {
+    $rbac_enabled = false
+  }
+
+  kubernetes::apply{'aws-vpc-cni':
+    manifests => [
+      template('kubernetes_addons/aws-vpc-cni-daemonset.yaml.erb'),
+      template('kubernetes_addons/aws-vpc-cni-rbac.yaml.erb'),
+    ],
+  }
+}
@@ -0,0 +1,45 @@
+class kubernetes_addons::cert_manager(
+  String $image='quay.io/jetstack/cert-manager-controller',
+  String $version='0.8.1',
+  String $namespace='cert-manager',
+  String $acme_email='',
+  String $ingress_class='nginx',
+  String $request_cpu='10m',
+  String $request_mem='32Mi',
+  String $limit_cpu='200m',
+  String $limit_mem='256Mi',
+  Enum['present', 'absent'] $ensure = 'present',
+) inherits ::kubernetes_addons::params {
+  require ::kubernetes
+
+  $authorization_mode = $::kubernetes::_authorization_mode
+  if member($authorization_mode, 'RBAC'){
+    $rbac_enabled = true
+  } else {
+    $rbac_enabled = false
+  }
+
+  kubernetes::apply{'cert-manager':
+    ensure    => $ensure,
+    manifests => [
+      template('kubernetes_addons/cert-manager-crds.yaml.erb'),
+      template('kubernetes_addons/cert-manager-deployment.yaml.erb'),
+      template('kubernetes_addons/cert-manager-rbac.yaml.erb'),
+    ],
+  }
+
+  # issuers can only be created once the custom resources are known
+  if $ensure == 'present' and $acme_email != '' {
+    $issuers_ensure = 'present'
+  } else {
+    $issuers_ensure = 'absent'
+  }
+
+  kubernetes::apply{'cert-manager-issuers':
+    ensure    => $issuers_ensure,
+    manifests => [
+      template('kubernetes_addons/cert-manager-issuers.yaml.erb'),
+    ],
+    require   => Kubernetes::Apply['cert-manager'],
+  }
+}
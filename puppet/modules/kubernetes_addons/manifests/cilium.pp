@@ -0,0 +1,30 @@
+class kubernetes_addons::cilium(
+  Optional[String] $pod_network=undef,
+  String $image='docker.io/cilium/cilium',
+  String $operator_image='docker.io/cilium/operator',
+  String $version='1.6.12',
+  Enum['vxlan', 'geneve'] $tunnel='vxlan',
+  String $request_cpu='100m',
+  String $request_mem='128Mi',
+  String $limit_cpu='',
+  String $limit_mem='512Mi',
+) inherits ::kubernetes_addons::params {
+  require ::kubernetes
+
+  $_pod_network = pick($pod_network, $::kubernetes::pod_network)
+
+  $authorization_mode = $::kubernetes::_authorization_mode
+  if member($authorization_mode, 'RBAC'){
+    $rbac_enabled = true
+  } else {
+    $rbac_enabled = false
+  }
+
+  kubernetes::apply{'cilium':
+    manifests => [
+      template('kubernetes_addons/cilium-daemonset.yaml.erb'),
+      template('kubernetes_addons/cilium-operator-deployment.yaml.erb'),
+      template('kubernetes_addons/cilium-rbac.yaml.erb'),
+    ],
+  }
+}
@@ -0,0 +1,36 @@
+class kubernetes_addons::kiam(
+  String $base_role_arn='',
+  String $namespace='kube-system',
+  String $image='quay.io/uswitch/kiam',
+  String $version='3.6',
+  String $host_interface='cali+',
+  String $server_request_cpu='50m',
+  String $server_request_mem='64Mi',
+  String $server_limit_cpu='200m',
+  String $server_limit_mem='256Mi',
+  String $agent_request_cpu='10m',
+  String $agent_request_mem='32Mi',
+  String $agent_limit_cpu='100m',
+  String $agent_limit_mem='128Mi',
+  Enum['present', 'absent'] $ensure = 'present',
+) inherits ::kubernetes_addons::params {
+  require ::kubernetes
+
+  $authorization_mode = $::kubernetes::_authorization_mode
+  if member($authorization_mode, 'RBAC'){
+    $rbac_enabled = true
+  } else {
+    $rbac_enabled = false
+  }
+
+  # the TLS certificates of server and agent are issued by cert-manager
+  kubernetes::apply{'kiam':
+    ensure    => $ensure,
+    manifests => [
+      template('kubernetes_addons/kiam-certificates.yaml.erb'),
+      template('kubernetes_addons/kiam-server.yaml.erb'),
+      template('kubernetes_addons/kiam-agent.yaml.erb'),
+      template('kubernetes_addons/kiam-rbac.yaml.erb'),
+    ],
+  }
+}
@@ -7,6 +7,7 @@ class kubernetes_addons::kube2iam(
   String $request_mem='64Mi',
   String $limit_cpu='',
   String $limit_mem='256Mi',
+  String $host_interface='cali+',
   Enum['present', 'absent'] $ensure = 'present',
 ) {
   require ::kubernetes
@@ -11,6 +11,7 @@ class kubernetes_addons::metrics_server(
   String $nanny_request_mem='50Mi',
   String $nanny_limit_cpu='100m',
   String $nanny_limit_mem='300Mi',
+  Enum['present', 'absent'] $ensure = 'present',
 ) inherits ::kubernetes_addons::params {
   require ::kubernetes
 
@@ -44,13 +45,13 @@ class kubernetes_addons::metrics_server(
   }
 
   if versioncmp($::kubernetes::version, '1.7.0') >= 0 {
-    $ensure = 'present'
+    $_ensure = $ensure
   } else {
-    $ensure = 'absent'
+    $_ensure = 'absent'
   }
 
   kubernetes::apply{'metrics-server':
-    ensure    => $ensure,
+    ensure    => $_ensure,
     manifests => [
       template('kubernetes_addons/metrics-server.yaml.erb'),
       template('kubernetes_addons/metrics-server-rbac.yaml.erb'),
@@ -8,6 +8,11 @@ class kubernetes_addons::nginx_ingress(
   $namespace=$::kubernetes_addons::params::namespace,
   $replicas=undef,
   $host_port=false,
+  Enum['LoadBalancer', 'NodePort'] $service_type = 'LoadBalancer',
+  Hash[String, String] $service_annotations = {},
+  Hash[String, String] $node_selector = {},
+  Integer $node_port_http = 32080,
+  Integer $node_port_https = 32443,
   Enum['present', 'absent'] $ensure = 'present',
 ) inherits ::kubernetes_addons::params {
   require ::kubernetes
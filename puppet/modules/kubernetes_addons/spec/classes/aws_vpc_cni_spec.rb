@@ -0,0 +1,45 @@
+require 'spec_helper'
+describe 'kubernetes_addons::aws_vpc_cni' do
+  let(:pre_condition) do
+    "
+      class kubernetes{
+        $_authorization_mode = ['RBAC']
+        $version = '1.16.15'
+        $pod_network = '10.234.0.0/16'
+      }
+      define kubernetes::apply(
+        Enum['present', 'absent'] $ensure = 'present',
+        $manifests,
+      ){
+        if $manifests and $ensure == 'present' {
+          kubernetes::addon_manager_labels($manifests[0])
+        }
+      }
+    "
+  end
+
+  let(:manifests) do
+    catalogue.resource('Kubernetes::Apply', 'aws-vpc-cni').send(:parameters)[:manifests]
+  end
+
+  context 'with defaults' do
+    it 'be valid yaml' do
+      manifests.each do |manifest|
+        YAML.parse manifest
+      end
+    end
+
+    it 'have image set' do
+      expect(manifests[0]).to match(%{^[-\s].*image: [^:]+:[^:]+$})
+    end
+  end
+
+  context 'with warm_ip_target' do
+    let(:params) { {'warm_ip_target' => 5, 'image' => 'example.com/amazon-k8s-cni'} }
+
+    it 'configures the warm pool' do
+      expect(manifests[0]).to match(%r{name: WARM_IP_TARGET\n\s+value: "5"})
+      expect(manifests[0]).to match(%r{image: example.com/amazon-k8s-cni:v1.6.3})
+    end
+  end
+end
@@ -0,0 +1,59 @@
+require 'spec_helper'
+describe 'kubernetes_addons::cert_manager' do
+  let(:pre_condition) do
+    "
+      class kubernetes{
+        $_authorization_mode = ['RBAC']
+        $version = '1.16.15'
+      }
+      define kubernetes::apply(
+        Enum['present', 'absent'] $ensure = 'present',
+        $manifests,
+      ){
+        if $manifests and $ensure == 'present' {
+          kubernetes::addon_manager_labels($manifests[0])
+        }
+      }
+    "
+  end
+
+  let(:manifests) do
+    catalogue.resource('Kubernetes::Apply', 'cert-manager').send(:parameters)[:manifests]
+  end
+
+  let(:issuers) do
+    catalogue.resource('Kubernetes::Apply', 'cert-manager-issuers')
+  end
+
+  context 'with defaults' do
+    it 'be valid yaml' do
+      manifests.each do |manifest|
+        YAML.parse manifest
+      end
+    end
+
+    it 'have image set' do
+      expect(manifests[1]).to match(%{^[-\s].*image: [^:]+:[^:]+$})
+    end
+
+    it 'does not create issuers' do
+      expect(issuers.send(:parameters)[:ensure]).to eq('absent')
+    end
+  end
+
+  context 'with acme email' do
+    let(:params) { {'acme_email' => 'ops@example.com'} }
+
+    it 'creates letsencrypt issuers' do
+      expect(issuers.send(:parameters)[:ensure]).to eq('present')
+      manifest = issuers.send(:parameters)[:manifests][0]
+      expect(manifest).to match(%r{^  name: letsencrypt-staging$})
+      expect(manifest).to match(%r{^  name: letsencrypt-prod$})
+      expect(manifest).to match(%r{^    email: ops@example.com$})
+    end
+
+    it 'defaults to the production issuer' do
+      expect(manifests[1]).to match(%r{--default-issuer-name=letsencrypt-prod})
+    end
+  end
+end
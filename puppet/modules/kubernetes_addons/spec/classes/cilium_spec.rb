@@ -0,0 +1,45 @@
+require 'spec_helper'
+describe 'kubernetes_addons::cilium' do
+  let(:pre_condition) do
+    "
+      class kubernetes{
+        $_authorization_mode = ['RBAC']
+        $version = '1.16.15'
+        $pod_network = '10.234.0.0/16'
+      }
+      define kubernetes::apply(
+        Enum['present', 'absent'] $ensure = 'present',
+        $manifests,
+      ){
+        if $manifests and $ensure == 'present' {
+          kubernetes::addon_manager_labels($manifests[0])
+        }
+      }
+    "
+  end
+
+  let(:manifests) do
+    catalogue.resource('Kubernetes::Apply', 'cilium').send(:parameters)[:manifests]
+  end
+
+  context 'with defaults' do
+    it 'be valid yaml' do
+      manifests.each do |manifest|
+        YAML.parse manifest
+      end
+    end
+
+    it 'have image set' do
+      expect(manifests[0]).to match(%{^[-\s].*image: [^:]+:[^:]+$})
+    end
+  end
+
+  context 'with geneve tunnel' do
+    let(:params) { {'tunnel' => 'geneve'} }
+
+    it 'configures the tunnel' do
+      expect(manifests[0]).to match(%r{^  tunnel: geneve$})
+      expect(manifests[0]).to match(%r{^  ipv4-range: "10.234.0.0/16"$})
+    end
+  end
+end
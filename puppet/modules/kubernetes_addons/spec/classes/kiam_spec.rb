@@ -0,0 +1,58 @@
+require 'spec_helper'
+describe 'kubernetes_addons::kiam' do
+  let(:pre_condition) do
+    "
+      class kubernetes{
+        $_authorization_mode = ['RBAC']
+        $version = '1.16.15'
+      }
+      define kubernetes::apply(
+        Enum['present', 'absent'] $ensure = 'present',
+        $manifests,
+      ){
+        if $manifests and $ensure == 'present' {
+          kubernetes::addon_manager_labels($manifests[0])
+        }
+      }
+    "
+  end
+
+  let(:manifests) do
+    catalogue.resource('Kubernetes::Apply', 'kiam').send(:parameters)[:manifests]
+  end
+
+  context 'with defaults' do
+    it 'be valid yaml' do
+      manifests.each do |manifest|
+        YAML.parse manifest
+      end
+    end
+
+    it 'issues certificates' do
+      expect(manifests[0]).to match(%r{secretName: kiam-server-tls})
+      expect(manifests[0]).to match(%r{secretName: kiam-agent-tls})
+    end
+
+    it 'have image set' do
+      expect(manifests[1]).to match(%{^[-\s].*image: [^:]+:[^:]+$})
+      expect(manifests[2]).to match(%{^[-\s].*image: [^:]+:[^:]+$})
+    end
+
+    it 'runs the server on the masters' do
+      expect(manifests[1]).to match(%r{^        role: master$})
+    end
+
+    it 'runs the agent on the workers' do
+      expect(manifests[2]).to match(%r{^        role: worker$})
+      expect(manifests[2]).to match(%r{--host-interface=cali\+})
+    end
+  end
+
+  context 'with base role arn' do
+    let(:params) { {'base_role_arn' => 'arn:aws:iam::123456789012:role/'} }
+
+    it 'configures the server' do
+      expect(manifests[1]).to match(%r{--role-base-arn=arn:aws:iam::123456789012:role/})
+    end
+  end
+end
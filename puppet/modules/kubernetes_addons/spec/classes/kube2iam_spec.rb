@@ -37,4 +37,13 @@ describe 'kubernetes_addons::kube2iam' do
       expect(manifests[0]).to match(%{^[-\s].*memory: "?[0-9]"?+})
     end
   end
+
+  context 'with host interface' do
+    let(:params) { {'host_interface' => 'lxc+', 'base_role_arn' => 'arn:aws:iam::123456789012:role/'} }
+
+    it 'intercepts metadata requests of the interface' do
+      expect(manifests[0]).to match(%r{--host-interface=lxc\+})
+      expect(manifests[0]).to match(%r{--base-role-arn=arn:aws:iam::123456789012:role/})
+    end
+  end
 end
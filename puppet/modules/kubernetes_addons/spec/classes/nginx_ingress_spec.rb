@@ -41,6 +41,11 @@ describe 'kubernetes_addons::nginx_ingress' do
       expect(manifests[1]).to match(%{^[-\s].*memory: [0-9]+})
     end
 
+    it 'uses a load balancer service' do
+      expect(manifests[0]).to match(%r{^  type: LoadBalancer$})
+      expect(manifests[0]).not_to match(%r{annotations:})
+    end
+
     context 'minikube tests' do
       after(:each) do
           kubectl_delete(default_backend_manifests) if @minikube_cleanup
@@ -72,4 +77,34 @@ describe 'kubernetes_addons::nginx_ingress' do
       end
     end
   end
+
+  context 'with image and version' do
+    let(:params) { {'image' => 'quay.io/kubernetes-ingress-controller/nginx-ingress-controller', 'version' => '0.26.1'} }
+
+    it 'uses the image' do
+      expect(manifests[1]).to match(%r{image: quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.26.1$})
+    end
+  end
+
+  context 'with node port service and node selector' do
+    let(:params) { {'service_type' => 'NodePort', 'node_selector' => {'tarmak.io/ingress' => 'true'}} }
+
+    it 'exposes http and https on the node ports' do
+      expect(manifests[0]).to match(%r{^  type: NodePort$})
+      expect(manifests[0]).to match(%r{^    nodePort: 32080$})
+      expect(manifests[0]).to match(%r{^    nodePort: 32443$})
+    end
+
+    it 'selects the nodes' do
+      expect(manifests[1]).to match(%r{^      nodeSelector:\n        tarmak.io/ingress: "true"$})
+    end
+  end
+
+  context 'with service annotations' do
+    let(:params) { {'service_annotations' => {'service.beta.kubernetes.io/aws-load-balancer-type' => 'nlb'}} }
+
+    it 'annotates the service' do
+      expect(manifests[0]).to match(%r{^    service.beta.kubernetes.io/aws-load-balancer-type: "nlb"$})
+    end
+  end
 end
@@ -53,7 +53,7 @@ class prometheus::blackbox_exporter_etcd (
       }
     }
 
-    if $::tarmak::calico_backend != 'kubernetes' {
+    if $::tarmak::etcd_overlay_enabled {
       prometheus::scrape_config { 'etcd-overlay':
         order  =>  160,
         config => {
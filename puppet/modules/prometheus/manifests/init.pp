@@ -26,12 +26,14 @@ class prometheus(
 
       include ::prometheus::kube_state_metrics
       include ::prometheus::blackbox_exporter
+      include ::prometheus::wing
     }
 
     if $mode == 'ExternalScrapeTargetsOnly' {
       include ::prometheus::server
       include ::prometheus::blackbox_exporter_etcd
       include ::prometheus::node_exporter
+      include ::prometheus::wing
     }
   }
 
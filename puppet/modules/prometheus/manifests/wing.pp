@@ -0,0 +1,106 @@
+# Sets up scrapes and rules for the metrics of the wing agents and the wing
+# server running on the bastion
+class prometheus::wing (
+  Integer[1025,65535] $port = 9102,
+  Optional[String] $server = undef,
+)
+{
+  include ::prometheus
+
+  if $::prometheus::role == 'master' {
+    include ::prometheus::server
+    $kubernetes_token_file = $::prometheus::server::kubernetes_token_file
+    $kubernetes_ca_file = $::prometheus::server::kubernetes_ca_file
+
+    if $::prometheus::mode == 'Full' {
+      $node_ensure = $::prometheus::ensure
+    } else {
+      $node_ensure = 'absent'
+    }
+
+    # scrape wing agents running on every kubernetes node (through api proxy)
+    prometheus::scrape_config { 'kubernetes-nodes-wing':
+      ensure => $node_ensure,
+      order  =>  170,
+      config => {
+        'kubernetes_sd_configs' => [{
+          'role' => 'node',
+        }],
+        'tls_config'            => {
+          'ca_file' => $kubernetes_ca_file,
+        },
+        'bearer_token_file'     => $kubernetes_token_file,
+        'scheme'                => 'https',
+        'relabel_configs'       => [{
+          'action' => 'labelmap',
+          'regex'  => '__meta_kubernetes_node_label_(.+)',
+          },{
+            'target_label' => '__address__',
+            'replacement'  => 'kubernetes.default.svc:443',
+            }, {
+              'source_labels' => ['__meta_kubernetes_node_name'],
+              'regex'         => '(.+)',
+              'target_label'  => '__metrics_path__',
+              'replacement'   => "/api/v1/nodes/\${1}:${port}/proxy/metrics",
+          }],
+      }
+    }
+
+    # scrape wing agents running on etcd nodes
+    prometheus::scrape_config { 'etcd-nodes-wing':
+      order  =>  175,
+      config => {
+        'dns_sd_configs'  => [{
+          'names' => $tarmak::etcd_cluster_exporters,
+        }],
+        'relabel_configs' => [{
+          'source_labels' => ['__address__'],
+          'regex'         => '(.+):(.+)',
+          'target_label'  => '__address__',
+          'replacement'   => "\${1}:${port}",
+        }],
+      }
+    }
+
+    if $server != undef {
+      prometheus::scrape_config { 'wing-server':
+        order  =>  180,
+        config => {
+          'static_configs' => [{
+            'targets' => [$server],
+          }],
+        }
+      }
+    }
+
+    prometheus::rule { 'WingConvergeFailed':
+      expr        => 'wing_instances{state="error"} > 0',
+      for         => '5m',
+      summary     => '{{$labels.cluster}}: Instances failed to converge',
+      description => '{{$labels.cluster}}: {{$value}} instances of pool {{$labels.instance_pool}} failed to converge',
+    }
+
+    # wing retries failed puppet runs for up to 30 minutes, every run updates
+    # the instance's status
+    prometheus::rule { 'WingConvergeStuck':
+      expr        => '(time() - wing_instance_last_update_timestamp_seconds{state="converging"}) > 3600',
+      for         => '5m',
+      summary     => '{{$labels.instance_id}}: Converge is stuck',
+      description => '{{$labels.instance_id}}: Instance of pool {{$labels.instance_pool}} has not reported any converge progress for {{ $value }} seconds',
+    }
+
+    prometheus::rule { 'WingPuppetRunFailed':
+      expr        => 'wing_puppet_run_exit_code >= 4',
+      for         => '15m',
+      summary     => '{{$labels.instance}}: Puppet run failed',
+      description => '{{$labels.instance}}: The last puppet run failed with exit code {{ $value }}',
+    }
+
+    prometheus::rule { 'WingPuppetResourcesFailed':
+      expr        => 'wing_puppet_resources{state="failed"} > 0',
+      for         => '15m',
+      summary     => '{{$labels.instance}}: Puppet resources failed',
+      description => '{{$labels.instance}}: {{ $value }} resources failed during the last puppet run',
+    }
+  }
+}
@@ -17,6 +17,7 @@ describe 'prometheus' do
     'include prometheus::node_exporter',
     'include prometheus::blackbox_exporter_etcd',
     'include prometheus::kube_state_metrics',
+    'include prometheus::wing',
   ]}
 
   let :rules_file do
@@ -37,6 +38,7 @@ describe 'prometheus' do
       expect(rules_manifest).to match(/ScrapeEndpointDown/)
       expect(rules_manifest).to match(/NodeHighCPUUsage/)
       expect(rules_manifest).to match(/EtcdNoLeader/)
+      expect(rules_manifest).to match(/WingConvergeFailed/)
       expect(rules_manifest).to match(/KubernetesPodUnready/)
     end
 
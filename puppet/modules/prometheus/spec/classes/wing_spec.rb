@@ -0,0 +1,52 @@
+require 'spec_helper'
+
+describe 'prometheus::wing' do
+  context 'on etcd node' do
+    let(:pre_condition) {[
+      'class tarmak {',
+      "  $role = 'etcd'",
+      '  $etcd_k8s_main_client_port = 1234',
+      '  $etcd_k8s_events_client_port = 1235',
+      '  $etcd_overlay_client_port = 1236',
+      "  $etcd_cluster_exporters = ['etcd-exporters.example.tarmak.local']",
+      '}',
+      'include tarmak',
+    ]}
+
+    it { should contain_class('prometheus') }
+    it { should_not contain_prometheus__scrape_config('etcd-nodes-wing') }
+  end
+
+  context 'on master node' do
+    let(:pre_condition) {[
+      'class tarmak {',
+      "  $role = 'master'",
+      '  $etcd_k8s_main_client_port = 1234',
+      '  $etcd_k8s_events_client_port = 1235',
+      '  $etcd_overlay_client_port = 1236',
+      "  $etcd_cluster_exporters = ['etcd-exporters.example.tarmak.local']",
+      '}',
+      'include tarmak',
+      'class kubernetes::apiserver{}',
+      'require kubernetes::apiserver',
+    ]}
+
+    it { should contain_class('prometheus::server') }
+    it { should contain_prometheus__scrape_config('kubernetes-nodes-wing') }
+    it { should contain_prometheus__scrape_config('etcd-nodes-wing') }
+    it { should_not contain_prometheus__scrape_config('wing-server') }
+    it { should contain_prometheus__rule('WingConvergeFailed') }
+
+    context 'with wing server' do
+      let :params do
+        { :server => 'bastion.env.tarmak.local:9102' }
+      end
+
+      it 'should scrape the wing server' do
+        should contain_prometheus__scrape_config('wing-server').with_config(
+          'static_configs' => [{ 'targets' => ['bastion.env.tarmak.local:9102'] }],
+        )
+      end
+    end
+  end
+end
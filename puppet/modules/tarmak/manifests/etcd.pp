@@ -58,7 +58,7 @@ class tarmak::etcd(
     ],
   }
 
-  if $::tarmak::calico_backend == 'kubernetes' {
+  if ! $::tarmak::etcd_overlay_enabled {
     $overlay_service_ensure = 'stopped'
     $overlay_service_enable = false
     $overlay_file_ensure = 'absent'
@@ -19,6 +19,7 @@
 # @param kubernetes_ca_name Name of the PKI resource in Vault for main Kubernetes CA
 # @param kubernetes_api_proxy_ca_name Name of the PKI resource in Vault for the API server proxy
 # @param kubernetes_api_aggregation Enable API aggregation for Kubernetes, defaults to true for versions 1.7+
+# @param kubernetes_network_provider Network plugin providing the pod network (calico, cilium or aws-vpc-cni)
 class tarmak (
   String $service_ensure = 'running',
   String $dest_dir = '/opt',
@@ -72,10 +73,14 @@ class tarmak (
   String $helper_path = $tarmak::params::helper_path,
   String $systemd_dir = '/etc/systemd/system',
   Array[Hash] $fluent_bit_configs = $tarmak::params::fluent_bit_configs,
+  Enum['calico', 'cilium', 'aws-vpc-cni'] $kubernetes_network_provider = 'calico',
   Enum['etcd', 'kubernetes'] $calico_backend = 'etcd',
 ) inherits ::tarmak::params {
   $ipaddress = $::ipaddress
 
+  # the overlay etcd cluster is only used by calico's etcd backend
+  $etcd_overlay_enabled = $kubernetes_network_provider == 'calico' and $calico_backend == 'etcd'
+
   # decide if API aggregation should be enabled
   if $kubernetes_api_aggregation == undef {
     # enable after 1.7
@@ -3,6 +3,7 @@ class tarmak::master(
   $disable_proxy = true,
   Array[String] $apiserver_additional_san_domains = [],
   Array[String] $apiserver_additional_san_ips = [],
+  Boolean $encryption_kms_plugin = false,
 ){
   include ::tarmak
   include ::vault_client
@@ -39,6 +40,13 @@ class tarmak::master(
     uid         => $::tarmak::kubernetes_uid,
   }
 
+  if $encryption_kms_plugin {
+    include ::kubernetes::kms_plugin
+    $encryption_dependencies = ['kube-kms-plugin.service']
+  } else {
+    $encryption_dependencies = []
+  }
+
   $controller_manager_base_path = "${::tarmak::kubernetes_ssl_dir}/kube-controller-manager"
   vault_client::cert_service { 'kube-controller-manager':
     base_path   => $controller_manager_base_path,
@@ -91,12 +99,12 @@ class tarmak::master(
         "-${::tarmak::systemctl_path} --no-block try-restart kube-apiserver.service"
       ],
     }
-    $apiserver_dependencies = $apiserver_dependencies_base + 'kube-apiserver-proxy-cert.service'
+    $apiserver_dependencies = $apiserver_dependencies_base + $encryption_dependencies + 'kube-apiserver-proxy-cert.service'
     $requestheader_client_ca_file = "${apiserver_proxy_base_path}-ca.pem"
     $proxy_client_cert_file = "${apiserver_proxy_base_path}.pem"
     $proxy_client_key_file = "${apiserver_proxy_base_path}-key.pem"
   } else {
-    $apiserver_dependencies = $apiserver_dependencies_base
+    $apiserver_dependencies = $apiserver_dependencies_base + $encryption_dependencies
     $requestheader_client_ca_file = undef
     $proxy_client_cert_file = undef
     $proxy_client_key_file = undef
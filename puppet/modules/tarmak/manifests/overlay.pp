@@ -0,0 +1,22 @@
+# Sets up the pod network using the configured network provider
+class tarmak::overlay {
+  include ::tarmak
+
+  case $::tarmak::kubernetes_network_provider {
+    'cilium': {
+      if $::tarmak::role == 'master' {
+        class { 'kubernetes_addons::cilium':
+          pod_network => $::tarmak::kubernetes_pod_network,
+        }
+      }
+    }
+    'aws-vpc-cni': {
+      if $::tarmak::role == 'master' {
+        class { 'kubernetes_addons::aws_vpc_cni': }
+      }
+    }
+    default: {
+      include ::tarmak::overlay_calico
+    }
+  }
+}
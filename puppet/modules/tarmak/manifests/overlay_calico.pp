@@ -50,5 +50,9 @@ class tarmak::overlay_calico {
     class { 'calico::config': }
     Class['calico::config'] -> class { 'calico::policy_controller': }
     Class['calico::config'] -> class { 'calico::node': }
+
+    if $::tarmak::calico_backend == 'kubernetes' {
+      Class['calico::node'] -> class { 'calico::resources': }
+    }
   }
 }
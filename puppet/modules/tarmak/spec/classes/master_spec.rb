@@ -11,5 +11,21 @@ class{'vault_client': token => 'test-token'}
     it do
       is_expected.to compile
     end
+    it { should_not contain_class('kubernetes::kms_plugin') }
+  end
+
+  context 'with kms plugin' do
+    let(:params) { {'encryption_kms_plugin' => true} }
+    let(:pre_condition) {[
+      """
+class{'vault_client': token => 'test-token'}
+class{'kubernetes::kms_plugin':
+  key_arn => 'arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab',
+  region  => 'eu-west-1',
+}
+"""
+    ]}
+
+    it { should contain_file('/etc/systemd/system/kube-apiserver.service').with_content(/kube-kms-plugin.service/) }
   end
 end
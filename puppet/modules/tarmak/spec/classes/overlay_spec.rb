@@ -0,0 +1,54 @@
+require 'spec_helper'
+
+describe 'tarmak::overlay' do
+  let(:pre_condition) {[
+    """
+class{'vault_client': token => 'test-token'}
+class{'tarmak': role => 'master'}
+include kubernetes::master
+"""
+  ]}
+
+  context 'without params' do
+    it do
+      should contain_class('tarmak::overlay_calico')
+      should_not contain_class('kubernetes_addons::cilium')
+    end
+  end
+
+  context 'with cilium' do
+    let(:pre_condition) {[
+      """
+class{'vault_client': token => 'test-token'}
+class{'tarmak':
+  role                        => 'master',
+  kubernetes_network_provider => 'cilium',
+}
+include kubernetes::master
+"""
+    ]}
+
+    it do
+      should contain_class('kubernetes_addons::cilium').with_pod_network('10.234.0.0/16')
+      should_not contain_class('tarmak::overlay_calico')
+      should_not contain_class('calico')
+    end
+  end
+
+  context 'with aws-vpc-cni on a worker' do
+    let(:pre_condition) {[
+      """
+class{'vault_client': token => 'test-token'}
+class{'tarmak':
+  role                        => 'worker',
+  kubernetes_network_provider => 'aws-vpc-cni',
+}
+"""
+    ]}
+
+    it do
+      should_not contain_class('kubernetes_addons::aws_vpc_cni')
+      should_not contain_class('tarmak::overlay_calico')
+    end
+  end
+end
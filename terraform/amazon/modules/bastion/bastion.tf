@@ -5,8 +5,11 @@ data "template_file" "bastion_user_data" {
     fqdn               = "bastion.${var.public_zone}"
     tarmak_environment = "${var.environment}"
 
-    wing_binary_path = "${var.secrets_bucket}/${var.wing_binary_path}"
-    wing_version     = "${var.wing_version}"
+    wing_binary_path   = "${var.secrets_bucket}/${var.wing_binary_path}"
+    wing_version       = "${var.wing_version}"
+    wing_tls_path      = "${var.secrets_bucket}/bastion/wing"
+    wing_snapshot_path = "${var.secrets_bucket}/bastion/wing-snapshot/snapshot.json"
+    wing_regions       = "${join(",", var.environment_regions)}"
   }
 }
 
@@ -54,7 +57,11 @@ resource "aws_instance" "bastion" {
 
   user_data = "${data.template_file.bastion_user_data.rendered}"
 
-  depends_on = ["aws_iam_role_policy_attachment.bastion_tagging_control_lambda_invoke"]
+  depends_on = [
+    "aws_iam_role_policy_attachment.bastion_tagging_control_lambda_invoke",
+    "aws_iam_role_policy_attachment.bastion_wing_tls_read",
+    "aws_iam_role_policy_attachment.bastion_wing_snapshot",
+  ]
 }
 
 resource "awstag_ec2_tag" "Name" {
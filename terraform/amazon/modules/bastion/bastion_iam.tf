@@ -40,3 +40,57 @@ resource "aws_iam_role_policy_attachment" "bastion_tagging_control_lambda_invoke
   role       = "${aws_iam_role.bastion.name}"
   policy_arn = "${var.tagging_control_policy_arn}"
 }
+
+data "template_file" "wing_tls_read" {
+  template = "${file("${path.module}/templates/wing_tls_read.json")}"
+
+  vars {
+    wing_tls_path = "${var.secrets_bucket}/bastion/wing/*"
+  }
+}
+
+resource "aws_iam_policy" "wing_tls_read" {
+  name   = "${data.template_file.stack_name.rendered}.wing_tls_read"
+  path   = "/"
+  policy = "${data.template_file.wing_tls_read.rendered}"
+}
+
+resource "aws_iam_role_policy_attachment" "bastion_wing_tls_read" {
+  role       = "${aws_iam_role.bastion.name}"
+  policy_arn = "${aws_iam_policy.wing_tls_read.arn}"
+}
+
+# wing backs up its state to survive replacing the bastion
+data "template_file" "wing_snapshot" {
+  template = "${file("${path.module}/templates/wing_snapshot.json")}"
+
+  # listing the prefix is required to tell a missing snapshot from a denied
+  # request
+  vars {
+    secrets_bucket       = "${var.secrets_bucket}"
+    wing_snapshot_prefix = "bastion/wing-snapshot/"
+  }
+}
+
+resource "aws_iam_policy" "wing_snapshot" {
+  name   = "${data.template_file.stack_name.rendered}.wing_snapshot"
+  path   = "/"
+  policy = "${data.template_file.wing_snapshot.rendered}"
+}
+
+resource "aws_iam_role_policy_attachment" "bastion_wing_snapshot" {
+  role       = "${aws_iam_role.bastion.name}"
+  policy_arn = "${aws_iam_policy.wing_snapshot.arn}"
+}
+
+# wing verifies instances and garbage collects vanished instances
+resource "aws_iam_policy" "wing_describe_instances" {
+  name   = "${data.template_file.stack_name.rendered}.wing_describe_instances"
+  path   = "/"
+  policy = "${file("${path.module}/templates/wing_describe_instances.json")}"
+}
+
+resource "aws_iam_role_policy_attachment" "bastion_wing_describe_instances" {
+  role       = "${aws_iam_role.bastion.name}"
+  policy_arn = "${aws_iam_policy.wing_describe_instances.arn}"
+}
@@ -33,10 +33,18 @@ variable "public_zone_id" {}
 # data.terraform_remote_state.network.private_zone_id.0
 variable "private_zone_id" {}
 
+# data.terraform_remote_state.network.private_zone.0
+variable "private_zone" {}
+
 variable "bastion_iam_additional_policy_arns" {
   type = "list"
 }
 
 variable "secrets_bucket" {}
 
+# regions of the environment's clusters, instances are accepted from by wing
+variable "environment_regions" {
+  type = "list"
+}
+
 variable "tagging_control_policy_arn" {}
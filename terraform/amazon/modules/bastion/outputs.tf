@@ -25,3 +25,7 @@ output "remote_admin_security_group_id" {
 output "wing_binary_read_policy_arn" {
   value = "${aws_iam_policy.wing_binary_read.arn}"
 }
+
+output "wing_ca" {
+  value = "${tls_self_signed_cert.wing_ca.cert_pem}"
+}
@@ -0,0 +1,103 @@
+# Wing CA, signs the server certificate and all instance client certificates
+resource "tls_private_key" "wing_ca" {
+  algorithm = "RSA"
+  rsa_bits  = "4096"
+}
+
+resource "tls_self_signed_cert" "wing_ca" {
+  key_algorithm   = "${tls_private_key.wing_ca.algorithm}"
+  private_key_pem = "${tls_private_key.wing_ca.private_key_pem}"
+
+  subject {
+    common_name = "Wing ${var.environment} CA"
+  }
+
+  is_ca_certificate = true
+
+  # 10 years
+  validity_period_hours = 87660
+
+  allowed_uses = [
+    "key_encipherment",
+    "digital_signature",
+    "cert_signing",
+  ]
+}
+
+# Wing server cert
+resource "tls_private_key" "wing_server" {
+  algorithm = "RSA"
+  rsa_bits  = "2048"
+}
+
+resource "tls_cert_request" "wing_server" {
+  key_algorithm   = "${tls_private_key.wing_server.algorithm}"
+  private_key_pem = "${tls_private_key.wing_server.private_key_pem}"
+
+  subject {
+    common_name = "bastion.${var.environment}"
+  }
+
+  dns_names = [
+    "bastion.${var.environment}.${var.private_zone}",
+    "localhost",
+  ]
+
+  ip_addresses = [
+    "127.0.0.1",
+  ]
+}
+
+resource "tls_locally_signed_cert" "wing_server" {
+  cert_request_pem = "${tls_cert_request.wing_server.cert_request_pem}"
+
+  ca_key_algorithm   = "${tls_self_signed_cert.wing_ca.key_algorithm}"
+  ca_private_key_pem = "${tls_private_key.wing_ca.private_key_pem}"
+  ca_cert_pem        = "${tls_self_signed_cert.wing_ca.cert_pem}"
+
+  # 1 year
+  validity_period_hours = 8766
+
+  # mark the certificate for renewal 30 days before expiry
+  early_renewal_hours = 720
+
+  allowed_uses = [
+    "key_encipherment",
+    "digital_signature",
+    "server_auth",
+  ]
+}
+
+# The wing PKI is stored outside of the wing-* prefix, which is readable by all
+# instances
+resource "aws_s3_bucket_object" "wing_ca_cert" {
+  key                    = "bastion/wing/ca.pem"
+  bucket                 = "${var.secrets_bucket}"
+  content                = "${tls_self_signed_cert.wing_ca.cert_pem}"
+  content_type           = "text/plain"
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_bucket_object" "wing_ca_key" {
+  key                    = "bastion/wing/ca-key.pem"
+  bucket                 = "${var.secrets_bucket}"
+  content                = "${tls_private_key.wing_ca.private_key_pem}"
+  content_type           = "text/plain"
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_bucket_object" "wing_server_cert" {
+  key                    = "bastion/wing/server.pem"
+  bucket                 = "${var.secrets_bucket}"
+  content                = "${tls_locally_signed_cert.wing_server.cert_pem}"
+  content_type           = "text/plain"
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_bucket_object" "wing_server_key" {
+  key                    = "bastion/wing/server-key.pem"
+  bucket                 = "${var.secrets_bucket}"
+  content                = "${tls_private_key.wing_server.private_key_pem}"
+  content_type           = "text/plain"
+  server_side_encryption = "AES256"
+}
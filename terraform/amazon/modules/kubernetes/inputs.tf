@@ -66,9 +66,15 @@ variable "api_private_admin_cidrs" {
 
 variable "vpc_id" {}
 
+# network of clusters in another region than the hub
+variable "network" {
+  default = ""
+}
+
 variable "private_zone_id" {}
 
 variable "vault_ca" {}
+variable "wing_ca" {}
 
 variable "vault_url" {}
 
@@ -27,6 +27,11 @@ variable "allowed_account_ids" {
 
 variable "vpc_peer_stack" {}
 
+# region of the peer VPC, if it is not in the same region
+variable "peer_region" {
+  default = ""
+}
+
 variable "environment" {}
 
 variable "private_zone" {}
@@ -44,7 +44,7 @@ output "private_zone_id" {
 
 # remove trailing dots from the name
 output "private_zone" {
-  value = "${list(replace(aws_route53_zone.private.0.name, "/\\.$/", ""), "")}"
+  value = "${list(replace(element(concat(aws_route53_zone.private.*.name, list("")), 0), "/\\.$/", ""), "")}"
 }
 
 output "environment" {
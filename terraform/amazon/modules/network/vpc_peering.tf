@@ -1,5 +1,5 @@
 resource "aws_vpc_peering_connection" "peering" {
-  count       = "${signum(length(var.vpc_peer_stack))}"
+  count       = "${signum(length(var.vpc_peer_stack)) * (1 - signum(length(var.peer_region)))}"
   peer_vpc_id = "${var.peer_vpc_id}"
   vpc_id      = "${aws_vpc.main.0.id}"
   auto_accept = true
@@ -13,32 +13,69 @@ resource "aws_vpc_peering_connection" "peering" {
   }
 }
 
+# peering connections to another region have to be accepted in the region of
+# the peer VPC
+resource "aws_vpc_peering_connection" "cross_region" {
+  count       = "${signum(length(var.vpc_peer_stack)) * signum(length(var.peer_region))}"
+  peer_vpc_id = "${var.peer_vpc_id}"
+  peer_region = "${var.peer_region}"
+  vpc_id      = "${aws_vpc.main.0.id}"
+
+  tags {
+    Name        = "${data.template_file.stack_name.rendered}"
+    Environment = "${var.environment}"
+    Project     = "${var.project}"
+    Contact     = "${var.contact}"
+  }
+}
+
+resource "aws_vpc_peering_connection_accepter" "cross_region" {
+  provider                  = "aws.hub"
+  count                     = "${signum(length(var.vpc_peer_stack)) * signum(length(var.peer_region))}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.cross_region.id}"
+  auto_accept               = true
+
+  tags {
+    Name        = "${data.template_file.stack_name.rendered}"
+    Environment = "${var.environment}"
+    Project     = "${var.project}"
+    Contact     = "${var.contact}"
+  }
+}
+
+locals {
+  vpc_peering_connection_id = "${element(concat(aws_vpc_peering_connection.peering.*.id, aws_vpc_peering_connection_accepter.cross_region.*.id, list("")), 0)}"
+}
+
 resource "aws_route" "myself_peering_private" {
   count                     = "${signum(length(var.vpc_peer_stack))*length(var.availability_zones)}"
   route_table_id            = "${aws_route_table.private.*.id[count.index]}"
   destination_cidr_block    = "${var.vpc_net}"
-  vpc_peering_connection_id = "${aws_vpc_peering_connection.peering.id}"
+  vpc_peering_connection_id = "${local.vpc_peering_connection_id}"
 }
 
 resource "aws_route" "myself_peering_public" {
   count                     = "${signum(length(var.vpc_peer_stack))}"
   route_table_id            = "${aws_route_table.public.id}"
   destination_cidr_block    = "${var.vpc_net}"
-  vpc_peering_connection_id = "${aws_vpc_peering_connection.peering.id}"
+  vpc_peering_connection_id = "${local.vpc_peering_connection_id}"
 }
 
+# the routes of the peer VPC are created in its region
 resource "aws_route" "them_peering_public" {
+  provider                  = "aws.hub"
   count                     = "${signum(length(var.vpc_peer_stack))}"
   route_table_id            = "${var.route_table_public_ids[0]}"
   destination_cidr_block    = "${var.network}"
-  vpc_peering_connection_id = "${aws_vpc_peering_connection.peering.id}"
+  vpc_peering_connection_id = "${local.vpc_peering_connection_id}"
 }
 
 resource "aws_route" "them_peering_private" {
-  count                     = "${signum(length(var.vpc_peer_stack))*length(var.availability_zones)}"
+  provider                  = "aws.hub"
+  count                     = "${signum(length(var.vpc_peer_stack))*length(var.route_table_private_ids)}"
   route_table_id            = "${var.route_table_private_ids[count.index]}"
   destination_cidr_block    = "${var.network}"
-  vpc_peering_connection_id = "${aws_vpc_peering_connection.peering.id}"
+  vpc_peering_connection_id = "${local.vpc_peering_connection_id}"
 }
 
 resource "aws_route53_zone_association" "hub_zone" {
@@ -89,4 +89,5 @@ locals {
 }
 
 variable "wing_binary_read_policy_arn" {}
+variable "wing_ca" {}
 variable "tagging_control_policy_arn" {}
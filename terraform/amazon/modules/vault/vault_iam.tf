@@ -57,10 +57,12 @@ data "template_file" "iam_vault_tarmak_bucket_read" {
   count    = "${var.vault_min_instance_count}"
 
   vars {
-    puppet_tar_gz_bucket_path    = "${var.secrets_bucket}/${aws_s3_bucket_object.latest-puppet-hash.key}"
-    puppet_tar_gz_bucket_postfix = "${var.secrets_bucket}/${data.template_file.stack_name.rendered}/puppet-manifests/*-puppet.tar.gz"
-    wing_binary_path             = "${var.secrets_bucket}/${data.template_file.stack_name.rendered}/wing-*"
-    vault_unsealer_kms_key_id    = "${var.vault_kms_key_id}"
+    secrets_bucket                         = "${var.secrets_bucket}"
+    puppet_tar_gz_bucket_path              = "${var.secrets_bucket}/${aws_s3_bucket_object.latest-puppet-hash.key}"
+    puppet_tar_gz_bucket_postfix           = "${var.secrets_bucket}/${data.template_file.stack_name.rendered}/puppet-manifests/*-puppet.tar.gz"
+    puppet_tar_gz_signature_bucket_postfix = "${var.secrets_bucket}/${data.template_file.stack_name.rendered}/puppet-manifests/*-puppet.tar.gz.sig"
+    wing_binary_path                       = "${var.secrets_bucket}/${data.template_file.stack_name.rendered}/wing-*"
+    vault_unsealer_kms_key_id              = "${var.vault_kms_key_id}"
   }
 }
 